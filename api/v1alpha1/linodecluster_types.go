@@ -112,6 +112,53 @@ type NetworkSpec struct {
 	// NodeBalancerConfigID is the config ID of api server NodeBalancer.
 	// +optional
 	NodeBalancerConfigID *int `json:"nodeBalancerConfigID,omitempty"`
+	// NodeBalancerBackendAddressSource is the address the control plane machines are registered with as backends of
+	// the api server NodeBalancer, defaults to PrivateIP. VPC requires the VPC of the cluster to be set and the
+	// NodeBalancerVPCBackends feature gate to be enabled.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	// +optional
+	NodeBalancerBackendAddressSource NodeBalancerBackendAddressSource `json:"nodeBalancerBackendAddressSource,omitempty"`
+	// VPC is the VPC of the cluster. The cluster controller creates a LinodeVPC with the name of the LinodeCluster
+	// from it, sets VPCRef to it, and deletes it with the LinodeCluster. It cannot be set with a VPCRef to another
+	// LinodeVPC.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	// +optional
+	VPC *ClusterVPCSpec `json:"vpc,omitempty"`
+	// VLAN is a VLAN every machine of the cluster is attached to, with an address allocated from its CIDR.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	// +optional
+	VLAN *ClusterVLANSpec `json:"vlan,omitempty"`
+}
+
+// NodeBalancerBackendAddressSource is the source of the addresses of the backends of the api server NodeBalancer.
+// +kubebuilder:validation:Enum=PrivateIP;VPC
+type NodeBalancerBackendAddressSource string
+
+const (
+	// NodeBalancerBackendAddressPrivateIP registers the backends with the private IPv4 address of their instance.
+	NodeBalancerBackendAddressPrivateIP NodeBalancerBackendAddressSource = "PrivateIP"
+	// NodeBalancerBackendAddressVPC registers the backends with the IPv4 address of the VPC interface of their instance.
+	NodeBalancerBackendAddressVPC NodeBalancerBackendAddressSource = "VPC"
+)
+
+// ClusterVLANSpec defines the VLAN the machines of a LinodeCluster are attached to
+type ClusterVLANSpec struct {
+	// Label is the label of the VLAN.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	Label string `json:"label"`
+	// CIDR is the IPv4 range the addresses of the machines are allocated from, e.g. 192.168.0.0/24.
+	CIDR string `json:"cidr"`
+}
+
+// ClusterVPCSpec defines the VPC created for a LinodeCluster
+type ClusterVPCSpec struct {
+	// Description is the description of the VPC.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Subnets are the subnets of the VPC.
+	// +optional
+	Subnets []VPCSubnetCreateOptions `json:"subnets,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -18,18 +18,25 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
+	"net/netip"
 	"slices"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/component-base/featuregate"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	. "github.com/linode/cluster-api-provider-linode/clients"
+	"github.com/linode/cluster-api-provider-linode/feature"
 )
 
 // log is for logging in this package.
@@ -37,13 +44,15 @@ var linodeclusterlog = logf.Log.WithName("linodecluster-resource")
 
 // SetupWebhookWithManager will setup the manager to manage the webhooks
 func (r *LinodeCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	defaultK8sClient = mgr.GetClient()
+
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
 }
 
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable updation and deletion validation.
-//+kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1alpha1-linodecluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=linodeclusters,verbs=create,versions=v1alpha1,name=vlinodecluster.kb.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1alpha1-linodecluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=linodeclusters,verbs=create;update,versions=v1alpha1,name=vlinodecluster.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Validator = &LinodeCluster{}
 
@@ -54,15 +63,22 @@ func (r *LinodeCluster) ValidateCreate() (admission.Warnings, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultWebhookTimeout)
 	defer cancel()
 
-	return nil, r.validateLinodeCluster(ctx, &defaultLinodeClient)
+	return nil, r.validateLinodeCluster(ctx, defaultLinodeClient, defaultK8sClient)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *LinodeCluster) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	linodeclusterlog.Info("validate update", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object update.
-	return nil, nil
+	oldCluster, ok := old.(*LinodeCluster)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a LinodeCluster but got a %T", old))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultWebhookTimeout)
+	defer cancel()
+
+	return nil, r.validateLinodeClusterUpdate(ctx, oldCluster, feature.Gates, defaultK8sClient)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -73,12 +89,53 @@ func (r *LinodeCluster) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
-func (r *LinodeCluster) validateLinodeCluster(ctx context.Context, client LinodeClient) error {
+func (r *LinodeCluster) validateLinodeCluster(ctx context.Context, linodeClient LinodeClient, k8sClient client.Reader) error {
+	var errs field.ErrorList
+
+	if err := r.validateLinodeClusterFeatures(feature.Gates); err != nil {
+		errs = slices.Concat(errs, err)
+	}
+	if err := r.validateLinodeClusterSpec(ctx, linodeClient); err != nil {
+		errs = slices.Concat(errs, err)
+	}
+	if err := r.validateLinodeClusterVPC(ctx, k8sClient); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "infrastructure.cluster.x-k8s.io", Kind: "LinodeCluster"},
+		r.Name, errs)
+}
+
+// validateLinodeClusterUpdate validates the fields set since old. The fields which did not change are not validated
+// again, so that a LinodeCluster accepted before, e.g. before a feature gate was disabled, can still be updated by the
+// controllers.
+func (r *LinodeCluster) validateLinodeClusterUpdate(ctx context.Context, old *LinodeCluster, gates featuregate.FeatureGate, k8sClient client.Reader) error {
 	var errs field.ErrorList
 
-	if err := r.validateLinodeClusterSpec(ctx, client); err != nil {
+	changed := &LinodeCluster{ObjectMeta: r.ObjectMeta}
+	changed.Spec.Region = r.Spec.Region
+	// The VPCRef of an inline VPC is set by the cluster controller
+	if r.Spec.VPCRef != nil && !equality.Semantic.DeepEqual(r.Spec.VPCRef, old.Spec.VPCRef) &&
+		(r.Spec.Network.VPC == nil || !r.isInlineVPCRef(r.Spec.VPCRef)) {
+		changed.Spec.VPCRef = r.Spec.VPCRef
+	}
+	if !equality.Semantic.DeepEqual(r.Spec.Network.VPC, old.Spec.Network.VPC) {
+		changed.Spec.Network.VPC = r.Spec.Network.VPC
+	}
+	if r.Spec.Network.NodeBalancerBackendAddressSource != old.Spec.Network.NodeBalancerBackendAddressSource {
+		changed.Spec.Network.NodeBalancerBackendAddressSource = r.Spec.Network.NodeBalancerBackendAddressSource
+	}
+
+	if err := changed.validateLinodeClusterFeatures(gates); err != nil {
 		errs = slices.Concat(errs, err)
 	}
+	if err := changed.validateLinodeClusterVPC(ctx, k8sClient); err != nil {
+		errs = append(errs, err)
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -91,9 +148,91 @@ func (r *LinodeCluster) validateLinodeCluster(ctx context.Context, client Linode
 func (r *LinodeCluster) validateLinodeClusterSpec(ctx context.Context, client LinodeClient) field.ErrorList {
 	var errs field.ErrorList
 
-	if err := validateRegion(ctx, client, r.Spec.Region, field.NewPath("spec").Child("region")); err != nil {
+	var capabilities []string
+	if r.Spec.VPCRef != nil || r.Spec.Network.VPC != nil {
+		capabilities = append(capabilities, LinodeVPCCapability)
+	}
+	if err := validateRegion(ctx, client, r.Spec.Region, field.NewPath("spec").Child("region"), capabilities...); err != nil {
 		errs = append(errs, err)
 	}
+	if r.Spec.Network.VLAN != nil {
+		if err := validateVLANCIDR(r.Spec.Network.VLAN.CIDR, field.NewPath("spec").Child("network").Child("vlan").Child("cidr")); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if r.Spec.Network.NodeBalancerBackendAddressSource == NodeBalancerBackendAddressVPC && r.Spec.VPCRef == nil && r.Spec.Network.VPC == nil {
+		errs = append(errs, field.Invalid(
+			field.NewPath("spec").Child("network").Child("nodeBalancerBackendAddressSource"),
+			r.Spec.Network.NodeBalancerBackendAddressSource,
+			"requires spec.vpcRef or spec.network.vpc"))
+	}
+	// The VPCRef of a LinodeCluster with an inline VPC is set by the cluster controller, and kept when the cluster is
+	// moved or restored from a backup
+	if r.Spec.Network.VPC != nil && r.Spec.VPCRef != nil && !r.isInlineVPCRef(r.Spec.VPCRef) {
+		errs = append(errs, field.Forbidden(field.NewPath("spec").Child("network").Child("vpc"), fmt.Sprintf("cannot be set with a spec.vpcRef to another LinodeVPC than %s", r.Name)))
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// isInlineVPCRef returns true if vpcRef references the LinodeVPC created for the inline VPC of the cluster, which is
+// named after the cluster.
+func (r *LinodeCluster) isInlineVPCRef(vpcRef *corev1.ObjectReference) bool {
+	return vpcRef.Name == r.Name &&
+		(vpcRef.Namespace == "" || vpcRef.Namespace == r.Namespace) &&
+		(vpcRef.Kind == "" || vpcRef.Kind == "LinodeVPC")
+}
+
+// validateLinodeClusterVPC validates that the LinodeVPC referenced by the cluster is in its region. The cluster is not
+// rejected when the LinodeVPC cannot be found yet, e.g. while a cluster is being created.
+func (r *LinodeCluster) validateLinodeClusterVPC(ctx context.Context, k8sClient client.Reader) *field.Error {
+	if r.Spec.VPCRef == nil || k8sClient == nil {
+		return nil
+	}
+
+	linodeVPC, err := getLinodeVPC(ctx, k8sClient, r)
+	if err != nil {
+		linodeclusterlog.Info("skipping VPC validation", "name", r.Name, "error", err.Error())
+
+		return nil
+	}
+	if linodeVPC.Spec.Region != r.Spec.Region {
+		return field.Invalid(field.NewPath("spec").Child("vpcRef"), r.Spec.VPCRef.Name, fmt.Sprintf("LinodeVPC %s is in region %s, not %s", linodeVPC.Name, linodeVPC.Spec.Region, r.Spec.Region))
+	}
+
+	return nil
+}
+
+// validateVLANCIDR validates that cidr is an IPv4 range with room for machine addresses besides its network and
+// broadcast addresses.
+func validateVLANCIDR(cidr string, path *field.Path) *field.Error {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil || !prefix.Addr().Is4() || prefix.Masked() != prefix {
+		return field.Invalid(path, cidr, "must be an IPv4 CIDR")
+	}
+	if prefix.Bits() > 30 {
+		return field.Invalid(path, cidr, "must be /30 or larger")
+	}
+
+	return nil
+}
+
+// validateLinodeClusterFeatures rejects the fields of the features disabled in gates.
+func (r *LinodeCluster) validateLinodeClusterFeatures(gates featuregate.FeatureGate) field.ErrorList {
+	var errs field.ErrorList
+
+	if r.Spec.VPCRef != nil && !gates.Enabled(feature.LinodeVPC) {
+		errs = append(errs, featureDisabledError(field.NewPath("spec").Child("vpcRef"), feature.LinodeVPC))
+	}
+	if r.Spec.Network.VPC != nil && !gates.Enabled(feature.LinodeVPC) {
+		errs = append(errs, featureDisabledError(field.NewPath("spec").Child("network").Child("vpc"), feature.LinodeVPC))
+	}
+	if r.Spec.Network.NodeBalancerBackendAddressSource == NodeBalancerBackendAddressVPC && !gates.Enabled(feature.NodeBalancerVPCBackends) {
+		errs = append(errs, featureDisabledError(field.NewPath("spec").Child("network").Child("nodeBalancerBackendAddressSource"), feature.NodeBalancerVPCBackends))
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -21,10 +21,17 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/linode/linodego"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/linode/cluster-api-provider-linode/feature"
 	"github.com/linode/cluster-api-provider-linode/mock"
 
 	. "github.com/linode/cluster-api-provider-linode/mock/mocktest"
@@ -52,7 +59,39 @@ func TestValidateLinodeCluster(t *testing.T) {
 					mck.LinodeClient.EXPECT().GetRegion(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 				}),
 				Result("success", func(ctx context.Context, mck Mock) {
-					assert.NoError(t, cluster.validateLinodeCluster(ctx, mck.LinodeClient))
+					assert.NoError(t, cluster.validateLinodeCluster(ctx, mck.LinodeClient, nil))
+				}),
+			),
+			Path(
+				Call("inline VPC with its VPCRef", func(ctx context.Context, mck Mock) {
+					mck.LinodeClient.EXPECT().GetRegion(gomock.Any(), gomock.Any()).Return(&linodego.Region{Capabilities: []string{LinodeVPCCapability}}, nil).AnyTimes()
+				}),
+				Result("success", func(ctx context.Context, mck Mock) {
+					cluster := cluster
+					cluster.Spec.VPCRef = &corev1.ObjectReference{Kind: "LinodeVPC", Name: "example", Namespace: "example"}
+					cluster.Spec.Network.VPC = &ClusterVPCSpec{}
+					assert.NoError(t, cluster.validateLinodeCluster(ctx, mck.LinodeClient, nil))
+				}),
+			),
+			Path(
+				Call("inline VPC with another VPCRef", func(ctx context.Context, mck Mock) {
+					mck.LinodeClient.EXPECT().GetRegion(gomock.Any(), gomock.Any()).Return(&linodego.Region{Capabilities: []string{LinodeVPCCapability}}, nil).AnyTimes()
+				}),
+				Result("error", func(ctx context.Context, mck Mock) {
+					cluster := cluster
+					cluster.Spec.VPCRef = &corev1.ObjectReference{Name: "other"}
+					cluster.Spec.Network.VPC = &ClusterVPCSpec{}
+					assert.ErrorContains(t, cluster.validateLinodeCluster(ctx, mck.LinodeClient, nil), "spec.network.vpc: Forbidden: cannot be set with a spec.vpcRef to another LinodeVPC than example")
+				}),
+			),
+			Path(
+				Call("VPC backends without VPC", func(ctx context.Context, mck Mock) {
+					mck.LinodeClient.EXPECT().GetRegion(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+				}),
+				Result("error", func(ctx context.Context, mck Mock) {
+					cluster := cluster
+					cluster.Spec.Network.NodeBalancerBackendAddressSource = NodeBalancerBackendAddressVPC
+					assert.ErrorContains(t, cluster.validateLinodeCluster(ctx, mck.LinodeClient, nil), `spec.network.nodeBalancerBackendAddressSource: Invalid value: "VPC": requires spec.vpcRef or spec.network.vpc`)
 				}),
 			),
 		),
@@ -62,7 +101,222 @@ func TestValidateLinodeCluster(t *testing.T) {
 			})),
 		),
 		Result("error", func(ctx context.Context, mck Mock) {
-			assert.Error(t, cluster.validateLinodeCluster(ctx, mck.LinodeClient))
+			assert.Error(t, cluster.validateLinodeCluster(ctx, mck.LinodeClient, nil))
 		}),
 	)
 }
+
+func TestValidateLinodeClusterFeatures(t *testing.T) {
+	t.Parallel()
+
+	cluster := LinodeCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "example"},
+		Spec: LinodeClusterSpec{
+			Region: "example",
+			VPCRef: &corev1.ObjectReference{Name: "example"},
+		},
+	}
+
+	gates := feature.NewGates()
+	assert.Empty(t, cluster.validateLinodeClusterFeatures(gates))
+
+	require.NoError(t, gates.Set("LinodeVPC=false"))
+	errs := cluster.validateLinodeClusterFeatures(gates)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.vpcRef", errs[0].Field)
+	assert.Contains(t, errs[0].Detail, "LinodeVPC feature gate")
+
+	cluster.Spec.VPCRef = nil
+	cluster.Spec.Network.VPC = &ClusterVPCSpec{}
+	errs = cluster.validateLinodeClusterFeatures(gates)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.network.vpc", errs[0].Field)
+
+	// VPC backends are alpha and disabled by default
+	cluster.Spec.Network.VPC = nil
+	cluster.Spec.Network.NodeBalancerBackendAddressSource = NodeBalancerBackendAddressVPC
+	errs = cluster.validateLinodeClusterFeatures(gates)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.network.nodeBalancerBackendAddressSource", errs[0].Field)
+	assert.Contains(t, errs[0].Detail, "NodeBalancerVPCBackends feature gate")
+
+	require.NoError(t, gates.Set("NodeBalancerVPCBackends=true"))
+	assert.Empty(t, cluster.validateLinodeClusterFeatures(gates))
+}
+
+func TestValidateVLANCIDR(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		cidr        string
+		expectedErr string
+	}{
+		{name: "valid", cidr: "192.168.0.0/24"},
+		{name: "smallest", cidr: "192.168.0.0/30"},
+		{name: "too small", cidr: "192.168.0.0/31", expectedErr: "must be /30 or larger"},
+		{name: "not masked", cidr: "192.168.0.1/24", expectedErr: "must be an IPv4 CIDR"},
+		{name: "IPv6", cidr: "fd00::/64", expectedErr: "must be an IPv4 CIDR"},
+		{name: "invalid", cidr: "example", expectedErr: "must be an IPv4 CIDR"},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateVLANCIDR(testcase.cidr, field.NewPath("spec").Child("network").Child("vlan").Child("cidr"))
+			if testcase.expectedErr == "" {
+				assert.Nil(t, err)
+
+				return
+			}
+			require.NotNil(t, err)
+			assert.Equal(t, "spec.network.vlan.cidr", err.Field)
+			assert.Equal(t, testcase.expectedErr, err.Detail)
+		})
+	}
+}
+
+func TestValidateLinodeClusterVPC(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&LinodeVPC{
+			ObjectMeta: metav1.ObjectMeta{Name: "vpc", Namespace: "default"},
+			Spec:       LinodeVPCSpec{Region: "us-ord"},
+		},
+	).Build()
+
+	tests := []struct {
+		name        string
+		region      string
+		vpcRef      *corev1.ObjectReference
+		expectedErr string
+	}{
+		{
+			name:   "Success - no VPC",
+			region: "us-east",
+		},
+		{
+			name:   "Success - region of the VPC",
+			region: "us-ord",
+			vpcRef: &corev1.ObjectReference{Name: "vpc"},
+		},
+		{
+			name:   "Success - VPC not found",
+			region: "us-east",
+			vpcRef: &corev1.ObjectReference{Name: "missing"},
+		},
+		{
+			name:        "Error - region of another VPC",
+			region:      "us-east",
+			vpcRef:      &corev1.ObjectReference{Name: "vpc", Namespace: "default"},
+			expectedErr: `spec.vpcRef: Invalid value: "vpc": LinodeVPC vpc is in region us-ord, not us-east`,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cluster := LinodeCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+				Spec:       LinodeClusterSpec{Region: testcase.region, VPCRef: testcase.vpcRef},
+			}
+			err := cluster.validateLinodeClusterVPC(context.Background(), k8sClient)
+			if testcase.expectedErr != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, testcase.expectedErr, err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateLinodeClusterUpdate(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&LinodeVPC{
+			ObjectMeta: metav1.ObjectMeta{Name: "vpc", Namespace: "default"},
+			Spec:       LinodeVPCSpec{Region: "us-ord"},
+		},
+	).Build()
+
+	tests := []struct {
+		name        string
+		old         LinodeClusterSpec
+		spec        LinodeClusterSpec
+		gates       string
+		expectedErr string
+	}{
+		{
+			name: "Success - VPC of another region is not validated again",
+			old:  LinodeClusterSpec{Region: "us-east", VPCRef: &corev1.ObjectReference{Name: "vpc"}},
+			spec: LinodeClusterSpec{Region: "us-east", VPCRef: &corev1.ObjectReference{Name: "vpc"}},
+		},
+		{
+			name:  "Success - VPC of a disabled feature is not validated again",
+			old:   LinodeClusterSpec{Region: "us-ord", VPCRef: &corev1.ObjectReference{Name: "vpc"}},
+			spec:  LinodeClusterSpec{Region: "us-ord", VPCRef: &corev1.ObjectReference{Name: "vpc"}},
+			gates: "LinodeVPC=false",
+		},
+		{
+			name:  "Success - VPCRef of an inline VPC is set by the controller",
+			old:   LinodeClusterSpec{Region: "us-east", Network: NetworkSpec{VPC: &ClusterVPCSpec{}}},
+			spec:  LinodeClusterSpec{Region: "us-east", Network: NetworkSpec{VPC: &ClusterVPCSpec{}}, VPCRef: &corev1.ObjectReference{Name: "example"}},
+			gates: "LinodeVPC=false",
+		},
+		{
+			name: "Success - VPC of the region is set",
+			old:  LinodeClusterSpec{Region: "us-ord"},
+			spec: LinodeClusterSpec{Region: "us-ord", VPCRef: &corev1.ObjectReference{Name: "vpc"}},
+		},
+		{
+			name:        "Error - VPC of another region is set",
+			old:         LinodeClusterSpec{Region: "us-east"},
+			spec:        LinodeClusterSpec{Region: "us-east", VPCRef: &corev1.ObjectReference{Name: "vpc"}},
+			expectedErr: "LinodeVPC vpc is in region us-ord, not us-east",
+		},
+		{
+			name:        "Error - VPC is set with the feature disabled",
+			old:         LinodeClusterSpec{Region: "us-ord"},
+			spec:        LinodeClusterSpec{Region: "us-ord", VPCRef: &corev1.ObjectReference{Name: "vpc"}},
+			gates:       "LinodeVPC=false",
+			expectedErr: "spec.vpcRef: Forbidden",
+		},
+		{
+			name:        "Error - VPC backends are set with the feature disabled",
+			old:         LinodeClusterSpec{Region: "us-ord", VPCRef: &corev1.ObjectReference{Name: "vpc"}},
+			spec:        LinodeClusterSpec{Region: "us-ord", VPCRef: &corev1.ObjectReference{Name: "vpc"}, Network: NetworkSpec{NodeBalancerBackendAddressSource: NodeBalancerBackendAddressVPC}},
+			expectedErr: "spec.network.nodeBalancerBackendAddressSource: Forbidden",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			gates := feature.NewGates()
+			if testcase.gates != "" {
+				require.NoError(t, gates.Set(testcase.gates))
+			}
+			old := &LinodeCluster{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}, Spec: testcase.old}
+			cluster := &LinodeCluster{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}, Spec: testcase.spec}
+
+			err := cluster.validateLinodeClusterUpdate(context.Background(), old, gates, k8sClient)
+			if testcase.expectedErr != "" {
+				require.ErrorContains(t, err, testcase.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -65,6 +65,10 @@ type LinodeMachineSpec struct {
 	Tags []string `json:"tags,omitempty"`
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	FirewallID int `json:"firewallID,omitempty"`
+	// VPC configures how the machine is attached to the VPC of its LinodeCluster.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	// +optional
+	VPC *MachineVPCOptions `json:"vpc,omitempty"`
 	// OSDisk is configuration for the root disk that includes the OS,
 	// if not specified this defaults to whatever space is not taken up by the DataDisks
 	OSDisk *InstanceDisk `json:"osDisk,omitempty"`
@@ -82,6 +86,53 @@ type LinodeMachineSpec struct {
 	CredentialsRef *corev1.SecretReference `json:"credentialsRef,omitempty"`
 }
 
+// SubnetPlacementPolicy is how the subnet of a machine is chosen among the subnets of a VPC.
+// +kubebuilder:validation:Enum=LeastUsed;MostUsed;RoundRobin
+type SubnetPlacementPolicy string
+
+const (
+	// SubnetPlacementLeastUsed chooses the subnet with the fewest Linodes attached, spreading machines across subnets.
+	SubnetPlacementLeastUsed SubnetPlacementPolicy = "LeastUsed"
+	// SubnetPlacementMostUsed chooses the subnet with the most Linodes attached, filling subnets one at a time.
+	SubnetPlacementMostUsed SubnetPlacementPolicy = "MostUsed"
+	// SubnetPlacementRoundRobin cycles through the subnets in the order of their IDs as machines are created.
+	SubnetPlacementRoundRobin SubnetPlacementPolicy = "RoundRobin"
+)
+
+// MachineVPCOptions defines how a machine is attached to the VPC of its LinodeCluster
+type MachineVPCOptions struct {
+	// Subnet selects the subnet the machine is attached to. When not set, the subnet is chosen by SubnetPlacement.
+	// +optional
+	Subnet *VPCSubnetSelector `json:"subnet,omitempty"`
+	// SubnetPlacement is how the subnet is chosen among the subnets with available addresses when Subnet is not
+	// set. Defaults to LeastUsed.
+	// +optional
+	SubnetPlacement SubnetPlacementPolicy `json:"subnetPlacement,omitempty"`
+	// IPv4AddressPoolRef is a reference to the IPAM pool the VPC IPv4 address of the machine is claimed from, e.g. an
+	// InClusterIPPool. The addresses of the pool must be in the subnets of the VPC, and the machine is attached to the
+	// subnet containing its address. The address is released when the machine is deleted.
+	// +optional
+	IPv4AddressPoolRef *corev1.TypedLocalObjectReference `json:"ipv4AddressPoolRef,omitempty"`
+	// PodCIDRMaskSize is the prefix length of the range allocated to the machine from the IPv4 pod CIDR of its Cluster,
+	// e.g. 24. The range is routed to the VPC interface of the machine and recorded in its status. No range is allocated
+	// when not set.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=32
+	// +optional
+	PodCIDRMaskSize *int `json:"podCIDRMaskSize,omitempty"`
+}
+
+// VPCSubnetSelector selects a subnet of a VPC by label or ID
+// +kubebuilder:validation:XValidation:rule="has(self.label) != has(self.id)",message="Exactly one of label or id must be set"
+type VPCSubnetSelector struct {
+	// Label is the label of the subnet.
+	// +optional
+	Label string `json:"label,omitempty"`
+	// ID is the ID of the subnet.
+	// +optional
+	ID *int `json:"id,omitempty"`
+}
+
 // InstanceDisk defines a list of disks to use for an instance
 type InstanceDisk struct {
 	// DiskID is the linode assigned ID of the disk
@@ -138,6 +189,15 @@ type LinodeMachineStatus struct {
 	// +optional
 	InstanceState *linodego.InstanceStatus `json:"instanceState,omitempty"`
 
+	// PodCIDR is the range allocated to the machine from the pod CIDR of its Cluster and routed to its VPC interface,
+	// when spec.vpc.podCIDRMaskSize is set.
+	// +optional
+	PodCIDR string `json:"podCIDR,omitempty"`
+
+	// VLANAddress is the address of the machine on the VLAN of its LinodeCluster, in CIDR notation.
+	// +optional
+	VLANAddress string `json:"vlanAddress,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
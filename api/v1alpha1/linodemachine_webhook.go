@@ -22,12 +22,15 @@ import (
 	"slices"
 
 	"github.com/linode/linodego"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -55,6 +58,8 @@ var linodemachinelog = logf.Log.WithName("linodemachine-resource")
 
 // SetupWebhookWithManager will setup the manager to manage the webhooks
 func (r *LinodeMachine) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	defaultK8sClient = mgr.GetClient()
+
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
@@ -63,7 +68,7 @@ func (r *LinodeMachine) SetupWebhookWithManager(mgr ctrl.Manager) error {
 // TODO(user): EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable updation and deletion validation.
-//+kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1alpha1-linodemachine,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=linodemachines,verbs=create,versions=v1alpha1,name=vlinodemachine.kb.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1alpha1-linodemachine,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=linodemachines,verbs=create;update,versions=v1alpha1,name=vlinodemachine.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Validator = &LinodeMachine{}
 
@@ -74,15 +79,22 @@ func (r *LinodeMachine) ValidateCreate() (admission.Warnings, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultWebhookTimeout)
 	defer cancel()
 
-	return nil, r.validateLinodeMachine(ctx, &defaultLinodeClient)
+	return nil, r.validateLinodeMachine(ctx, defaultLinodeClient, defaultK8sClient)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *LinodeMachine) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	linodemachinelog.Info("validate update", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object update.
-	return nil, nil
+	oldMachine, ok := old.(*LinodeMachine)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a LinodeMachine but got a %T", old))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultWebhookTimeout)
+	defer cancel()
+
+	return nil, r.validateLinodeMachineUpdate(ctx, oldMachine, defaultK8sClient)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -93,12 +105,18 @@ func (r *LinodeMachine) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
-func (r *LinodeMachine) validateLinodeMachine(ctx context.Context, client LinodeClient) error {
+func (r *LinodeMachine) validateLinodeMachine(ctx context.Context, linodeClient LinodeClient, k8sClient client.Reader) error {
 	var errs field.ErrorList
 
-	if err := r.validateLinodeMachineSpec(ctx, client); err != nil {
+	if err := r.validateLinodeMachineSpec(ctx, linodeClient); err != nil {
+		errs = slices.Concat(errs, err)
+	}
+	if err := r.validateLinodeMachineRegion(ctx, linodeClient, k8sClient); err != nil {
 		errs = slices.Concat(errs, err)
 	}
+	if err := r.validateLinodeMachineVPC(ctx, k8sClient); err != nil {
+		errs = append(errs, err)
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -128,6 +146,134 @@ func (r *LinodeMachine) validateLinodeMachineSpec(ctx context.Context, client Li
 	return errs
 }
 
+// validateLinodeMachineRegion validates that the machine is in the region of its LinodeCluster and of the LinodeVPC
+// of the LinodeCluster, and that the region supports VPCs if the LinodeCluster has one. Like the subnet, the region
+// is not validated against objects which cannot be found yet.
+func (r *LinodeMachine) validateLinodeMachineRegion(ctx context.Context, linodeClient LinodeClient, k8sClient client.Reader) field.ErrorList {
+	clusterName := r.Labels[clusterv1.ClusterNameLabel]
+	if clusterName == "" || k8sClient == nil {
+		return nil
+	}
+	path := field.NewPath("spec").Child("region")
+
+	linodeCluster, err := getLinodeCluster(ctx, k8sClient, r.Namespace, clusterName)
+	if err != nil || linodeCluster == nil {
+		if err != nil {
+			linodemachinelog.Info("skipping region validation", "name", r.Name, "error", err.Error())
+		}
+
+		return nil
+	}
+
+	var errs field.ErrorList
+	if linodeCluster.Spec.Region != r.Spec.Region {
+		errs = append(errs, field.Invalid(path, r.Spec.Region, fmt.Sprintf("must be the region of LinodeCluster %s: %s", linodeCluster.Name, linodeCluster.Spec.Region)))
+	}
+	if linodeCluster.Spec.VPCRef != nil || linodeCluster.Spec.Network.VPC != nil {
+		if err := validateRegion(ctx, linodeClient, r.Spec.Region, path, LinodeVPCCapability); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if linodeCluster.Spec.VPCRef != nil {
+		linodeVPC, err := getLinodeVPC(ctx, k8sClient, linodeCluster)
+		if err != nil {
+			linodemachinelog.Info("skipping VPC region validation", "name", r.Name, "error", err.Error())
+		} else if linodeVPC.Spec.Region != r.Spec.Region {
+			errs = append(errs, field.Invalid(path, r.Spec.Region, fmt.Sprintf("must be the region of LinodeVPC %s: %s", linodeVPC.Name, linodeVPC.Spec.Region)))
+		}
+	}
+
+	return errs
+}
+
+// validateLinodeMachineVPC validates that the subnet selected by the machine exists on the LinodeVPC referenced by the
+// LinodeCluster of the machine. The machine is not rejected when these objects cannot be found yet, e.g. while a
+// cluster is being created, or when the subnet IDs are not known yet.
+// validateLinodeMachineUpdate validates spec.vpc when it is set after the LinodeMachine is created. The fields which
+// did not change are not validated again, so that the controllers can still update the LinodeMachine.
+func (r *LinodeMachine) validateLinodeMachineUpdate(ctx context.Context, old *LinodeMachine, k8sClient client.Reader) error {
+	if equality.Semantic.DeepEqual(r.Spec.VPC, old.Spec.VPC) {
+		return nil
+	}
+	if err := r.validateLinodeMachineVPC(ctx, k8sClient); err != nil {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: "infrastructure.cluster.x-k8s.io", Kind: "LinodeMachine"},
+			r.Name, field.ErrorList{err})
+	}
+
+	return nil
+}
+
+func (r *LinodeMachine) validateLinodeMachineVPC(ctx context.Context, k8sClient client.Reader) *field.Error {
+	if r.Spec.VPC == nil || r.Spec.VPC.Subnet == nil || k8sClient == nil {
+		return nil
+	}
+	path := field.NewPath("spec").Child("vpc").Child("subnet")
+
+	clusterName := r.Labels[clusterv1.ClusterNameLabel]
+	if clusterName == "" {
+		return nil
+	}
+	linodeCluster, err := getLinodeCluster(ctx, k8sClient, r.Namespace, clusterName)
+	if err != nil || linodeCluster == nil {
+		if err != nil {
+			linodemachinelog.Info("skipping subnet validation", "name", r.Name, "error", err.Error())
+		}
+
+		return nil
+	}
+	if linodeCluster.Spec.VPCRef == nil && linodeCluster.Spec.Network.VPC == nil {
+		return field.Invalid(path.Root().Child("vpc"), r.Spec.VPC, fmt.Sprintf("LinodeCluster %s has no VPC", linodeCluster.Name))
+	}
+
+	selector := r.Spec.VPC.Subnet
+	// The LinodeVPC of an inline VPC is only referenced once the cluster controller creates it
+	if linodeCluster.Spec.VPCRef == nil {
+		if selector.ID != nil {
+			return nil
+		}
+		for _, subnet := range linodeCluster.Spec.Network.VPC.Subnets {
+			if subnet.Label == selector.Label {
+				return nil
+			}
+		}
+
+		return field.NotFound(path.Child("label"), selector.Label)
+	}
+	linodeVPC, err := getLinodeVPC(ctx, k8sClient, linodeCluster)
+	if err != nil {
+		linodemachinelog.Info("skipping subnet validation", "name", r.Name, "error", err.Error())
+
+		return nil
+	}
+
+	if selector.ID != nil {
+		// Subnet IDs are only known once the VPC is created
+		if len(linodeVPC.Status.Subnets) == 0 {
+			return nil
+		}
+		for _, subnet := range linodeVPC.Status.Subnets {
+			if subnet.ID == *selector.ID {
+				return nil
+			}
+		}
+
+		return field.NotFound(path.Child("id"), *selector.ID)
+	}
+	for _, subnet := range linodeVPC.Spec.Subnets {
+		if subnet.Label == selector.Label {
+			return nil
+		}
+	}
+	for _, subnet := range linodeVPC.Status.Subnets {
+		if subnet.Label == selector.Label {
+			return nil
+		}
+	}
+
+	return field.NotFound(path.Child("label"), selector.Label)
+}
+
 func (r *LinodeMachine) validateLinodeMachineDisks(plan *linodego.LinodeType) *field.Error {
 	// The Linode plan information is required to perform disk validation
 	if plan == nil {
@@ -25,9 +25,15 @@ import (
 
 	"github.com/linode/linodego"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/linode/cluster-api-provider-linode/mock"
 
@@ -63,7 +69,7 @@ func TestValidateLinodeMachine(t *testing.T) {
 					mck.LinodeClient.EXPECT().GetType(gomock.Any(), gomock.Any()).Return(&plan_max, nil).AnyTimes()
 				}),
 				Result("success", func(ctx context.Context, mck Mock) {
-					assert.NoError(t, machine.validateLinodeMachine(ctx, mck.LinodeClient))
+					assert.NoError(t, machine.validateLinodeMachine(ctx, mck.LinodeClient, nil))
 				}),
 				Call("valid with disks", func(ctx context.Context, mck Mock) {
 					mck.LinodeClient.EXPECT().GetRegion(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
@@ -73,7 +79,7 @@ func TestValidateLinodeMachine(t *testing.T) {
 					machine := machine
 					machine.Spec.OSDisk = disk.DeepCopy()
 					machine.Spec.DataDisks = map[string]*InstanceDisk{"sdb": disk.DeepCopy()}
-					assert.NoError(t, machine.validateLinodeMachine(ctx, mck.LinodeClient))
+					assert.NoError(t, machine.validateLinodeMachine(ctx, mck.LinodeClient, nil))
 				}),
 			),
 		),
@@ -88,7 +94,7 @@ func TestValidateLinodeMachine(t *testing.T) {
 			})),
 		),
 		Result("error", func(ctx context.Context, mck Mock) {
-			assert.Error(t, machine.validateLinodeMachine(ctx, mck.LinodeClient))
+			assert.Error(t, machine.validateLinodeMachine(ctx, mck.LinodeClient, nil))
 		}),
 		OneOf(
 			Path(
@@ -99,7 +105,7 @@ func TestValidateLinodeMachine(t *testing.T) {
 				Result("os disk too large", func(ctx context.Context, mck Mock) {
 					machine := machine
 					machine.Spec.OSDisk = disk.DeepCopy()
-					assert.ErrorContains(t, machine.validateLinodeMachine(ctx, mck.LinodeClient), strconv.Itoa(plan_zero.Disk))
+					assert.ErrorContains(t, machine.validateLinodeMachine(ctx, mck.LinodeClient, nil), strconv.Itoa(plan_zero.Disk))
 				}),
 			),
 			Path(
@@ -111,7 +117,7 @@ func TestValidateLinodeMachine(t *testing.T) {
 					machine := machine
 					machine.Spec.OSDisk = disk.DeepCopy()
 					machine.Spec.DataDisks = map[string]*InstanceDisk{"sdb": disk.DeepCopy(), "sdc": disk.DeepCopy()}
-					assert.Error(t, machine.validateLinodeMachine(ctx, mck.LinodeClient))
+					assert.Error(t, machine.validateLinodeMachine(ctx, mck.LinodeClient, nil))
 				}),
 			),
 			Path(
@@ -122,7 +128,7 @@ func TestValidateLinodeMachine(t *testing.T) {
 				Result("error", func(ctx context.Context, mck Mock) {
 					machine := machine
 					machine.Spec.DataDisks = map[string]*InstanceDisk{"sda": disk.DeepCopy()}
-					assert.Error(t, machine.validateLinodeMachine(ctx, mck.LinodeClient))
+					assert.Error(t, machine.validateLinodeMachine(ctx, mck.LinodeClient, nil))
 				}),
 			),
 			Path(
@@ -133,9 +139,307 @@ func TestValidateLinodeMachine(t *testing.T) {
 				Result("error", func(ctx context.Context, mck Mock) {
 					machine := machine
 					machine.Spec.OSDisk = disk_zero.DeepCopy()
-					assert.Error(t, machine.validateLinodeMachine(ctx, mck.LinodeClient))
+					assert.Error(t, machine.validateLinodeMachine(ctx, mck.LinodeClient, nil))
 				}),
 			),
 		),
 	)
 }
+
+func TestValidateLinodeMachineVPC(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	require.NoError(t, AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"},
+			Spec: clusterv1.ClusterSpec{
+				InfrastructureRef: &corev1.ObjectReference{Kind: "LinodeCluster", Name: "cluster"},
+			},
+		},
+		&LinodeCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"},
+			Spec:       LinodeClusterSpec{VPCRef: &corev1.ObjectReference{Kind: "LinodeVPC", Name: "vpc"}},
+		},
+		&LinodeVPC{
+			ObjectMeta: metav1.ObjectMeta{Name: "vpc", Namespace: "default"},
+			Spec: LinodeVPCSpec{
+				Subnets: []VPCSubnetCreateOptions{{Label: "workers", IPv4: "10.0.0.0/24"}},
+			},
+			Status: LinodeVPCStatus{
+				Subnets: []VPCSubnetStatus{{Label: "workers", ID: 7, IPv4: "10.0.0.0/24"}},
+			},
+		},
+		&clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "vpcless", Namespace: "default"},
+			Spec: clusterv1.ClusterSpec{
+				InfrastructureRef: &corev1.ObjectReference{Kind: "LinodeCluster", Name: "vpcless"},
+			},
+		},
+		&LinodeCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "vpcless", Namespace: "default"},
+		},
+		&clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "inline", Namespace: "default"},
+			Spec: clusterv1.ClusterSpec{
+				InfrastructureRef: &corev1.ObjectReference{Kind: "LinodeCluster", Name: "inline"},
+			},
+		},
+		&LinodeCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "inline", Namespace: "default"},
+			Spec: LinodeClusterSpec{
+				Network: NetworkSpec{
+					VPC: &ClusterVPCSpec{Subnets: []VPCSubnetCreateOptions{{Label: "workers", IPv4: "10.0.0.0/24"}}},
+				},
+			},
+		},
+	).Build()
+
+	tests := []struct {
+		name        string
+		cluster     string
+		subnet      *VPCSubnetSelector
+		expectedErr string
+	}{
+		{
+			name:    "Success - no subnet selected",
+			cluster: "cluster",
+		},
+		{
+			name:    "Success - subnet selected by label",
+			cluster: "cluster",
+			subnet:  &VPCSubnetSelector{Label: "workers"},
+		},
+		{
+			name:    "Success - subnet selected by ID",
+			cluster: "cluster",
+			subnet:  &VPCSubnetSelector{ID: ptr.To(7)},
+		},
+		{
+			name:    "Success - cluster not found",
+			cluster: "missing",
+			subnet:  &VPCSubnetSelector{Label: "missing"},
+		},
+		{
+			name:        "Error - subnet label not found",
+			cluster:     "cluster",
+			subnet:      &VPCSubnetSelector{Label: "missing"},
+			expectedErr: `spec.vpc.subnet.label: Not found: "missing"`,
+		},
+		{
+			name:        "Error - subnet ID not found",
+			cluster:     "cluster",
+			subnet:      &VPCSubnetSelector{ID: ptr.To(8)},
+			expectedErr: "spec.vpc.subnet.id: Not found: 8",
+		},
+		{
+			name:    "Success - subnet of an inline VPC selected by label",
+			cluster: "inline",
+			subnet:  &VPCSubnetSelector{Label: "workers"},
+		},
+		{
+			name:    "Success - subnet of an inline VPC selected by ID",
+			cluster: "inline",
+			subnet:  &VPCSubnetSelector{ID: ptr.To(7)},
+		},
+		{
+			name:        "Error - subnet label of an inline VPC not found",
+			cluster:     "inline",
+			subnet:      &VPCSubnetSelector{Label: "missing"},
+			expectedErr: `spec.vpc.subnet.label: Not found: "missing"`,
+		},
+		{
+			name:        "Error - cluster has no VPC",
+			cluster:     "vpcless",
+			subnet:      &VPCSubnetSelector{Label: "workers"},
+			expectedErr: "LinodeCluster vpcless has no VPC",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			machine := LinodeMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "example",
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.ClusterNameLabel: testcase.cluster},
+				},
+				Spec: LinodeMachineSpec{
+					VPC: &MachineVPCOptions{Subnet: testcase.subnet},
+				},
+			}
+			err := machine.validateLinodeMachineVPC(context.Background(), k8sClient)
+			if testcase.expectedErr != "" {
+				require.ErrorContains(t, err, testcase.expectedErr)
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateLinodeMachineUpdate(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	require.NoError(t, AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "vpcless", Namespace: "default"},
+			Spec: clusterv1.ClusterSpec{
+				InfrastructureRef: &corev1.ObjectReference{Kind: "LinodeCluster", Name: "vpcless"},
+			},
+		},
+		&LinodeCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "vpcless", Namespace: "default"},
+		},
+	).Build()
+
+	tests := []struct {
+		name        string
+		old         *MachineVPCOptions
+		vpc         *MachineVPCOptions
+		expectedErr string
+	}{
+		{
+			name: "Success - subnet is not validated again",
+			old:  &MachineVPCOptions{Subnet: &VPCSubnetSelector{Label: "workers"}},
+			vpc:  &MachineVPCOptions{Subnet: &VPCSubnetSelector{Label: "workers"}},
+		},
+		{
+			name:        "Error - subnet is set",
+			vpc:         &MachineVPCOptions{Subnet: &VPCSubnetSelector{Label: "workers"}},
+			expectedErr: "LinodeCluster vpcless has no VPC",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			objectMeta := metav1.ObjectMeta{
+				Name:      "example",
+				Namespace: "default",
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: "vpcless"},
+			}
+			old := &LinodeMachine{ObjectMeta: objectMeta, Spec: LinodeMachineSpec{VPC: testcase.old}}
+			machine := &LinodeMachine{ObjectMeta: objectMeta, Spec: LinodeMachineSpec{VPC: testcase.vpc}}
+
+			err := machine.validateLinodeMachineUpdate(context.Background(), old, k8sClient)
+			if testcase.expectedErr != "" {
+				require.ErrorContains(t, err, testcase.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateLinodeMachineRegion(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	require.NoError(t, AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"},
+			Spec: clusterv1.ClusterSpec{
+				InfrastructureRef: &corev1.ObjectReference{Kind: "LinodeCluster", Name: "cluster"},
+			},
+		},
+		&LinodeCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"},
+			Spec: LinodeClusterSpec{
+				Region: "us-east",
+				VPCRef: &corev1.ObjectReference{Kind: "LinodeVPC", Name: "vpc"},
+			},
+		},
+		&LinodeVPC{
+			ObjectMeta: metav1.ObjectMeta{Name: "vpc", Namespace: "default"},
+			Spec:       LinodeVPCSpec{Region: "us-ord"},
+		},
+		&clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "vpcless", Namespace: "default"},
+			Spec: clusterv1.ClusterSpec{
+				InfrastructureRef: &corev1.ObjectReference{Kind: "LinodeCluster", Name: "vpcless"},
+			},
+		},
+		&LinodeCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "vpcless", Namespace: "default"},
+			Spec:       LinodeClusterSpec{Region: "us-east"},
+		},
+	).Build()
+
+	tests := []struct {
+		name         string
+		cluster      string
+		region       string
+		capabilities []string
+		expectedErrs []string
+	}{
+		{
+			name:    "Success - region of the cluster",
+			cluster: "vpcless",
+			region:  "us-east",
+		},
+		{
+			name:    "Success - cluster not found",
+			cluster: "missing",
+			region:  "us-ord",
+		},
+		{
+			name:         "Error - region of another cluster",
+			cluster:      "vpcless",
+			region:       "us-ord",
+			expectedErrs: []string{`spec.region: Invalid value: "us-ord": must be the region of LinodeCluster vpcless: us-east`},
+		},
+		{
+			name:         "Error - region of another VPC",
+			cluster:      "cluster",
+			region:       "us-east",
+			capabilities: []string{LinodeVPCCapability},
+			expectedErrs: []string{`spec.region: Invalid value: "us-east": must be the region of LinodeVPC vpc: us-ord`},
+		},
+		{
+			name:    "Error - region without VPCs",
+			cluster: "cluster",
+			region:  "us-ord",
+			expectedErrs: []string{
+				`spec.region: Invalid value: "us-ord": must be the region of LinodeCluster cluster: us-east`,
+				`spec.region: Invalid value: "us-ord": no capability: VPCs`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			linodeClient := mock.NewMockLinodeClient(gomock.NewController(t))
+			linodeClient.EXPECT().GetRegion(gomock.Any(), testcase.region).Return(&linodego.Region{Capabilities: testcase.capabilities}, nil).AnyTimes()
+
+			machine := LinodeMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "example",
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.ClusterNameLabel: testcase.cluster},
+				},
+				Spec: LinodeMachineSpec{Region: testcase.region},
+			}
+			errs := machine.validateLinodeMachineRegion(context.Background(), linodeClient, k8sClient)
+			require.Len(t, errs, len(testcase.expectedErrs))
+			for i, err := range errs {
+				assert.Equal(t, testcase.expectedErrs[i], err.Error())
+			}
+		})
+	}
+}
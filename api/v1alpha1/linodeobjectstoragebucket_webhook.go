@@ -61,7 +61,7 @@ func (r *LinodeObjectStorageBucket) ValidateCreate() (admission.Warnings, error)
 	ctx, cancel := context.WithTimeout(context.Background(), defaultWebhookTimeout)
 	defer cancel()
 
-	return nil, r.validateLinodeObjectStorageBucket(ctx, &defaultLinodeClient)
+	return nil, r.validateLinodeObjectStorageBucket(ctx, defaultLinodeClient)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -27,13 +27,24 @@ import (
 
 // LinodeVPCSpec defines the desired state of LinodeVPC
 type LinodeVPCSpec struct {
+	// VPCID is the ID of the VPC. An existing VPC is adopted when set on creation.
 	// +optional
 	VPCID *int `json:"vpcID,omitempty"`
+	// Label is the label of the VPC. An existing VPC with this label is adopted. Defaults to the name of the LinodeVPC.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	// +optional
+	Label string `json:"label,omitempty"`
+	// DeletionPolicy is what happens to the VPC when the LinodeVPC is deleted: it is deleted, or retained, e.g. for a
+	// VPC shared with other clusters. Defaults to Delete for the VPCs created by CAPL and to Retain for adopted VPCs.
+	// +optional
+	DeletionPolicy VPCDeletionPolicy `json:"deletionPolicy,omitempty"`
 	// +optional
 	Description string `json:"description,omitempty"`
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	Region string `json:"region"`
-	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	// Subnets are the subnets of the VPC, identified by their label. Subnets can be added and removed, but the IPv4
+	// range of a subnet cannot change. Only the subnets created by CAPL are removed from the VPC, unless it is
+	// retained or Linodes are attached to them. The subnets of the VPC are left alone when empty.
 	// +optional
 	Subnets []VPCSubnetCreateOptions `json:"subnets,omitempty"`
 
@@ -43,6 +54,17 @@ type LinodeVPCSpec struct {
 	CredentialsRef *corev1.SecretReference `json:"credentialsRef,omitempty"`
 }
 
+// VPCDeletionPolicy is what happens to a VPC when its LinodeVPC is deleted.
+// +kubebuilder:validation:Enum=Delete;Retain
+type VPCDeletionPolicy string
+
+const (
+	// VPCDeletionPolicyDelete deletes the VPC with the LinodeVPC, even if it was adopted.
+	VPCDeletionPolicyDelete VPCDeletionPolicy = "Delete"
+	// VPCDeletionPolicyRetain leaves the VPC and its subnets in place when the LinodeVPC is deleted.
+	VPCDeletionPolicyRetain VPCDeletionPolicy = "Retain"
+)
+
 // VPCSubnetCreateOptions defines subnet options
 type VPCSubnetCreateOptions struct {
 	// +kubebuilder:validation:MinLength=3
@@ -101,6 +123,39 @@ type LinodeVPCStatus struct {
 	// Conditions defines current service state of the LinodeVPC.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// Subnets are the subnets of the VPC as last observed, refreshed periodically.
+	// +optional
+	Subnets []VPCSubnetStatus `json:"subnets,omitempty"`
+
+	// Created is true when the VPC was created by CAPL rather than adopted.
+	// +optional
+	Created bool `json:"created,omitempty"`
+
+	// CreatedSubnetIDs are the IDs of the subnets created by CAPL. Only these subnets are deleted from the VPC when
+	// they are removed from spec.subnets.
+	// +optional
+	CreatedSubnetIDs []int `json:"createdSubnetIDs,omitempty"`
+}
+
+// VPCSubnetStatus is the observed state of a subnet of a VPC.
+type VPCSubnetStatus struct {
+	// Label is the label of the subnet.
+	Label string `json:"label"`
+
+	// ID is the ID of the subnet.
+	ID int `json:"id"`
+
+	// IPv4 is the IPv4 range of the subnet.
+	// +optional
+	IPv4 string `json:"ipv4,omitempty"`
+
+	// LinodeIDs are the IDs of the Linodes attached to the subnet.
+	// +optional
+	LinodeIDs []int `json:"linodeIDs,omitempty"`
+
+	// AvailableIPv4Addresses is the number of IPv4 addresses of the subnet which can still be assigned to Linodes.
+	AvailableIPv4Addresses int `json:"availableIPv4Addresses"`
 }
 
 // +kubebuilder:object:root=true
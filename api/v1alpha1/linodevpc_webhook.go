@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/netip"
 	"regexp"
 	"slices"
@@ -30,12 +31,16 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/component-base/featuregate"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	. "github.com/linode/cluster-api-provider-linode/clients"
+	"github.com/linode/cluster-api-provider-linode/feature"
+	"github.com/linode/cluster-api-provider-linode/util"
 )
 
 var (
@@ -76,13 +81,15 @@ var linodevpclog = logf.Log.WithName("linodevpc-resource")
 
 // SetupWebhookWithManager will setup the manager to manage the webhooks
 func (r *LinodeVPC) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	defaultK8sClient = mgr.GetClient()
+
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
 }
 
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable updation and deletion validation.
-//+kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1alpha1-linodevpc,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=linodevpcs,verbs=create,versions=v1alpha1,name=vlinodevpc.kb.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1alpha1-linodevpc,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=linodevpcs,verbs=create;update,versions=v1alpha1,name=vlinodevpc.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Validator = &LinodeVPC{}
 
@@ -93,15 +100,19 @@ func (r *LinodeVPC) ValidateCreate() (admission.Warnings, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultWebhookTimeout)
 	defer cancel()
 
-	return nil, r.validateLinodeVPC(ctx, &defaultLinodeClient)
+	return r.adoptionWarnings(), r.validateLinodeVPC(ctx, defaultLinodeClient, defaultAccountLinodeClient, defaultK8sClient)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *LinodeVPC) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	linodevpclog.Info("validate update", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object update.
-	return nil, nil
+	oldVPC, ok := old.(*LinodeVPC)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a LinodeVPC but got a %T", old))
+	}
+
+	return nil, r.validateLinodeVPCUpdate(oldVPC)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -112,10 +123,37 @@ func (r *LinodeVPC) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
-func (r *LinodeVPC) validateLinodeVPC(ctx context.Context, client LinodeClient) error {
+func (r *LinodeVPC) validateLinodeVPC(ctx context.Context, linodeClient, accountClient LinodeClient, k8sClient client.Reader) error {
+	var errs field.ErrorList
+
+	if err := r.validateLinodeVPCFeatures(feature.Gates); err != nil {
+		errs = slices.Concat(errs, err)
+	}
+	if err := r.validateLinodeVPCSpec(ctx, linodeClient); err != nil {
+		errs = slices.Concat(errs, err)
+	}
+	if err := r.validateLinodeVPCID(ctx, accountClient); err != nil {
+		errs = append(errs, err)
+	}
+	if err := r.validateLinodeVPCClusters(ctx, k8sClient); err != nil {
+		errs = slices.Concat(errs, err)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "infrastructure.cluster.x-k8s.io", Kind: "LinodeVPC"},
+		r.Name, errs)
+}
+
+func (r *LinodeVPC) validateLinodeVPCUpdate(old *LinodeVPC) error {
 	var errs field.ErrorList
 
-	if err := r.validateLinodeVPCSpec(ctx, client); err != nil {
+	if err := r.validateLinodeVPCSubnets(); err != nil {
+		errs = slices.Concat(errs, err)
+	}
+	if err := r.validateLinodeVPCSubnetUpdates(old); err != nil {
 		errs = slices.Concat(errs, err)
 	}
 
@@ -133,6 +171,11 @@ func (r *LinodeVPC) validateLinodeVPCSpec(ctx context.Context, client LinodeClie
 	if err := validateRegion(ctx, client, r.Spec.Region, field.NewPath("spec").Child("region"), LinodeVPCCapability); err != nil {
 		errs = append(errs, err)
 	}
+	if r.Spec.Label != "" {
+		if err := validateVPCLabel(r.Spec.Label, field.NewPath("spec").Child("label")); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	if err := r.validateLinodeVPCSubnets(); err != nil {
 		errs = slices.Concat(errs, err)
 	}
@@ -143,6 +186,76 @@ func (r *LinodeVPC) validateLinodeVPCSpec(ctx context.Context, client LinodeClie
 	return errs
 }
 
+// validateLinodeVPCID validates that the VPC adopted by ID exists and is in the region of the LinodeVPC. VPCs adopted
+// with credentialsRef belong to another account and are only checked by the controller.
+func (r *LinodeVPC) validateLinodeVPCID(ctx context.Context, accountClient LinodeClient) *field.Error {
+	if r.Spec.VPCID == nil || r.Spec.CredentialsRef != nil || accountClient == nil {
+		return nil
+	}
+
+	path := field.NewPath("spec").Child("vpcID")
+	vpc, err := accountClient.GetVPC(ctx, *r.Spec.VPCID)
+	if util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
+		linodevpclog.Info("skipping VPC ID validation", "name", r.Name, "error", err.Error())
+
+		return nil
+	} else if err != nil {
+		return field.NotFound(path, *r.Spec.VPCID)
+	}
+	if vpc.Region != r.Spec.Region {
+		return field.Invalid(path, *r.Spec.VPCID, fmt.Sprintf("VPC is in region %s, not %s", vpc.Region, r.Spec.Region))
+	}
+
+	return nil
+}
+
+// validateLinodeVPCClusters validates that the LinodeClusters referencing the LinodeVPC, e.g. clusters created before
+// the LinodeVPC, are in its region.
+func (r *LinodeVPC) validateLinodeVPCClusters(ctx context.Context, k8sClient client.Reader) field.ErrorList {
+	if k8sClient == nil {
+		return nil
+	}
+
+	var linodeClusters LinodeClusterList
+	if err := k8sClient.List(ctx, &linodeClusters, client.InNamespace(r.Namespace)); err != nil {
+		linodevpclog.Info("skipping cluster validation", "name", r.Name, "error", err.Error())
+
+		return nil
+	}
+
+	var errs field.ErrorList
+	for _, linodeCluster := range linodeClusters.Items {
+		vpcRef := linodeCluster.Spec.VPCRef
+		if vpcRef == nil || vpcRef.Name != r.Name || (vpcRef.Namespace != "" && vpcRef.Namespace != r.Namespace) {
+			continue
+		}
+		if linodeCluster.Spec.Region != r.Spec.Region {
+			errs = append(errs, field.Invalid(field.NewPath("spec").Child("region"), r.Spec.Region, fmt.Sprintf("must be the region of LinodeCluster %s: %s", linodeCluster.Name, linodeCluster.Spec.Region)))
+		}
+	}
+
+	return errs
+}
+
+// adoptionWarnings warns that an existing VPC adopted by ID will be deleted with the LinodeVPC because its deletion
+// policy is explicitly Delete. Adopted VPCs are retained by default.
+func (r *LinodeVPC) adoptionWarnings() admission.Warnings {
+	if r.Spec.VPCID == nil || r.Spec.DeletionPolicy != VPCDeletionPolicyDelete {
+		return nil
+	}
+
+	return admission.Warnings{fmt.Sprintf("VPC %d will be deleted with the LinodeVPC, unset spec.deletionPolicy to keep it", *r.Spec.VPCID)}
+}
+
+// validateLinodeVPCFeatures rejects LinodeVPCs when the LinodeVPC feature is disabled in gates.
+func (r *LinodeVPC) validateLinodeVPCFeatures(gates featuregate.FeatureGate) field.ErrorList {
+	if !gates.Enabled(feature.LinodeVPC) {
+		return field.ErrorList{featureDisabledError(field.NewPath("spec"), feature.LinodeVPC)}
+	}
+
+	return nil
+}
+
 func (r *LinodeVPC) validateLinodeVPCSubnets() field.ErrorList {
 	var (
 		errs    field.ErrorList
@@ -190,6 +303,29 @@ func (r *LinodeVPC) validateLinodeVPCSubnets() field.ErrorList {
 	return errs
 }
 
+// validateLinodeVPCSubnetUpdates rejects changes to the IPv4 range of the subnets of old, which the Linode API cannot
+// update. The overlap of the ranges is validated by validateLinodeVPCSubnets.
+func (r *LinodeVPC) validateLinodeVPCSubnetUpdates(old *LinodeVPC) field.ErrorList {
+	var errs field.ErrorList
+
+	for i, subnet := range r.Spec.Subnets {
+		j := slices.IndexFunc(old.Spec.Subnets, func(oldSubnet VPCSubnetCreateOptions) bool {
+			return oldSubnet.Label == subnet.Label
+		})
+		if j < 0 || old.Spec.Subnets[j].IPv4 == subnet.IPv4 {
+			continue
+		}
+		errs = append(errs, field.Forbidden(field.NewPath("spec").Child("Subnets").Index(i).Child("IPv4"),
+			fmt.Sprintf("range of subnet %s cannot change from %s, remove the subnet and add it again with a new label",
+				subnet.Label, old.Spec.Subnets[j].IPv4)))
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
 // TODO: Replace the OpenAPI schema validation for .metadata.name.
 //
 // validateVPCLabel validates a label string is a valid [Linode VPC Label].
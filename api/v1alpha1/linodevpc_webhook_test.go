@@ -19,14 +19,22 @@ package v1alpha1
 import (
 	"context"
 	"errors"
+	"net/http"
 	"slices"
 	"testing"
 
 	"github.com/linode/linodego"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/linode/cluster-api-provider-linode/feature"
 	"github.com/linode/cluster-api-provider-linode/mock"
 
 	. "github.com/linode/cluster-api-provider-linode/mock/mocktest"
@@ -59,7 +67,7 @@ func TestValidateLinodeVPC(t *testing.T) {
 					mck.LinodeClient.EXPECT().GetRegion(gomock.Any(), gomock.Any()).Return(&region, nil).AnyTimes()
 				}),
 				Result("success", func(ctx context.Context, mck Mock) {
-					assert.NoError(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient))
+					assert.NoError(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient, nil, nil))
 				}),
 			),
 			Path(
@@ -71,7 +79,7 @@ func TestValidateLinodeVPC(t *testing.T) {
 				Result("success", func(ctx context.Context, mck Mock) {
 					vpc := vpc
 					vpc.Spec.Subnets = []VPCSubnetCreateOptions{{Label: "foo", IPv4: "10.0.0.0/24"}, {Label: "bar", IPv4: "10.0.1.0/24"}}
-					assert.NoError(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient))
+					assert.NoError(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient, nil, nil))
 				}),
 			),
 		),
@@ -86,7 +94,7 @@ func TestValidateLinodeVPC(t *testing.T) {
 			})),
 		),
 		Result("error", func(ctx context.Context, mck Mock) {
-			assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient))
+			assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient, nil, nil))
 		}),
 		OneOf(
 			Path(
@@ -98,7 +106,7 @@ func TestValidateLinodeVPC(t *testing.T) {
 				Result("error", func(ctx context.Context, mck Mock) {
 					vpc := vpc
 					vpc.Spec.Subnets = []VPCSubnetCreateOptions{{IPv4: "10.0.0.0/8"}}
-					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient))
+					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient, nil, nil))
 				}),
 			),
 			Path(
@@ -111,7 +119,7 @@ func TestValidateLinodeVPC(t *testing.T) {
 					vpc := vpc
 					vpc.Spec.Subnets = []VPCSubnetCreateOptions{{Label: "$", IPv4: "10.0.0.0/8"}}
 
-					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient))
+					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient, nil, nil))
 				}),
 			),
 			Path(
@@ -123,7 +131,7 @@ func TestValidateLinodeVPC(t *testing.T) {
 				Result("error", func(ctx context.Context, mck Mock) {
 					vpc := vpc
 					vpc.Spec.Subnets = []VPCSubnetCreateOptions{{Label: "--", IPv4: "10.0.0.0/8"}}
-					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient))
+					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient, nil, nil))
 				}),
 			),
 
@@ -136,7 +144,7 @@ func TestValidateLinodeVPC(t *testing.T) {
 				Result("error", func(ctx context.Context, mck Mock) {
 					vpc := vpc
 					vpc.Spec.Subnets = []VPCSubnetCreateOptions{{Label: "test", IPv4: "IPv4 CIDR"}}
-					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient))
+					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient, nil, nil))
 				}),
 			),
 			Path(
@@ -148,7 +156,7 @@ func TestValidateLinodeVPC(t *testing.T) {
 				Result("error", func(ctx context.Context, mck Mock) {
 					vpc := vpc
 					vpc.Spec.Subnets = []VPCSubnetCreateOptions{{Label: "test", IPv4: "10.9.9.9/8"}}
-					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient))
+					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient, nil, nil))
 				}),
 			),
 			Path(
@@ -160,7 +168,7 @@ func TestValidateLinodeVPC(t *testing.T) {
 				Result("error", func(ctx context.Context, mck Mock) {
 					vpc := vpc
 					vpc.Spec.Subnets = []VPCSubnetCreateOptions{{Label: "test", IPv4: "10.0.0.0/32"}}
-					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient))
+					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient, nil, nil))
 				}),
 			),
 			Path(
@@ -173,7 +181,7 @@ func TestValidateLinodeVPC(t *testing.T) {
 					vpc := vpc
 					vpc.Spec.Subnets = []VPCSubnetCreateOptions{{Label: "test", IPv4: "9.9.9.0/24"}}
 
-					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient))
+					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient, nil, nil))
 				}),
 			),
 			Path(
@@ -185,7 +193,7 @@ func TestValidateLinodeVPC(t *testing.T) {
 				Result("error", func(ctx context.Context, mck Mock) {
 					vpc := vpc
 					vpc.Spec.Subnets = []VPCSubnetCreateOptions{{Label: "test", IPv4: "192.168.128.0/24"}}
-					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient))
+					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient, nil, nil))
 				}),
 			),
 			Path(
@@ -197,7 +205,7 @@ func TestValidateLinodeVPC(t *testing.T) {
 				Result("error", func(ctx context.Context, mck Mock) {
 					vpc := vpc
 					vpc.Spec.Subnets = []VPCSubnetCreateOptions{{Label: "test", IPv4: "10.255.255.1/24"}, {Label: "test", IPv4: "10.255.255.0/24"}}
-					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient))
+					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient, nil, nil))
 				}),
 			),
 			Path(
@@ -209,9 +217,223 @@ func TestValidateLinodeVPC(t *testing.T) {
 				Result("error", func(ctx context.Context, mck Mock) {
 					vpc := vpc
 					vpc.Spec.Subnets = []VPCSubnetCreateOptions{{Label: "foo", IPv4: "10.0.0.0/8"}, {Label: "bar", IPv4: "10.0.0.0/24"}}
-					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient))
+					assert.Error(t, vpc.validateLinodeVPC(ctx, mck.LinodeClient, nil, nil))
 				}),
 			),
 		),
 	)
 }
+
+func TestValidateLinodeVPCFeatures(t *testing.T) {
+	t.Parallel()
+
+	vpc := LinodeVPC{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "example"}}
+
+	gates := feature.NewGates()
+	assert.Empty(t, vpc.validateLinodeVPCFeatures(gates))
+
+	require.NoError(t, gates.Set("LinodeVPC=false"))
+	errs := vpc.validateLinodeVPCFeatures(gates)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec", errs[0].Field)
+}
+
+func TestValidateLinodeVPCUpdate(t *testing.T) {
+	t.Parallel()
+
+	old := LinodeVPC{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "example"},
+		Spec: LinodeVPCSpec{
+			Region:  "example",
+			Subnets: []VPCSubnetCreateOptions{{Label: "foo", IPv4: "10.0.0.0/24"}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		subnets     []VPCSubnetCreateOptions
+		expectedErr string
+	}{
+		{
+			name:    "Success - subnet added",
+			subnets: []VPCSubnetCreateOptions{{Label: "foo", IPv4: "10.0.0.0/24"}, {Label: "bar", IPv4: "10.0.1.0/24"}},
+		},
+		{
+			name: "Success - subnet removed",
+		},
+		{
+			name:    "Success - subnet replaced",
+			subnets: []VPCSubnetCreateOptions{{Label: "bar", IPv4: "10.0.0.0/25"}},
+		},
+		{
+			name:        "Error - added subnet overlaps",
+			subnets:     []VPCSubnetCreateOptions{{Label: "foo", IPv4: "10.0.0.0/24"}, {Label: "bar", IPv4: "10.0.0.128/25"}},
+			expectedErr: "range must not overlap with other subnets on the same vpc",
+		},
+		{
+			name:        "Error - subnet range changed",
+			subnets:     []VPCSubnetCreateOptions{{Label: "foo", IPv4: "10.0.1.0/24"}},
+			expectedErr: "range of subnet foo cannot change from 10.0.0.0/24",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			vpc := old.DeepCopy()
+			vpc.Spec.Subnets = testcase.subnets
+			_, err := vpc.ValidateUpdate(&old)
+			if testcase.expectedErr != "" {
+				require.ErrorContains(t, err, testcase.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLinodeVPCAdoptionWarnings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		spec     LinodeVPCSpec
+		expected admission.Warnings
+	}{
+		{
+			name: "Success - created VPC",
+			spec: LinodeVPCSpec{DeletionPolicy: VPCDeletionPolicyDelete},
+		},
+		{
+			name: "Success - adopted VPC is retained",
+			spec: LinodeVPCSpec{VPCID: ptr.To(5), DeletionPolicy: VPCDeletionPolicyRetain},
+		},
+		{
+			name: "Success - adopted VPC is retained by default",
+			spec: LinodeVPCSpec{VPCID: ptr.To(5)},
+		},
+		{
+			name:     "Warning - adopted VPC is deleted",
+			spec:     LinodeVPCSpec{VPCID: ptr.To(5), DeletionPolicy: VPCDeletionPolicyDelete},
+			expected: admission.Warnings{"VPC 5 will be deleted with the LinodeVPC, unset spec.deletionPolicy to keep it"},
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			vpc := LinodeVPC{Spec: testcase.spec}
+			assert.Equal(t, testcase.expected, vpc.adoptionWarnings())
+		})
+	}
+}
+
+func TestValidateLinodeVPCID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		spec        LinodeVPCSpec
+		expects     func(client *mock.MockLinodeClient)
+		expectedErr string
+	}{
+		{
+			name:    "Success - created VPC",
+			spec:    LinodeVPCSpec{Region: "us-east"},
+			expects: func(client *mock.MockLinodeClient) {},
+		},
+		{
+			name: "Success - adopted VPC",
+			spec: LinodeVPCSpec{VPCID: ptr.To(5), Region: "us-east"},
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().GetVPC(gomock.Any(), 5).Return(&linodego.VPC{ID: 5, Region: "us-east"}, nil)
+			},
+		},
+		{
+			name:    "Success - VPC of another account",
+			spec:    LinodeVPCSpec{VPCID: ptr.To(5), Region: "us-east", CredentialsRef: &corev1.SecretReference{Name: "example"}},
+			expects: func(client *mock.MockLinodeClient) {},
+		},
+		{
+			name: "Success - API error",
+			spec: LinodeVPCSpec{VPCID: ptr.To(5), Region: "us-east"},
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().GetVPC(gomock.Any(), 5).Return(nil, &linodego.Error{Code: http.StatusInternalServerError})
+			},
+		},
+		{
+			name: "Error - VPC not found",
+			spec: LinodeVPCSpec{VPCID: ptr.To(5), Region: "us-east"},
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().GetVPC(gomock.Any(), 5).Return(nil, &linodego.Error{Code: http.StatusNotFound})
+			},
+			expectedErr: "spec.vpcID: Not found: 5",
+		},
+		{
+			name: "Error - VPC in another region",
+			spec: LinodeVPCSpec{VPCID: ptr.To(5), Region: "us-east"},
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().GetVPC(gomock.Any(), 5).Return(&linodego.VPC{ID: 5, Region: "us-ord"}, nil)
+			},
+			expectedErr: "spec.vpcID: Invalid value: 5: VPC is in region us-ord, not us-east",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			client := mock.NewMockLinodeClient(mockCtrl)
+			testcase.expects(client)
+
+			vpc := LinodeVPC{ObjectMeta: metav1.ObjectMeta{Name: "example"}, Spec: testcase.spec}
+			err := vpc.validateLinodeVPCID(context.Background(), client)
+			if testcase.expectedErr != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, testcase.expectedErr, err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateLinodeVPCClusters(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&LinodeCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"},
+			Spec: LinodeClusterSpec{
+				Region: "us-east",
+				VPCRef: &corev1.ObjectReference{Kind: "LinodeVPC", Name: "vpc"},
+			},
+		},
+		&LinodeCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-cluster", Namespace: "default"},
+			Spec: LinodeClusterSpec{
+				Region: "us-ord",
+				VPCRef: &corev1.ObjectReference{Kind: "LinodeVPC", Name: "other-vpc"},
+			},
+		},
+	).Build()
+
+	vpc := LinodeVPC{
+		ObjectMeta: metav1.ObjectMeta{Name: "vpc", Namespace: "default"},
+		Spec:       LinodeVPCSpec{Region: "us-east"},
+	}
+	assert.Empty(t, vpc.validateLinodeVPCClusters(context.Background(), k8sClient))
+
+	vpc.Spec.Region = "us-ord"
+	errs := vpc.validateLinodeVPCClusters(context.Background(), k8sClient)
+	require.Len(t, errs, 1)
+	assert.Equal(t, `spec.region: Invalid value: "us-ord": must be the region of LinodeCluster cluster: us-east`, errs[0].Error())
+}
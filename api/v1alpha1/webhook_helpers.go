@@ -10,8 +10,15 @@ import (
 
 	"github.com/linode/linodego"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/component-base/featuregate"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	. "github.com/linode/cluster-api-provider-linode/clients"
+	"github.com/linode/cluster-api-provider-linode/clients/cache"
+	"github.com/linode/cluster-api-provider-linode/observability/metrics"
+	"github.com/linode/cluster-api-provider-linode/observability/tracing"
+	"github.com/linode/cluster-api-provider-linode/util"
 )
 
 const (
@@ -22,10 +29,60 @@ const (
 )
 
 var (
+	// defaultLinodeAPIClient is the unauthenticated Linode client underlying defaultLinodeClient
+	defaultLinodeAPIClient = util.Pointer(linodego.NewClient(&http.Client{Timeout: defaultClientTimeout}))
+
 	// defaultLinodeClient is an unauthenticated Linode client
-	defaultLinodeClient = linodego.NewClient(&http.Client{Timeout: defaultClientTimeout})
+	defaultLinodeClient = newDefaultLinodeClient(cache.Endpoint("", ""))
+
+	// defaultAccountLinodeClient reads the resources of the account of the controller's Linode API token, such as the
+	// VPCs adopted by LinodeVPCs. It is nil until set with SetDefaultAccountLinodeClient, and the validations which
+	// need it are skipped.
+	defaultAccountLinodeClient LinodeClient
+
+	// defaultK8sClient reads the objects referenced by the validated objects. It is nil until the webhooks are set up
+	// with a manager, and the validations which need it are skipped.
+	defaultK8sClient client.Reader
 )
 
+// SetDefaultLinodeClientEndpoint points the unauthenticated Linode client used by the webhooks at the given API URL
+// and version. Empty values keep the linodego defaults. It must be called once, before the webhooks are started.
+func SetDefaultLinodeClientEndpoint(baseURL, apiVersion string) {
+	if baseURL != "" {
+		defaultLinodeAPIClient.SetBaseURL(baseURL)
+	}
+	if apiVersion != "" {
+		defaultLinodeAPIClient.SetAPIVersion(apiVersion)
+	}
+	defaultLinodeClient = newDefaultLinodeClient(cache.Endpoint(baseURL, apiVersion))
+}
+
+// newDefaultLinodeClient wraps defaultLinodeAPIClient, which talks to the given endpoint, with the layers shared by
+// the webhooks.
+func newDefaultLinodeClient(endpoint string) LinodeClient {
+	return tracing.NewLinodeClient(
+		cache.NewLinodeClient(
+			metrics.NewLinodeClient(
+				defaultLinodeAPIClient,
+				metrics.ReconcilerWebhook,
+			),
+			cache.DefaultCatalog,
+			endpoint,
+		),
+	)
+}
+
+// SetDefaultAccountLinodeClient sets the Linode client used by the webhooks to read the resources of the account of the
+// controller. It must be called before the webhooks are started.
+func SetDefaultAccountLinodeClient(client LinodeClient) {
+	defaultAccountLinodeClient = client
+}
+
+// featureDisabledError returns the error for a field of a feature disabled with the --feature-gates flag.
+func featureDisabledError(path *field.Path, name featuregate.Feature) *field.Error {
+	return field.Forbidden(path, fmt.Sprintf("requires the %s feature gate to be enabled", name))
+}
+
 func validateRegion(ctx context.Context, client LinodeClient, id string, path *field.Path, capabilities ...string) *field.Error {
 	region, err := client.GetRegion(ctx, id)
 	if err != nil {
@@ -41,6 +98,42 @@ func validateRegion(ctx context.Context, client LinodeClient, id string, path *f
 	return nil
 }
 
+// getLinodeCluster returns the LinodeCluster of the Cluster named clusterName, or nil if the Cluster has none.
+func getLinodeCluster(ctx context.Context, k8sClient client.Reader, namespace, clusterName string) (*LinodeCluster, error) {
+	var cluster clusterv1.Cluster
+	if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: clusterName}, &cluster); err != nil {
+		return nil, err
+	}
+	infraRef := cluster.Spec.InfrastructureRef
+	if infraRef == nil || infraRef.Kind != "LinodeCluster" {
+		return nil, nil
+	}
+	var linodeCluster LinodeCluster
+	if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: infraRef.Name}, &linodeCluster); err != nil {
+		return nil, err
+	}
+
+	return &linodeCluster, nil
+}
+
+// getLinodeVPC returns the LinodeVPC referenced by the LinodeCluster, or nil if it references none.
+func getLinodeVPC(ctx context.Context, k8sClient client.Reader, linodeCluster *LinodeCluster) (*LinodeVPC, error) {
+	vpcRef := linodeCluster.Spec.VPCRef
+	if vpcRef == nil {
+		return nil, nil
+	}
+	namespace := vpcRef.Namespace
+	if namespace == "" {
+		namespace = linodeCluster.Namespace
+	}
+	var linodeVPC LinodeVPC
+	if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: vpcRef.Name}, &linodeVPC); err != nil {
+		return nil, err
+	}
+
+	return &linodeVPC, nil
+}
+
 func validateLinodeType(ctx context.Context, client LinodeClient, id string, path *field.Path) (*linodego.LinodeType, *field.Error) {
 	plan, err := client.GetType(ctx, id)
 	if err != nil {
@@ -55,8 +148,8 @@ func validateLinodeType(ctx context.Context, client LinodeClient, id string, pat
 //   - The region has Object Storage support.
 //
 // NOTE: This implementation intended to bypass the authentication requirement for the [Clusters List] and [Cluster
-// View] endpoints in the Linode API, thereby reusing a [github.com/linode/linodego.Client] (and its caching if enabled)
-// across many admission requests.
+// View] endpoints in the Linode API, thereby reusing a [github.com/linode/linodego.Client] (and the shared catalog cache) across many
+// admission requests.
 //
 // [Clusters List]: https://www.linode.com/docs/api/object-storage/#clusters-list
 // [Cluster View]: https://www.linode.com/docs/api/object-storage/#cluster-view
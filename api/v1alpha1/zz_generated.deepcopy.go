@@ -28,6 +28,41 @@ import (
 	"sigs.k8s.io/cluster-api/errors"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterVLANSpec) DeepCopyInto(out *ClusterVLANSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVLANSpec.
+func (in *ClusterVLANSpec) DeepCopy() *ClusterVLANSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVLANSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterVPCSpec) DeepCopyInto(out *ClusterVPCSpec) {
+	*out = *in
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]VPCSubnetCreateOptions, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVPCSpec.
+func (in *ClusterVPCSpec) DeepCopy() *ClusterVPCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVPCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceConfigInterfaceCreateOptions) DeepCopyInto(out *InstanceConfigInterfaceCreateOptions) {
 	*out = *in
@@ -396,6 +431,11 @@ func (in *LinodeMachineSpec) DeepCopyInto(out *LinodeMachineSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.VPC != nil {
+		in, out := &in.VPC, &out.VPC
+		*out = new(MachineVPCOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.OSDisk != nil {
 		in, out := &in.OSDisk, &out.OSDisk
 		*out = new(InstanceDisk)
@@ -810,6 +850,18 @@ func (in *LinodeVPCStatus) DeepCopyInto(out *LinodeVPCStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]VPCSubnetStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CreatedSubnetIDs != nil {
+		in, out := &in.CreatedSubnetIDs, &out.CreatedSubnetIDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinodeVPCStatus.
@@ -822,6 +874,36 @@ func (in *LinodeVPCStatus) DeepCopy() *LinodeVPCStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineVPCOptions) DeepCopyInto(out *MachineVPCOptions) {
+	*out = *in
+	if in.Subnet != nil {
+		in, out := &in.Subnet, &out.Subnet
+		*out = new(VPCSubnetSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPv4AddressPoolRef != nil {
+		in, out := &in.IPv4AddressPoolRef, &out.IPv4AddressPoolRef
+		*out = new(v1.TypedLocalObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodCIDRMaskSize != nil {
+		in, out := &in.PodCIDRMaskSize, &out.PodCIDRMaskSize
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineVPCOptions.
+func (in *MachineVPCOptions) DeepCopy() *MachineVPCOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineVPCOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 	*out = *in
@@ -835,6 +917,16 @@ func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.VPC != nil {
+		in, out := &in.VPC, &out.VPC
+		*out = new(ClusterVPCSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VLAN != nil {
+		in, out := &in.VLAN, &out.VLAN
+		*out = new(ClusterVLANSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
@@ -876,3 +968,43 @@ func (in *VPCSubnetCreateOptions) DeepCopy() *VPCSubnetCreateOptions {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCSubnetSelector) DeepCopyInto(out *VPCSubnetSelector) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCSubnetSelector.
+func (in *VPCSubnetSelector) DeepCopy() *VPCSubnetSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCSubnetSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCSubnetStatus) DeepCopyInto(out *VPCSubnetStatus) {
+	*out = *in
+	if in.LinodeIDs != nil {
+		in, out := &in.LinodeIDs, &out.LinodeIDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCSubnetStatus.
+func (in *VPCSubnetStatus) DeepCopy() *VPCSubnetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCSubnetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,170 @@
+package cache
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/linode/linodego"
+	"k8s.io/apimachinery/pkg/util/cache"
+
+	"github.com/linode/cluster-api-provider-linode/clients"
+	"github.com/linode/cluster-api-provider-linode/observability/metrics"
+)
+
+const (
+	// DefaultSize is the default maximum number of entries kept per resource kind.
+	DefaultSize = 256
+	// DefaultTTL is the default duration an entry is served from the cache before it is looked up again.
+	DefaultTTL = 10 * time.Minute
+
+	// publicImagePrefix identifies images published by Linode, which are the same for every account.
+	publicImagePrefix = "linode/"
+)
+
+// Catalog holds cached Linode catalog objects (regions, images and types). These rarely change and do not depend
+// on the credentials used to look them up, so a single Catalog is safe to share across reconcilers and webhooks.
+// Entries are keyed by the API endpoint they were read from, since each endpoint has its own catalog.
+type Catalog struct {
+	ttl     time.Duration
+	regions *cache.LRUExpireCache
+	images  *cache.LRUExpireCache
+	types   *cache.LRUExpireCache
+}
+
+// NewCatalog creates a Catalog that keeps at most size entries per resource kind, each for the given ttl.
+func NewCatalog(size int, ttl time.Duration) *Catalog {
+	return &Catalog{
+		ttl:     ttl,
+		regions: cache.NewLRUExpireCache(size),
+		images:  cache.NewLRUExpireCache(size),
+		types:   cache.NewLRUExpireCache(size),
+	}
+}
+
+// DefaultCatalog is the Catalog shared by all controllers and webhooks.
+var DefaultCatalog = NewCatalog(DefaultSize, DefaultTTL)
+
+// Endpoint returns the API endpoint a Linode client created with the given base URL and API version talks to, as
+// used to key a [Catalog]. Empty values select the linodego defaults.
+func Endpoint(baseURL, apiVersion string) string {
+	if baseURL == "" {
+		baseURL = linodego.APIProto + "://" + linodego.APIHost
+	}
+	if apiVersion == "" {
+		apiVersion = linodego.APIVersion
+	}
+
+	return strings.TrimSuffix(baseURL, "/") + "/" + apiVersion
+}
+
+// LinodeClient is a [clients.LinodeClient] that serves GetRegion, GetImage and GetType from a [Catalog], only
+// calling the Linode API on a miss. All other calls are passed through to the wrapped client.
+type LinodeClient struct {
+	clients.LinodeClient
+	catalog  *Catalog
+	endpoint string
+}
+
+var _ clients.LinodeClient = (*LinodeClient)(nil)
+
+// NewLinodeClient wraps client so that catalog lookups are read through the given catalog. The endpoint is the one
+// client talks to, as returned by [Endpoint].
+func NewLinodeClient(client clients.LinodeClient, catalog *Catalog, endpoint string) *LinodeClient {
+	return &LinodeClient{
+		LinodeClient: client,
+		catalog:      catalog,
+		endpoint:     endpoint,
+	}
+}
+
+func (c *LinodeClient) GetRegion(ctx context.Context, regionID string) (*linodego.Region, error) {
+	return readThrough(ctx, c, c.catalog.regions, "region", regionID, c.LinodeClient.GetRegion, copyRegion)
+}
+
+func (c *LinodeClient) GetImage(ctx context.Context, imageID string) (*linodego.Image, error) {
+	// Private images are scoped to an account, so only public images can be shared between credentials.
+	if !strings.HasPrefix(imageID, publicImagePrefix) {
+		return c.LinodeClient.GetImage(ctx, imageID)
+	}
+
+	return readThrough(ctx, c, c.catalog.images, "image", imageID, c.LinodeClient.GetImage, copyImage)
+}
+
+func (c *LinodeClient) GetType(ctx context.Context, typeID string) (*linodego.LinodeType, error) {
+	return readThrough(ctx, c, c.catalog.types, "type", typeID, c.LinodeClient.GetType, copyType)
+}
+
+// readThrough returns a copy of the object cached under id for the endpoint of client, calling get and caching a
+// copy of its result on a miss. Errors are never cached.
+func readThrough[T any](
+	ctx context.Context,
+	client *LinodeClient,
+	store *cache.LRUExpireCache,
+	resource, id string,
+	get func(context.Context, string) (*T, error),
+	deepCopy func(*T) *T,
+) (*T, error) {
+	key := client.endpoint + " " + id
+	if cached, ok := store.Get(key); ok {
+		metrics.ObserveCacheLookup(resource, true)
+
+		return deepCopy(cached.(*T)), nil
+	}
+	metrics.ObserveCacheLookup(resource, false)
+
+	obj, err := get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	store.Add(key, deepCopy(obj), client.catalog.ttl)
+
+	return obj, nil
+}
+
+func copyRegion(region *linodego.Region) *linodego.Region {
+	out := *region
+	out.Capabilities = slices.Clone(region.Capabilities)
+
+	return &out
+}
+
+func copyImage(image *linodego.Image) *linodego.Image {
+	out := *image
+	out.Capabilities = slices.Clone(image.Capabilities)
+	out.Updated = copyPointer(image.Updated)
+	out.Created = copyPointer(image.Created)
+	out.Expiry = copyPointer(image.Expiry)
+	out.EOL = copyPointer(image.EOL)
+
+	return &out
+}
+
+func copyType(linodeType *linodego.LinodeType) *linodego.LinodeType {
+	out := *linodeType
+	out.Price = copyPointer(linodeType.Price)
+	out.RegionPrices = slices.Clone(linodeType.RegionPrices)
+	if linodeType.Addons != nil {
+		out.Addons = &linodego.LinodeAddons{}
+		if backups := linodeType.Addons.Backups; backups != nil {
+			out.Addons.Backups = &linodego.LinodeBackupsAddon{
+				Price:        copyPointer(backups.Price),
+				RegionPrices: slices.Clone(backups.RegionPrices),
+			}
+		}
+	}
+
+	return &out
+}
+
+// copyPointer returns a pointer to a shallow copy of the value p points to, or nil when p is nil.
+func copyPointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	out := *p
+
+	return &out
+}
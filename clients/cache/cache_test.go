@@ -0,0 +1,230 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestLinodeClient(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		expects func(client *mock.MockLinodeClient)
+		call    func(ctx context.Context, client *LinodeClient) (any, error)
+		want    any
+		wantErr bool
+	}{
+		{
+			name: "Success - region is only looked up once",
+			ttl:  time.Minute,
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().GetRegion(gomock.Any(), "us-east").Return(&linodego.Region{ID: "us-east"}, nil).Times(1)
+			},
+			call: func(ctx context.Context, client *LinodeClient) (any, error) {
+				return client.GetRegion(ctx, "us-east")
+			},
+			want: &linodego.Region{ID: "us-east"},
+		},
+		{
+			name: "Success - type is only looked up once",
+			ttl:  time.Minute,
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().GetType(gomock.Any(), "g6-standard-1").Return(&linodego.LinodeType{ID: "g6-standard-1"}, nil).Times(1)
+			},
+			call: func(ctx context.Context, client *LinodeClient) (any, error) {
+				return client.GetType(ctx, "g6-standard-1")
+			},
+			want: &linodego.LinodeType{ID: "g6-standard-1"},
+		},
+		{
+			name: "Success - public image is only looked up once",
+			ttl:  time.Minute,
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().GetImage(gomock.Any(), "linode/ubuntu22.04").Return(&linodego.Image{ID: "linode/ubuntu22.04"}, nil).Times(1)
+			},
+			call: func(ctx context.Context, client *LinodeClient) (any, error) {
+				return client.GetImage(ctx, "linode/ubuntu22.04")
+			},
+			want: &linodego.Image{ID: "linode/ubuntu22.04"},
+		},
+		{
+			name: "Success - private image is never cached",
+			ttl:  time.Minute,
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().GetImage(gomock.Any(), "private/123").Return(&linodego.Image{ID: "private/123"}, nil).Times(2)
+			},
+			call: func(ctx context.Context, client *LinodeClient) (any, error) {
+				return client.GetImage(ctx, "private/123")
+			},
+			want: &linodego.Image{ID: "private/123"},
+		},
+		{
+			name: "Success - expired entries are looked up again",
+			ttl:  time.Nanosecond,
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().GetRegion(gomock.Any(), "us-east").Return(&linodego.Region{ID: "us-east"}, nil).Times(2)
+			},
+			call: func(ctx context.Context, client *LinodeClient) (any, error) {
+				time.Sleep(time.Millisecond)
+				return client.GetRegion(ctx, "us-east")
+			},
+			want: &linodego.Region{ID: "us-east"},
+		},
+		{
+			name: "Error - errors are never cached",
+			ttl:  time.Minute,
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().GetRegion(gomock.Any(), "us-east").Return(nil, errors.New("unavailable")).Times(2)
+			},
+			call: func(ctx context.Context, client *LinodeClient) (any, error) {
+				return client.GetRegion(ctx, "us-east")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockClient)
+
+			client := NewLinodeClient(mockClient, NewCatalog(DefaultSize, testcase.ttl), Endpoint("", ""))
+			for range 2 {
+				got, err := testcase.call(context.Background(), client)
+				if testcase.wantErr {
+					require.Error(t, err)
+					continue
+				}
+				require.NoError(t, err)
+				assert.Equal(t, testcase.want, got)
+			}
+		})
+	}
+}
+
+func TestLinodeClientReturnsCopies(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockLinodeClient(ctrl)
+	mockClient.EXPECT().GetRegion(gomock.Any(), "us-east").Return(&linodego.Region{
+		ID:           "us-east",
+		Label:        "Newark",
+		Capabilities: []string{"VPCs"},
+	}, nil)
+	mockClient.EXPECT().GetType(gomock.Any(), "g6-standard-1").Return(&linodego.LinodeType{
+		ID:           "g6-standard-1",
+		Price:        &linodego.LinodePrice{Monthly: 12},
+		RegionPrices: []linodego.LinodeRegionPrice{{ID: "id-cgk", Monthly: 14}},
+		Addons: &linodego.LinodeAddons{Backups: &linodego.LinodeBackupsAddon{
+			Price: &linodego.LinodePrice{Monthly: 2},
+		}},
+	}, nil)
+
+	client := NewLinodeClient(mockClient, NewCatalog(DefaultSize, time.Minute), Endpoint("", ""))
+	region, err := client.GetRegion(context.Background(), "us-east")
+	require.NoError(t, err)
+	region.Label = "changed"
+	region.Capabilities[0] = "changed"
+
+	region, err = client.GetRegion(context.Background(), "us-east")
+	require.NoError(t, err)
+	assert.Equal(t, "Newark", region.Label)
+	assert.Equal(t, []string{"VPCs"}, region.Capabilities)
+	region.Capabilities[0] = "changed"
+
+	region, err = client.GetRegion(context.Background(), "us-east")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"VPCs"}, region.Capabilities)
+
+	linodeType, err := client.GetType(context.Background(), "g6-standard-1")
+	require.NoError(t, err)
+	linodeType.Price.Monthly = 0
+	linodeType.RegionPrices[0].Monthly = 0
+	linodeType.Addons.Backups.Price.Monthly = 0
+
+	linodeType, err = client.GetType(context.Background(), "g6-standard-1")
+	require.NoError(t, err)
+	assert.InDelta(t, 12, linodeType.Price.Monthly, 0)
+	assert.InDelta(t, 14, linodeType.RegionPrices[0].Monthly, 0)
+	assert.InDelta(t, 2, linodeType.Addons.Backups.Price.Monthly, 0)
+}
+
+func TestLinodeClientKeysByEndpoint(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	defaultClient := mock.NewMockLinodeClient(ctrl)
+	defaultClient.EXPECT().GetRegion(gomock.Any(), "us-east").Return(&linodego.Region{ID: "us-east", Label: "Newark"}, nil).Times(1)
+	otherClient := mock.NewMockLinodeClient(ctrl)
+	otherClient.EXPECT().GetRegion(gomock.Any(), "us-east").Return(&linodego.Region{ID: "us-east", Label: "Other"}, nil).Times(1)
+
+	catalog := NewCatalog(DefaultSize, time.Minute)
+	clients := map[string]*LinodeClient{
+		"Newark": NewLinodeClient(defaultClient, catalog, Endpoint("", "")),
+		"Other":  NewLinodeClient(otherClient, catalog, Endpoint("https://api.example.com", "v4beta")),
+	}
+	for range 2 {
+		for label, client := range clients {
+			region, err := client.GetRegion(context.Background(), "us-east")
+			require.NoError(t, err)
+			assert.Equal(t, label, region.Label)
+		}
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		baseURL    string
+		apiVersion string
+		want       string
+	}{
+		{
+			name: "Success - linodego defaults",
+			want: "https://api.linode.com/v4",
+		},
+		{
+			name:       "Success - explicit defaults match the linodego defaults",
+			baseURL:    "https://api.linode.com/",
+			apiVersion: "v4",
+			want:       "https://api.linode.com/v4",
+		},
+		{
+			name:       "Success - custom endpoint",
+			baseURL:    "https://api.example.com",
+			apiVersion: "v4beta",
+			want:       "https://api.example.com/v4beta",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testcase.want, Endpoint(testcase.baseURL, testcase.apiVersion))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/linode/linodego"
+)
+
+// listPageSize is the number of results requested per page, the maximum allowed by the Linode API.
+const listPageSize = 500
+
+// ListFunc lists the Linode resources matching opts, e.g. [LinodeInstanceClient.ListInstances].
+type ListFunc[T any] func(ctx context.Context, opts *linodego.ListOptions) ([]T, error)
+
+// MultipleMatchesError is returned by [FindUnique] when several Linode resources match a filter.
+type MultipleMatchesError struct {
+	// IDs are the IDs of the matching resources.
+	IDs []int
+}
+
+func (e *MultipleMatchesError) Error() string {
+	ids := make([]string, len(e.IDs))
+	for i, id := range e.IDs {
+		ids[i] = strconv.Itoa(id)
+	}
+
+	return fmt.Sprintf("multiple matches: %s", strings.Join(ids, ", "))
+}
+
+// ForEach calls fn with every Linode resource matching filter. Pages are fetched one at a time, so that at most one
+// page of resources is held in memory, and fetching stops when ctx is done or fn returns an error.
+func ForEach[T any](ctx context.Context, list ListFunc[T], filter string, fn func(T) error) error {
+	opts := linodego.NewListOptions(1, filter)
+	opts.PageSize = listPageSize
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		items, err := list(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+
+		if opts.Page >= opts.Pages {
+			return nil
+		}
+		opts.Page++
+	}
+}
+
+// FindUnique returns the only Linode resource matching filter, or nil if none does. A [*MultipleMatchesError]
+// listing the IDs of the matching resources is returned if several do.
+func FindUnique[T any](ctx context.Context, list ListFunc[T], filter string, id func(T) int) (*T, error) {
+	var (
+		found *T
+		ids   []int
+	)
+	err := ForEach(ctx, list, filter, func(item T) error {
+		if found == nil {
+			found = &item
+		}
+		ids = append(ids, id(item))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > 1 {
+		return nil, &MultipleMatchesError{IDs: ids}
+	}
+
+	return found, nil
+}
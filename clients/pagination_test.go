@@ -0,0 +1,208 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+// expectInstancePages makes client return pages of instances, one page per call.
+func expectInstancePages(client *mock.MockLinodeClient, pages ...[]linodego.Instance) {
+	client.EXPECT().ListInstances(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, opts *linodego.ListOptions) ([]linodego.Instance, error) {
+			opts.Pages = len(pages)
+			opts.Results = 0
+			for _, page := range pages {
+				opts.Results += len(page)
+			}
+
+			return pages[opts.Page-1], nil
+		}).Times(len(pages))
+}
+
+func instanceID(instance linodego.Instance) int {
+	return instance.ID
+}
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		expects     func(client *mock.MockLinodeClient)
+		fn          func(cancel context.CancelFunc, instance linodego.Instance) error
+		expectedIDs []int
+		expectedErr string
+	}{
+		{
+			name: "Success - all pages are listed",
+			expects: func(client *mock.MockLinodeClient) {
+				expectInstancePages(client, []linodego.Instance{{ID: 1}, {ID: 2}}, []linodego.Instance{{ID: 3}}, []linodego.Instance{{ID: 4}})
+			},
+			expectedIDs: []int{1, 2, 3, 4},
+		},
+		{
+			name: "Success - no results",
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().ListInstances(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+		},
+		{
+			name: "Error - list fails",
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().ListInstances(gomock.Any(), gomock.Any()).Return(nil, errors.New("server error"))
+			},
+			expectedErr: "server error",
+		},
+		{
+			name: "Error - fn fails",
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().ListInstances(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, opts *linodego.ListOptions) ([]linodego.Instance, error) {
+						opts.Pages = 2
+
+						return []linodego.Instance{{ID: 1}, {ID: 2}}, nil
+					})
+			},
+			fn: func(_ context.CancelFunc, instance linodego.Instance) error {
+				if instance.ID == 2 {
+					return errors.New("stop")
+				}
+
+				return nil
+			},
+			expectedIDs: []int{1, 2},
+			expectedErr: "stop",
+		},
+		{
+			name: "Error - context is cancelled between pages",
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().ListInstances(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, opts *linodego.ListOptions) ([]linodego.Instance, error) {
+						opts.Pages = 2
+
+						return []linodego.Instance{{ID: 1}}, nil
+					})
+			},
+			fn: func(cancel context.CancelFunc, _ linodego.Instance) error {
+				cancel()
+
+				return nil
+			},
+			expectedIDs: []int{1},
+			expectedErr: context.Canceled.Error(),
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			client := mock.NewMockLinodeClient(mockCtrl)
+			testcase.expects(client)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var ids []int
+			err := ForEach(ctx, client.ListInstances, `{"tags":"capl"}`, func(instance linodego.Instance) error {
+				ids = append(ids, instance.ID)
+				if testcase.fn != nil {
+					return testcase.fn(cancel, instance)
+				}
+
+				return nil
+			})
+			if testcase.expectedErr != "" {
+				require.ErrorContains(t, err, testcase.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, testcase.expectedIDs, ids)
+		})
+	}
+}
+
+func TestForEachListOptions(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	client := mock.NewMockLinodeClient(mockCtrl)
+	var pages []int
+	client.EXPECT().ListInstances(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, opts *linodego.ListOptions) ([]linodego.Instance, error) {
+			assert.Equal(t, `{"tags":"capl"}`, opts.Filter)
+			assert.Equal(t, listPageSize, opts.PageSize)
+			pages = append(pages, opts.Page)
+			opts.Pages = 3
+
+			return nil, nil
+		}).Times(3)
+
+	require.NoError(t, ForEach(context.Background(), client.ListInstances, `{"tags":"capl"}`, func(linodego.Instance) error {
+		return nil
+	}))
+	assert.Equal(t, []int{1, 2, 3}, pages)
+}
+
+func TestFindUnique(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		pages       [][]linodego.Instance
+		expected    *linodego.Instance
+		expectedIDs []int
+	}{
+		{
+			name:  "No match",
+			pages: [][]linodego.Instance{{}},
+		},
+		{
+			name:     "One match",
+			pages:    [][]linodego.Instance{{{ID: 1, Label: "test"}}},
+			expected: &linodego.Instance{ID: 1, Label: "test"},
+		},
+		{
+			name:        "Multiple matches",
+			pages:       [][]linodego.Instance{{{ID: 1}}, {{ID: 2}, {ID: 3}}},
+			expectedIDs: []int{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			client := mock.NewMockLinodeClient(mockCtrl)
+			expectInstancePages(client, testcase.pages...)
+
+			found, err := FindUnique(context.Background(), client.ListInstances, "", instanceID)
+			if testcase.expectedIDs != nil {
+				var multipleMatches *MultipleMatchesError
+				require.ErrorAs(t, err, &multipleMatches)
+				assert.Equal(t, testcase.expectedIDs, multipleMatches.IDs)
+				assert.EqualError(t, err, "multiple matches: 1, 2, 3")
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expected, found)
+		})
+	}
+}
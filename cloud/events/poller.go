@@ -0,0 +1,192 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/linode/linodego"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	. "github.com/linode/cluster-api-provider-linode/clients"
+)
+
+const (
+	// DefaultPollInterval is the default interval between two listings of the account events.
+	DefaultPollInterval = 10 * time.Second
+	// DefaultMaxEventAge is how long an in-progress event is tracked before it is given up on.
+	DefaultMaxEventAge = time.Hour
+
+	// filterTimeFormat is the timestamp format expected by the Linode API filters.
+	filterTimeFormat = "2006-01-02T15:04:05"
+)
+
+// Handler is called with every Linode event that reached a terminal status, i.e. finished, failed or notification.
+type Handler func(ctx context.Context, event linodego.Event)
+
+// Poller periodically lists the Linode account events and calls its handlers once for every event that reaches a
+// terminal status. A single Poller is shared by all controllers so the number of Events API calls does not grow with
+// the number of managed objects.
+type Poller struct {
+	client      LinodeEventClient
+	interval    time.Duration
+	maxEventAge time.Duration
+	now         func() time.Time
+
+	mu       sync.Mutex
+	handlers []Handler
+	since    time.Time
+	pending  map[int]time.Time
+	handled  map[int]time.Time
+}
+
+// NewPoller creates a Poller listing the account events with client every interval.
+func NewPoller(client LinodeEventClient, interval time.Duration) *Poller {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	return &Poller{
+		client:      client,
+		interval:    interval,
+		maxEventAge: DefaultMaxEventAge,
+		now:         time.Now,
+		pending:     make(map[int]time.Time),
+		handled:     make(map[int]time.Time),
+	}
+}
+
+// Subscribe registers a handler called for every event reaching a terminal status.
+func (p *Poller) Subscribe(handler Handler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.handlers = append(p.handlers, handler)
+}
+
+// Start polls the account events until ctx is done. It implements manager.Runnable.
+func (p *Poller) Start(ctx context.Context) error {
+	logger := ctrl.LoggerFrom(ctx).WithName("LinodeEventPoller")
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Poll(ctx); err != nil {
+			logger.Error(err, "Failed to poll Linode account events")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll lists the account events created since the oldest event still in progress and dispatches the ones which
+// reached a terminal status since the previous poll.
+func (p *Poller) Poll(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.since.IsZero() {
+		p.since = p.now().Add(-p.interval)
+	}
+
+	filter, err := json.Marshal(map[string]any{
+		"created":   map[string]string{"+gte": p.since.UTC().Format(filterTimeFormat)},
+		"+order_by": "created",
+		"+order":    "asc",
+	})
+	if err != nil {
+		return err
+	}
+
+	events, err := p.client.ListEvents(ctx, linodego.NewListOptions(0, string(filter)))
+	if err != nil {
+		return fmt.Errorf("list events: %w", err)
+	}
+
+	latest := p.since
+	for _, event := range events {
+		if event.Created == nil {
+			continue
+		}
+		if event.Created.After(latest) {
+			latest = *event.Created
+		}
+
+		switch event.Status {
+		case linodego.EventScheduled, linodego.EventStarted:
+			p.pending[event.ID] = *event.Created
+		default:
+			delete(p.pending, event.ID)
+			if _, ok := p.handled[event.ID]; ok {
+				continue
+			}
+			p.handled[event.ID] = *event.Created
+
+			for _, handler := range p.handlers {
+				handler(ctx, event)
+			}
+		}
+	}
+
+	// Keep listing from the oldest in-progress event so its completion is not missed, but give up on events which
+	// have been running for longer than maxEventAge.
+	cutoff := p.now().Add(-p.maxEventAge)
+	p.since = latest
+	for id, created := range p.pending {
+		if created.Before(cutoff) {
+			delete(p.pending, id)
+
+			continue
+		}
+		if created.Before(p.since) {
+			p.since = created
+		}
+	}
+	for id, created := range p.handled {
+		if created.Before(p.since) {
+			delete(p.handled, id)
+		}
+	}
+
+	return nil
+}
+
+// EntityID returns the numeric ID of an event entity, or 0 if the entity has no numeric ID.
+func EntityID(entity *linodego.EventEntity) int {
+	if entity == nil {
+		return 0
+	}
+
+	// IDs are decoded from JSON, so numeric IDs are float64
+	switch id := entity.ID.(type) {
+	case float64:
+		return int(id)
+	case int:
+		return id
+	default:
+		return 0
+	}
+}
+
+// Message describes an event, since the Linode API event message is not exposed by linodego.
+func Message(event linodego.Event) string {
+	msg := fmt.Sprintf("%s %s", event.Action, event.Status)
+	if event.Entity != nil {
+		msg += fmt.Sprintf(" on %s %q", event.Entity.Type, event.Entity.Label)
+	}
+	if event.SecondaryEntity != nil {
+		msg += fmt.Sprintf(" (%s %q)", event.SecondaryEntity.Type, event.SecondaryEntity.Label)
+	}
+	if event.Username != "" {
+		msg += fmt.Sprintf(" by %s", event.Username)
+	}
+
+	return fmt.Sprintf("%s (event %d)", msg, event.ID)
+}
@@ -0,0 +1,179 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestPollerPoll(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(offset time.Duration) *time.Time {
+		created := now.Add(offset)
+		return &created
+	}
+
+	tests := []struct {
+		name          string
+		polls         [][]linodego.Event
+		expectedIDs   []int
+		expectedSince time.Time
+	}{
+		{
+			name: "terminal events are dispatched",
+			polls: [][]linodego.Event{{
+				{ID: 1, Status: linodego.EventFinished, Action: linodego.ActionLinodeBoot, Created: at(-5 * time.Second)},
+				{ID: 2, Status: linodego.EventFailed, Action: linodego.ActionDiskResize, Created: at(-4 * time.Second)},
+				{ID: 3, Status: linodego.EventNotification, Action: linodego.ActionLassieReboot, Created: at(-3 * time.Second)},
+			}},
+			expectedIDs:   []int{1, 2, 3},
+			expectedSince: now.Add(-3 * time.Second),
+		},
+		{
+			name: "events are dispatched once",
+			polls: [][]linodego.Event{
+				{{ID: 1, Status: linodego.EventFinished, Created: at(-5 * time.Second)}},
+				{{ID: 1, Status: linodego.EventFinished, Created: at(-5 * time.Second)}},
+			},
+			expectedIDs:   []int{1},
+			expectedSince: now.Add(-5 * time.Second),
+		},
+		{
+			name: "in-progress events are dispatched when they finish",
+			polls: [][]linodego.Event{
+				{
+					{ID: 1, Status: linodego.EventStarted, Created: at(-8 * time.Second)},
+					{ID: 2, Status: linodego.EventFinished, Created: at(-2 * time.Second)},
+				},
+				{
+					{ID: 1, Status: linodego.EventFinished, Created: at(-8 * time.Second)},
+					{ID: 2, Status: linodego.EventFinished, Created: at(-2 * time.Second)},
+				},
+			},
+			expectedIDs:   []int{2, 1},
+			expectedSince: now.Add(-2 * time.Second),
+		},
+		{
+			name: "listing keeps the oldest in-progress event",
+			polls: [][]linodego.Event{{
+				{ID: 1, Status: linodego.EventScheduled, Created: at(-8 * time.Second)},
+				{ID: 2, Status: linodego.EventFinished, Created: at(-2 * time.Second)},
+			}},
+			expectedIDs:   []int{2},
+			expectedSince: now.Add(-8 * time.Second),
+		},
+		{
+			name: "stale in-progress events are given up on",
+			polls: [][]linodego.Event{{
+				{ID: 1, Status: linodego.EventStarted, Created: at(-2 * time.Hour)},
+				{ID: 2, Status: linodego.EventFinished, Created: at(-2 * time.Second)},
+			}},
+			expectedIDs:   []int{2},
+			expectedSince: now.Add(-2 * time.Second),
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock.NewMockLinodeClient(ctrl)
+			for _, events := range testcase.polls {
+				mockClient.EXPECT().ListEvents(gomock.Any(), gomock.Any()).Return(events, nil)
+			}
+
+			poller := NewPoller(mockClient, time.Minute)
+			poller.now = func() time.Time { return now }
+			poller.since = now.Add(-3 * time.Hour)
+
+			var dispatched []int
+			poller.Subscribe(func(_ context.Context, event linodego.Event) {
+				dispatched = append(dispatched, event.ID)
+			})
+
+			for range testcase.polls {
+				require.NoError(t, poller.Poll(context.Background()))
+			}
+
+			assert.Equal(t, testcase.expectedIDs, dispatched)
+			assert.Equal(t, testcase.expectedSince, poller.since)
+		})
+	}
+}
+
+func TestPollerPollFilter(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	mockClient := mock.NewMockLinodeClient(ctrl)
+	mockClient.EXPECT().ListEvents(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, opts *linodego.ListOptions) ([]linodego.Event, error) {
+			assert.JSONEq(t, `{"created":{"+gte":"2024-06-01T11:59:50"},"+order_by":"created","+order":"asc"}`, opts.Filter)
+			return nil, nil
+		})
+
+	poller := NewPoller(mockClient, 10*time.Second)
+	poller.now = func() time.Time { return now }
+
+	require.NoError(t, poller.Poll(context.Background()))
+	assert.Equal(t, now.Add(-10*time.Second), poller.since)
+}
+
+func TestPollerPollError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockLinodeClient(ctrl)
+	mockClient.EXPECT().ListEvents(gomock.Any(), gomock.Any()).Return(nil, errors.New("unauthorized"))
+
+	poller := NewPoller(mockClient, time.Minute)
+	poller.Subscribe(func(context.Context, linodego.Event) {
+		t.Error("handler should not be called")
+	})
+
+	assert.EqualError(t, poller.Poll(context.Background()), "list events: unauthorized")
+}
+
+func TestEntityID(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0, EntityID(nil))
+	assert.Equal(t, 123, EntityID(&linodego.EventEntity{ID: float64(123)}))
+	assert.Equal(t, 123, EntityID(&linodego.EventEntity{ID: 123}))
+	assert.Equal(t, 0, EntityID(&linodego.EventEntity{ID: "my-bucket"}))
+}
+
+func TestMessage(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		`disk_resize failed on linode "test-machine" (disk "root") by capl (event 42)`,
+		Message(linodego.Event{
+			ID:              42,
+			Action:          linodego.ActionDiskResize,
+			Status:          linodego.EventFailed,
+			Username:        "capl",
+			Entity:          &linodego.EventEntity{Type: linodego.EntityLinode, Label: "test-machine"},
+			SecondaryEntity: &linodego.EventEntity{Type: linodego.EntityDisk, Label: "root"},
+		}))
+	assert.Equal(t, "linode_boot finished (event 1)", Message(linodego.Event{ID: 1, Action: linodego.ActionLinodeBoot, Status: linodego.EventFinished}))
+}
@@ -26,6 +26,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/clients/cache"
 
 	. "github.com/linode/cluster-api-provider-linode/clients"
 )
@@ -35,6 +36,8 @@ type ClusterScopeParams struct {
 	Client        K8sClient
 	Cluster       *clusterv1.Cluster
 	LinodeCluster *infrav1alpha1.LinodeCluster
+	// ManagementClusterID identifies the management cluster in the tags of the Linode resources.
+	ManagementClusterID string
 }
 
 func validateClusterScopeParams(params ClusterScopeParams) error {
@@ -50,20 +53,20 @@ func validateClusterScopeParams(params ClusterScopeParams) error {
 
 // NewClusterScope creates a new Scope from the supplied parameters.
 // This is meant to be called for each reconcile iteration.
-func NewClusterScope(ctx context.Context, apiKey string, params ClusterScopeParams) (*ClusterScope, error) {
+func NewClusterScope(ctx context.Context, clientConfig ClientConfig, params ClusterScopeParams) (*ClusterScope, error) {
 	if err := validateClusterScopeParams(params); err != nil {
 		return nil, err
 	}
 
 	// Override the controller credentials with ones from the Cluster's Secret reference (if supplied).
 	if params.LinodeCluster.Spec.CredentialsRef != nil {
-		data, err := getCredentialDataFromRef(ctx, params.Client, *params.LinodeCluster.Spec.CredentialsRef, params.LinodeCluster.GetNamespace())
+		config, err := getClientConfigFromRef(ctx, params.Client, *params.LinodeCluster.Spec.CredentialsRef, params.LinodeCluster.GetNamespace(), clientConfig)
 		if err != nil {
 			return nil, fmt.Errorf("credentials from secret ref: %w", err)
 		}
-		apiKey = string(data)
+		clientConfig = config
 	}
-	linodeClient, err := CreateLinodeClient(apiKey, defaultClientTimeout)
+	linodeClient, err := CreateLinodeClient(clientConfig, defaultClientTimeout)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create linode client: %w", err)
 	}
@@ -74,21 +77,32 @@ func NewClusterScope(ctx context.Context, apiKey string, params ClusterScopePara
 	}
 
 	return &ClusterScope{
-		Client:        params.Client,
-		Cluster:       params.Cluster,
-		LinodeClient:  linodeClient,
-		LinodeCluster: params.LinodeCluster,
-		PatchHelper:   helper,
+		Client:              params.Client,
+		Cluster:             params.Cluster,
+		LinodeClient:        linodeClient,
+		LinodeEndpoint:      cache.Endpoint(clientConfig.BaseURL, clientConfig.APIVersion),
+		LinodeCluster:       params.LinodeCluster,
+		PatchHelper:         helper,
+		ManagementClusterID: params.ManagementClusterID,
 	}, nil
 }
 
 // ClusterScope defines the basic context for an actuator to operate upon.
 type ClusterScope struct {
-	Client        K8sClient
-	PatchHelper   *patch.Helper
-	LinodeClient  LinodeClient
-	Cluster       *clusterv1.Cluster
-	LinodeCluster *infrav1alpha1.LinodeCluster
+	Client       K8sClient
+	PatchHelper  *patch.Helper
+	LinodeClient LinodeClient
+	// LinodeEndpoint is the Linode API endpoint LinodeClient talks to, as returned by cache.Endpoint.
+	LinodeEndpoint string
+	Cluster        *clusterv1.Cluster
+	LinodeCluster  *infrav1alpha1.LinodeCluster
+
+	ManagementClusterID string
+}
+
+// Tags returns the tags of the Linode resources of the cluster.
+func (s *ClusterScope) Tags() []string {
+	return clusterTags(s.ManagementClusterID, s.Cluster, s.LinodeCluster)
 }
 
 // PatchObject persists the cluster configuration and status.
@@ -157,7 +157,7 @@ func TestClusterScopeMethods(t *testing.T) {
 
 			cScope, err := NewClusterScope(
 				context.Background(),
-				"test-key",
+				ClientConfig{Token: "test-key"},
 				ClusterScopeParams{
 					Cluster:       testcase.fields.Cluster,
 					LinodeCluster: testcase.fields.LinodeCluster,
@@ -209,7 +209,7 @@ func TestNewClusterScope(t *testing.T) {
 			},
 		},
 		{
-			name: "Success - Validate getCredentialDataFromRef() returns some apiKey data and we create a valid ClusterScope",
+			name: "Success - Validate getClientConfigFromRef() returns some apiKey data and we create a valid ClusterScope",
 			args: args{
 				apiKey: "test-key",
 				params: ClusterScopeParams{
@@ -267,7 +267,7 @@ func TestNewClusterScope(t *testing.T) {
 			},
 		},
 		{
-			name: "Error - Using getCredentialDataFromRef(), func returns an error. Unable to create a valid ClusterScope",
+			name: "Error - Using getClientConfigFromRef(), func returns an error. Unable to create a valid ClusterScope",
 			args: args{
 				apiKey: "test-key",
 				params: ClusterScopeParams{
@@ -316,7 +316,7 @@ func TestNewClusterScope(t *testing.T) {
 
 			testcase.args.params.Client = mockK8sClient
 
-			got, err := NewClusterScope(context.Background(), testcase.args.apiKey, testcase.args.params)
+			got, err := NewClusterScope(context.Background(), ClientConfig{Token: testcase.args.apiKey}, testcase.args.params)
 
 			if testcase.expectedError != nil {
 				assert.ErrorContains(t, err, testcase.expectedError.Error())
@@ -411,7 +411,7 @@ func TestClusterAddCredentialsRefFinalizer(t *testing.T) {
 
 			cScope, err := NewClusterScope(
 				context.Background(),
-				"test-key",
+				ClientConfig{Token: "test-key"},
 				ClusterScopeParams{
 					Cluster:       testcase.fields.Cluster,
 					LinodeCluster: testcase.fields.LinodeCluster,
@@ -512,7 +512,7 @@ func TestRemoveCredentialsRefFinalizer(t *testing.T) {
 
 			cScope, err := NewClusterScope(
 				context.Background(),
-				"test-key",
+				ClientConfig{Token: "test-key"},
 				ClusterScopeParams{
 					Cluster:       testcase.fields.Cluster,
 					LinodeCluster: testcase.fields.LinodeCluster,
@@ -5,15 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/linode/linodego"
 	"golang.org/x/oauth2"
 	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/util"
 	"github.com/linode/cluster-api-provider-linode/version"
 
 	. "github.com/linode/cluster-api-provider-linode/clients"
@@ -24,12 +29,24 @@ const (
 	defaultClientTimeout = time.Second * 10
 )
 
-func CreateLinodeClient(apiKey string, timeout time.Duration) (*linodego.Client, error) {
-	if apiKey == "" {
+// ClientConfig holds the settings used to create a Linode API client.
+type ClientConfig struct {
+	// Token is the Linode API token.
+	Token string
+
+	// BaseURL is the URL of the Linode API (e.g. https://api.linode.com). The linodego default is used when empty.
+	BaseURL string
+
+	// APIVersion is the Linode API version (e.g. v4beta). The linodego default is used when empty.
+	APIVersion string
+}
+
+func CreateLinodeClient(config ClientConfig, timeout time.Duration) (*linodego.Client, error) {
+	if config.Token == "" {
 		return nil, errors.New("missing Linode API key")
 	}
 
-	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: apiKey})
+	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: config.Token})
 
 	oauth2Client := &http.Client{
 		Transport: &oauth2.Transport{
@@ -41,22 +58,55 @@ func CreateLinodeClient(apiKey string, timeout time.Duration) (*linodego.Client,
 
 	linodeClient.SetUserAgent(fmt.Sprintf("CAPL/%s", version.GetVersion()))
 
+	if config.BaseURL != "" {
+		if err := validateBaseURL(config.BaseURL); err != nil {
+			return nil, err
+		}
+		linodeClient.SetBaseURL(config.BaseURL)
+	}
+	if config.APIVersion != "" {
+		linodeClient.SetAPIVersion(config.APIVersion)
+	}
+
 	return &linodeClient, nil
 }
 
-func getCredentialDataFromRef(ctx context.Context, crClient K8sClient, credentialsRef corev1.SecretReference, defaultNamespace string) ([]byte, error) {
+// validateBaseURL checks that baseURL is an absolute HTTP(S) URL.
+func validateBaseURL(baseURL string) error {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid Linode API URL %q: %w", baseURL, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid Linode API URL %q: must be an absolute http or https URL", baseURL)
+	}
+
+	return nil
+}
+
+// getClientConfigFromRef returns config with the settings from the referenced credentials Secret applied. The Secret
+// must contain an apiToken key and may optionally override the API URL and version with apiURL and apiVersion keys.
+func getClientConfigFromRef(ctx context.Context, crClient K8sClient, credentialsRef corev1.SecretReference, defaultNamespace string, config ClientConfig) (ClientConfig, error) {
 	credSecret, err := getCredentials(ctx, crClient, credentialsRef, defaultNamespace)
 	if err != nil {
-		return nil, err
+		return ClientConfig{}, err
 	}
 
 	// TODO: This key is hard-coded (for now) to match the externally-managed `manager-credentials` Secret.
 	rawData, ok := credSecret.Data["apiToken"]
 	if !ok {
-		return nil, fmt.Errorf("no apiToken key in credentials secret %s/%s", credentialsRef.Namespace, credentialsRef.Name)
+		return ClientConfig{}, fmt.Errorf("no apiToken key in credentials secret %s/%s", credentialsRef.Namespace, credentialsRef.Name)
+	}
+	config.Token = string(rawData)
+
+	if baseURL, ok := credSecret.Data["apiURL"]; ok {
+		config.BaseURL = string(baseURL)
+	}
+	if apiVersion, ok := credSecret.Data["apiVersion"]; ok {
+		config.APIVersion = string(apiVersion)
 	}
 
-	return rawData, nil
+	return config, nil
 }
 
 func addCredentialsFinalizer(ctx context.Context, crClient K8sClient, credentialsRef corev1.SecretReference, defaultNamespace, finalizer string) error {
@@ -66,6 +116,11 @@ func addCredentialsFinalizer(ctx context.Context, crClient K8sClient, credential
 	}
 
 	controllerutil.AddFinalizer(secret, finalizer)
+	// The secret isn't owned by the objects referencing it, so clusterctl move only moves it with this label
+	if secret.Labels == nil {
+		secret.Labels = make(map[string]string, 1)
+	}
+	secret.Labels[clusterctlv1.ClusterctlMoveLabel] = ""
 	if err := crClient.Update(ctx, secret); err != nil {
 		return fmt.Errorf("add finalizer to credentials secret %s/%s: %w", secret.Namespace, secret.Name, err)
 	}
@@ -116,3 +171,13 @@ func toFinalizer(obj client.Object) string {
 	}
 	return fmt.Sprintf("%s.%s/%s.%s", kind, group, namespace, name)
 }
+
+// clusterTags returns the ownership tags of linodeCluster and the tags from the labels of cluster.
+func clusterTags(managementClusterID string, cluster *clusterv1.Cluster, linodeCluster *infrav1alpha1.LinodeCluster) []string {
+	tags := util.OwnershipTags(managementClusterID, linodeCluster.Namespace, linodeCluster.Name, string(linodeCluster.UID))
+	if cluster != nil {
+		tags = append(tags, util.LabelTags(cluster.Labels)...)
+	}
+
+	return tags
+}
@@ -23,19 +23,29 @@ func TestCreateLinodeClient(t *testing.T) {
 
 	tests := []struct {
 		name        string
-		apiKey      string
+		config      ClientConfig
 		expectedErr error
 	}{
 		{
 			"Success - Valid API Key",
-			"test-key",
+			ClientConfig{Token: "test-key"},
+			nil,
+		},
+		{
+			"Success - Custom API URL and version",
+			ClientConfig{Token: "test-key", BaseURL: "http://localhost:8080", APIVersion: "v4beta"},
 			nil,
 		},
 		{
 			"Error - Empty API Key",
-			"",
+			ClientConfig{},
 			errors.New("missing Linode API key"),
 		},
+		{
+			"Error - Invalid API URL",
+			ClientConfig{Token: "test-key", BaseURL: "localhost:8080"},
+			errors.New(`invalid Linode API URL "localhost:8080": must be an absolute http or https URL`),
+		},
 	}
 
 	for _, tt := range tests {
@@ -43,7 +53,7 @@ func TestCreateLinodeClient(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, err := CreateLinodeClient(testCase.apiKey, defaultClientTimeout)
+			got, err := CreateLinodeClient(testCase.config, defaultClientTimeout)
 
 			if testCase.expectedErr != nil {
 				assert.EqualError(t, err, testCase.expectedErr.Error())
@@ -54,8 +64,8 @@ func TestCreateLinodeClient(t *testing.T) {
 	}
 }
 
-// Test_getCredentialDataFromRef tests the getCredentialDataFromRef function.
-func TestGetCredentialDataFromRef(t *testing.T) {
+// TestGetClientConfigFromRef tests the getClientConfigFromRef function.
+func TestGetClientConfigFromRef(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
@@ -65,10 +75,10 @@ func TestGetCredentialDataFromRef(t *testing.T) {
 	}
 
 	tests := []struct {
-		name          string
-		args          args
-		expectedByte  []byte
-		expectedError string
+		name           string
+		args           args
+		expectedConfig ClientConfig
+		expectedError  string
 	}{
 		{
 			name: "Testing functionality using valid/good data. No error should be returned",
@@ -92,8 +102,8 @@ func TestGetCredentialDataFromRef(t *testing.T) {
 					return nil
 				},
 			},
-			expectedByte:  []byte("example"),
-			expectedError: "",
+			expectedConfig: ClientConfig{Token: "example", BaseURL: "https://api.linode.com"},
+			expectedError:  "",
 		},
 		{
 			name: "Empty namespace provided and default namespace is used. No error should be returned",
@@ -117,8 +127,35 @@ func TestGetCredentialDataFromRef(t *testing.T) {
 					return nil
 				},
 			},
-			expectedByte:  []byte("example"),
-			expectedError: "",
+			expectedConfig: ClientConfig{Token: "example", BaseURL: "https://api.linode.com"},
+			expectedError:  "",
+		},
+		{
+			name: "API URL and version provided in the secret override the defaults. No error should be returned",
+			args: args{
+				providedCredentialsRef: corev1.SecretReference{
+					Name:      "example",
+					Namespace: "test",
+				},
+				expectedCredentialsRef: corev1.SecretReference{
+					Name:      "example",
+					Namespace: "test",
+				},
+				funcBehavior: func(ctx context.Context, key types.NamespacedName, obj *corev1.Secret, opts ...client.GetOption) error {
+					cred := corev1.Secret{
+						Data: map[string][]byte{
+							"apiToken":   []byte("example"),
+							"apiURL":     []byte("http://linode-proxy.internal"),
+							"apiVersion": []byte("v4beta"),
+						},
+					}
+					*obj = cred
+
+					return nil
+				},
+			},
+			expectedConfig: ClientConfig{Token: "example", BaseURL: "http://linode-proxy.internal", APIVersion: "v4beta"},
+			expectedError:  "",
 		},
 		{
 			name: "Handle error from crClient. Error should be returned.",
@@ -135,7 +172,6 @@ func TestGetCredentialDataFromRef(t *testing.T) {
 					return errors.New("Could not find the secret")
 				},
 			},
-			expectedByte:  []byte(nil),
 			expectedError: "get credentials secret test/example: Could not find the secret",
 		},
 		{
@@ -153,7 +189,6 @@ func TestGetCredentialDataFromRef(t *testing.T) {
 					return nil
 				},
 			},
-			expectedByte:  []byte(nil),
 			expectedError: "no apiToken key in credentials secret test/example",
 		},
 	}
@@ -172,14 +207,14 @@ func TestGetCredentialDataFromRef(t *testing.T) {
 			// Setup Expected behaviour
 			mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(testCase.args.funcBehavior)
 
-			// Call getCredentialDataFromRef using the mock client
-			got, err := getCredentialDataFromRef(context.Background(), mockClient, testCase.args.providedCredentialsRef, "default")
+			// Call getClientConfigFromRef using the mock client
+			got, err := getClientConfigFromRef(context.Background(), mockClient, testCase.args.providedCredentialsRef, "default", ClientConfig{BaseURL: "https://api.linode.com"})
 
 			// Check that the function returned the expected result
 			if testCase.expectedError != "" {
 				assert.EqualError(t, err, testCase.expectedError)
 			} else {
-				assert.Equal(t, testCase.expectedByte, got)
+				assert.Equal(t, testCase.expectedConfig, got)
 			}
 		})
 	}
@@ -224,6 +259,10 @@ func Test_addCredentialsFinalizer(t *testing.T) {
 						return nil
 					},
 					Update: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+						if _, ok := obj.GetLabels()["clusterctl.cluster.x-k8s.io/move"]; !ok {
+							return errors.New("missing clusterctl move label")
+						}
+
 						return nil
 					},
 				},
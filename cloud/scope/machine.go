@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/clients/cache"
 
 	. "github.com/linode/cluster-api-provider-linode/clients"
 )
@@ -22,16 +23,22 @@ type MachineScopeParams struct {
 	Machine       *clusterv1.Machine
 	LinodeCluster *infrav1alpha1.LinodeCluster
 	LinodeMachine *infrav1alpha1.LinodeMachine
+	// ManagementClusterID identifies the management cluster in the tags of the Linode resources.
+	ManagementClusterID string
 }
 
 type MachineScope struct {
-	Client        K8sClient
-	PatchHelper   *patch.Helper
-	Cluster       *clusterv1.Cluster
-	Machine       *clusterv1.Machine
-	LinodeClient  LinodeClient
-	LinodeCluster *infrav1alpha1.LinodeCluster
-	LinodeMachine *infrav1alpha1.LinodeMachine
+	Client       K8sClient
+	PatchHelper  *patch.Helper
+	Cluster      *clusterv1.Cluster
+	Machine      *clusterv1.Machine
+	LinodeClient LinodeClient
+	// LinodeEndpoint is the Linode API endpoint LinodeClient talks to, as returned by cache.Endpoint.
+	LinodeEndpoint string
+	LinodeCluster  *infrav1alpha1.LinodeCluster
+	LinodeMachine  *infrav1alpha1.LinodeMachine
+
+	ManagementClusterID string
 }
 
 func validateMachineScopeParams(params MachineScopeParams) error {
@@ -51,7 +58,7 @@ func validateMachineScopeParams(params MachineScopeParams) error {
 	return nil
 }
 
-func NewMachineScope(ctx context.Context, apiKey string, params MachineScopeParams) (*MachineScope, error) {
+func NewMachineScope(ctx context.Context, clientConfig ClientConfig, params MachineScopeParams) (*MachineScope, error) {
 	if err := validateMachineScopeParams(params); err != nil {
 		return nil, err
 	}
@@ -77,13 +84,13 @@ func NewMachineScope(ctx context.Context, apiKey string, params MachineScopePara
 	}
 
 	if credentialRef != nil {
-		data, err := getCredentialDataFromRef(ctx, params.Client, *credentialRef, defaultNamespace)
+		config, err := getClientConfigFromRef(ctx, params.Client, *credentialRef, defaultNamespace, clientConfig)
 		if err != nil {
 			return nil, fmt.Errorf("credentials from secret ref: %w", err)
 		}
-		apiKey = string(data)
+		clientConfig = config
 	}
-	linodeClient, err := CreateLinodeClient(apiKey, defaultClientTimeout)
+	linodeClient, err := CreateLinodeClient(clientConfig, defaultClientTimeout)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create linode client: %w", err)
 	}
@@ -95,16 +102,23 @@ func NewMachineScope(ctx context.Context, apiKey string, params MachineScopePara
 	}
 
 	return &MachineScope{
-		Client:        params.Client,
-		PatchHelper:   helper,
-		Cluster:       params.Cluster,
-		Machine:       params.Machine,
-		LinodeClient:  linodeClient,
-		LinodeCluster: params.LinodeCluster,
-		LinodeMachine: params.LinodeMachine,
+		Client:              params.Client,
+		PatchHelper:         helper,
+		Cluster:             params.Cluster,
+		Machine:             params.Machine,
+		LinodeClient:        linodeClient,
+		LinodeEndpoint:      cache.Endpoint(clientConfig.BaseURL, clientConfig.APIVersion),
+		LinodeCluster:       params.LinodeCluster,
+		LinodeMachine:       params.LinodeMachine,
+		ManagementClusterID: params.ManagementClusterID,
 	}, nil
 }
 
+// Tags returns the tags of the Linode resources of the machine's cluster.
+func (s *MachineScope) Tags() []string {
+	return clusterTags(s.ManagementClusterID, s.Cluster, s.LinodeCluster)
+}
+
 // PatchObject persists the machine configuration and status.
 func (s *MachineScope) PatchObject(ctx context.Context) error {
 	return s.PatchHelper.Patch(ctx, s.LinodeMachine)
@@ -130,7 +130,7 @@ func TestMachineScopeAddFinalizer(t *testing.T) {
 				})
 			})),
 			Path(Result("has finalizer", func(ctx context.Context, mck Mock) {
-				mScope, err := NewMachineScope(ctx, "token", MachineScopeParams{
+				mScope, err := NewMachineScope(ctx, ClientConfig{Token: "token"}, MachineScopeParams{
 					Client:        mck.K8sClient,
 					Cluster:       &clusterv1.Cluster{},
 					Machine:       &clusterv1.Machine{},
@@ -153,7 +153,7 @@ func TestMachineScopeAddFinalizer(t *testing.T) {
 					mck.K8sClient.EXPECT().Patch(ctx, gomock.Any(), gomock.Any()).Return(nil)
 				}),
 				Result("finalizer added", func(ctx context.Context, mck Mock) {
-					mScope, err := NewMachineScope(ctx, "token", MachineScopeParams{
+					mScope, err := NewMachineScope(ctx, ClientConfig{Token: "token"}, MachineScopeParams{
 						Client:        mck.K8sClient,
 						Cluster:       &clusterv1.Cluster{},
 						Machine:       &clusterv1.Machine{},
@@ -171,7 +171,7 @@ func TestMachineScopeAddFinalizer(t *testing.T) {
 					mck.K8sClient.EXPECT().Patch(ctx, gomock.Any(), gomock.Any()).Return(errors.New("fail"))
 				}),
 				Result("error", func(ctx context.Context, mck Mock) {
-					mScope, err := NewMachineScope(ctx, "token", MachineScopeParams{
+					mScope, err := NewMachineScope(ctx, ClientConfig{Token: "token"}, MachineScopeParams{
 						Client:        mck.K8sClient,
 						Cluster:       &clusterv1.Cluster{},
 						Machine:       &clusterv1.Machine{},
@@ -193,12 +193,12 @@ func TestNewMachineScope(t *testing.T) {
 	NewSuite(t, mock.MockK8sClient{}).Run(
 		OneOf(
 			Path(Result("invalid params", func(ctx context.Context, mck Mock) {
-				mScope, err := NewMachineScope(ctx, "token", MachineScopeParams{})
+				mScope, err := NewMachineScope(ctx, ClientConfig{Token: "token"}, MachineScopeParams{})
 				require.ErrorContains(t, err, "is required")
 				assert.Nil(t, mScope)
 			})),
 			Path(Result("no token", func(ctx context.Context, mck Mock) {
-				mScope, err := NewMachineScope(ctx, "", MachineScopeParams{
+				mScope, err := NewMachineScope(ctx, ClientConfig{}, MachineScopeParams{
 					Client:        mck.K8sClient,
 					Cluster:       &clusterv1.Cluster{},
 					Machine:       &clusterv1.Machine{},
@@ -213,7 +213,7 @@ func TestNewMachineScope(t *testing.T) {
 					mck.K8sClient.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "example"))
 				}),
 				Result("error", func(ctx context.Context, mck Mock) {
-					mScope, err := NewMachineScope(ctx, "", MachineScopeParams{
+					mScope, err := NewMachineScope(ctx, ClientConfig{}, MachineScopeParams{
 						Client:        mck.K8sClient,
 						Cluster:       &clusterv1.Cluster{},
 						Machine:       &clusterv1.Machine{},
@@ -245,7 +245,7 @@ func TestNewMachineScope(t *testing.T) {
 					mck.K8sClient.EXPECT().Scheme().Return(runtime.NewScheme())
 				}),
 				Result("cannot init patch helper", func(ctx context.Context, mck Mock) {
-					mScope, err := NewMachineScope(ctx, "token", MachineScopeParams{
+					mScope, err := NewMachineScope(ctx, ClientConfig{Token: "token"}, MachineScopeParams{
 						Client:        mck.K8sClient,
 						Cluster:       &clusterv1.Cluster{},
 						Machine:       &clusterv1.Machine{},
@@ -270,7 +270,7 @@ func TestNewMachineScope(t *testing.T) {
 					})
 			})),
 			Path(Result("default credentials", func(ctx context.Context, mck Mock) {
-				mScope, err := NewMachineScope(ctx, "token", MachineScopeParams{
+				mScope, err := NewMachineScope(ctx, ClientConfig{Token: "token"}, MachineScopeParams{
 					Client:        mck.K8sClient,
 					Cluster:       &clusterv1.Cluster{},
 					Machine:       &clusterv1.Machine{},
@@ -283,7 +283,7 @@ func TestNewMachineScope(t *testing.T) {
 		),
 		OneOf(
 			Path(Result("credentials from LinodeMachine credentialsRef", func(ctx context.Context, mck Mock) {
-				mScope, err := NewMachineScope(ctx, "", MachineScopeParams{
+				mScope, err := NewMachineScope(ctx, ClientConfig{}, MachineScopeParams{
 					Client:        mck.K8sClient,
 					Cluster:       &clusterv1.Cluster{},
 					Machine:       &clusterv1.Machine{},
@@ -301,7 +301,7 @@ func TestNewMachineScope(t *testing.T) {
 				assert.NotNil(t, mScope)
 			})),
 			Path(Result("credentials from LinodeCluster credentialsRef", func(ctx context.Context, mck Mock) {
-				mScope, err := NewMachineScope(ctx, "token", MachineScopeParams{
+				mScope, err := NewMachineScope(ctx, ClientConfig{Token: "token"}, MachineScopeParams{
 					Client:  mck.K8sClient,
 					Cluster: &clusterv1.Cluster{},
 					Machine: &clusterv1.Machine{},
@@ -468,7 +468,7 @@ func TestMachineAddCredentialsRefFinalizer(t *testing.T) {
 
 			mScope, err := NewMachineScope(
 				context.Background(),
-				"test-key",
+				ClientConfig{Token: "test-key"},
 				MachineScopeParams{
 					Client:        mockK8sClient,
 					Cluster:       &clusterv1.Cluster{},
@@ -561,7 +561,7 @@ func TestMachineRemoveCredentialsRefFinalizer(t *testing.T) {
 
 			mScope, err := NewMachineScope(
 				context.Background(),
-				"test-key",
+				ClientConfig{Token: "test-key"},
 				MachineScopeParams{
 					Client:        mockK8sClient,
 					Cluster:       &clusterv1.Cluster{},
@@ -16,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/clients/cache"
 
 	. "github.com/linode/cluster-api-provider-linode/clients"
 )
@@ -45,7 +46,9 @@ type ObjectStorageBucketScope struct {
 	Bucket       *infrav1alpha1.LinodeObjectStorageBucket
 	Logger       logr.Logger
 	LinodeClient LinodeClient
-	PatchHelper  *patch.Helper
+	// LinodeEndpoint is the Linode API endpoint LinodeClient talks to, as returned by cache.Endpoint.
+	LinodeEndpoint string
+	PatchHelper    *patch.Helper
 }
 
 const (
@@ -65,20 +68,20 @@ func validateObjectStorageBucketScopeParams(params ObjectStorageBucketScopeParam
 	return nil
 }
 
-func NewObjectStorageBucketScope(ctx context.Context, apiKey string, params ObjectStorageBucketScopeParams) (*ObjectStorageBucketScope, error) {
+func NewObjectStorageBucketScope(ctx context.Context, clientConfig ClientConfig, params ObjectStorageBucketScopeParams) (*ObjectStorageBucketScope, error) {
 	if err := validateObjectStorageBucketScopeParams(params); err != nil {
 		return nil, err
 	}
 
 	// Override the controller credentials with ones from the Cluster's Secret reference (if supplied).
 	if params.Bucket.Spec.CredentialsRef != nil {
-		data, err := getCredentialDataFromRef(ctx, params.Client, *params.Bucket.Spec.CredentialsRef, params.Bucket.GetNamespace())
+		config, err := getClientConfigFromRef(ctx, params.Client, *params.Bucket.Spec.CredentialsRef, params.Bucket.GetNamespace(), clientConfig)
 		if err != nil {
 			return nil, fmt.Errorf("credentials from cluster secret ref: %w", err)
 		}
-		apiKey = string(data)
+		clientConfig = config
 	}
-	linodeClient, err := CreateLinodeClient(apiKey, clientTimeout)
+	linodeClient, err := CreateLinodeClient(clientConfig, clientTimeout)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create linode client: %w", err)
 	}
@@ -89,11 +92,12 @@ func NewObjectStorageBucketScope(ctx context.Context, apiKey string, params Obje
 	}
 
 	return &ObjectStorageBucketScope{
-		Client:       params.Client,
-		Bucket:       params.Bucket,
-		Logger:       *params.Logger,
-		LinodeClient: linodeClient,
-		PatchHelper:  patchHelper,
+		Client:         params.Client,
+		Bucket:         params.Bucket,
+		Logger:         *params.Logger,
+		LinodeClient:   linodeClient,
+		LinodeEndpoint: cache.Endpoint(clientConfig.BaseURL, clientConfig.APIVersion),
+		PatchHelper:    patchHelper,
 	}, nil
 }
 
@@ -107,7 +107,7 @@ func TestNewObjectStorageBucketScope(t *testing.T) {
 			},
 		},
 		{
-			name: "Success - Validate getCredentialDataFromRef() return some apiKey Data and we create a valid ClusterScope",
+			name: "Success - Validate getClientConfigFromRef() return some apiKey Data and we create a valid ClusterScope",
 			args: args{
 				apiKey: "apikey",
 				params: ObjectStorageBucketScopeParams{
@@ -166,7 +166,7 @@ func TestNewObjectStorageBucketScope(t *testing.T) {
 			},
 		},
 		{
-			name: "Error - Using getCredentialDataFromRef(), func returns an error. Unable to create a valid ClusterScope",
+			name: "Error - Using getClientConfigFromRef(), func returns an error. Unable to create a valid ClusterScope",
 			args: args{
 				apiKey: "test-key",
 				params: ObjectStorageBucketScopeParams{
@@ -215,7 +215,7 @@ func TestNewObjectStorageBucketScope(t *testing.T) {
 
 			testcase.args.params.Client = mockK8sClient
 
-			got, err := NewObjectStorageBucketScope(context.Background(), testcase.args.apiKey, testcase.args.params)
+			got, err := NewObjectStorageBucketScope(context.Background(), ClientConfig{Token: testcase.args.apiKey}, testcase.args.params)
 
 			if testcase.expectedErr != nil {
 				assert.ErrorContains(t, err, testcase.expectedErr.Error())
@@ -275,7 +275,7 @@ func TestObjectStorageBucketScopeMethods(t *testing.T) {
 
 			objScope, err := NewObjectStorageBucketScope(
 				context.Background(),
-				"test-key",
+				ClientConfig{Token: "test-key"},
 				ObjectStorageBucketScopeParams{
 					Client: mockK8sClient,
 					Bucket: testcase.Bucket,
@@ -25,6 +25,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/clients/cache"
 
 	. "github.com/linode/cluster-api-provider-linode/clients"
 )
@@ -35,7 +36,9 @@ type VPCScope struct {
 
 	PatchHelper  *patch.Helper
 	LinodeClient LinodeClient
-	LinodeVPC    *infrav1alpha1.LinodeVPC
+	// LinodeEndpoint is the Linode API endpoint LinodeClient talks to, as returned by cache.Endpoint.
+	LinodeEndpoint string
+	LinodeVPC      *infrav1alpha1.LinodeVPC
 }
 
 // VPCScopeParams defines the input parameters used to create a new Scope.
@@ -54,20 +57,20 @@ func validateVPCScopeParams(params VPCScopeParams) error {
 
 // NewVPCScope creates a new Scope from the supplied parameters.
 // This is meant to be called for each reconcile iteration.
-func NewVPCScope(ctx context.Context, apiKey string, params VPCScopeParams) (*VPCScope, error) {
+func NewVPCScope(ctx context.Context, clientConfig ClientConfig, params VPCScopeParams) (*VPCScope, error) {
 	if err := validateVPCScopeParams(params); err != nil {
 		return nil, err
 	}
 
 	// Override the controller credentials with ones from the VPC's Secret reference (if supplied).
 	if params.LinodeVPC.Spec.CredentialsRef != nil {
-		data, err := getCredentialDataFromRef(ctx, params.Client, *params.LinodeVPC.Spec.CredentialsRef, params.LinodeVPC.GetNamespace())
+		config, err := getClientConfigFromRef(ctx, params.Client, *params.LinodeVPC.Spec.CredentialsRef, params.LinodeVPC.GetNamespace(), clientConfig)
 		if err != nil {
 			return nil, fmt.Errorf("credentials from secret ref: %w", err)
 		}
-		apiKey = string(data)
+		clientConfig = config
 	}
-	linodeClient, err := CreateLinodeClient(apiKey, defaultClientTimeout)
+	linodeClient, err := CreateLinodeClient(clientConfig, defaultClientTimeout)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create linode client: %w", err)
 	}
@@ -79,10 +82,11 @@ func NewVPCScope(ctx context.Context, apiKey string, params VPCScopeParams) (*VP
 	}
 
 	return &VPCScope{
-		Client:       params.Client,
-		LinodeClient: linodeClient,
-		LinodeVPC:    params.LinodeVPC,
-		PatchHelper:  helper,
+		Client:         params.Client,
+		LinodeClient:   linodeClient,
+		LinodeEndpoint: cache.Endpoint(clientConfig.BaseURL, clientConfig.APIVersion),
+		LinodeVPC:      params.LinodeVPC,
+		PatchHelper:    helper,
 	}, nil
 }
 
@@ -5,13 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"slices"
 
 	"github.com/go-logr/logr"
 	"github.com/linode/linodego"
+	"k8s.io/component-base/featuregate"
 	kutil "sigs.k8s.io/cluster-api/util"
 
+	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/clients"
 	"github.com/linode/cluster-api-provider-linode/cloud/scope"
+	"github.com/linode/cluster-api-provider-linode/feature"
 	"github.com/linode/cluster-api-provider-linode/util"
 )
 
@@ -25,32 +28,37 @@ func CreateNodeBalancer(ctx context.Context, clusterScope *scope.ClusterScope, l
 
 	NBLabel := clusterScope.LinodeCluster.Name
 	clusterUID := string(clusterScope.LinodeCluster.UID)
-	tags := []string{string(clusterScope.LinodeCluster.UID)}
-	listFilter := util.Filter{
-		ID:    clusterScope.LinodeCluster.Spec.Network.NodeBalancerID,
-		Label: NBLabel,
-		Tags:  tags,
+	tags := clusterScope.Tags()
+	// NodeBalancers created before the ownership tags were introduced are tagged with the bare cluster UID
+	listFilter := util.FilterAnd(
+		util.FilterEq("label", NBLabel),
+		util.FilterOr(util.FilterEq("tags", util.ClusterUIDTag(clusterUID)), util.FilterEq("tags", clusterUID)),
+	)
+	if nodeBalancerID := clusterScope.LinodeCluster.Spec.Network.NodeBalancerID; nodeBalancerID != nil {
+		listFilter = util.FilterEq("id", *nodeBalancerID)
 	}
 	filter, err := listFilter.String()
 	if err != nil {
 		return nil, err
 	}
-	linodeNBs, err := clusterScope.LinodeClient.ListNodeBalancers(ctx, linodego.NewListOptions(1, filter))
+	linodeNB, err = clients.FindUnique(ctx, clusterScope.LinodeClient.ListNodeBalancers, filter, func(nb linodego.NodeBalancer) int {
+		return nb.ID
+	})
 	if err != nil {
 		logger.Info("Failed to list NodeBalancers", "error", err.Error())
 
-		return nil, err
+		return nil, fmt.Errorf("find NodeBalancer: %w", err)
 	}
-	if len(linodeNBs) == 1 {
-		logger.Info(fmt.Sprintf("NodeBalancer %s already exists", *linodeNBs[0].Label))
-		if !slices.Contains(linodeNBs[0].Tags, clusterUID) {
+	if linodeNB != nil {
+		logger.Info(fmt.Sprintf("NodeBalancer %s already exists", *linodeNB.Label))
+		if !util.IsOwnedBy(linodeNB.Tags, clusterUID, clusterUID, clusterScope.LinodeCluster.Spec.Network.NodeBalancerID != nil) {
 			err = errors.New("NodeBalancer conflict")
-			logger.Error(err, fmt.Sprintf("NodeBalancer %s is not associated with cluster UID %s. Owner cluster is %s", *linodeNBs[0].Label, clusterUID, linodeNBs[0].Tags[0]))
+			logger.Error(err, fmt.Sprintf("NodeBalancer %s is not associated with cluster UID %s", *linodeNB.Label, clusterUID), "tags", linodeNB.Tags)
 
 			return nil, err
 		}
 
-		return &linodeNBs[0], nil
+		return linodeNB, nil
 	}
 
 	logger.Info(fmt.Sprintf("Creating NodeBalancer %s", clusterScope.LinodeCluster.Name))
@@ -71,6 +79,28 @@ func CreateNodeBalancer(ctx context.Context, clusterScope *scope.ClusterScope, l
 	return linodeNB, nil
 }
 
+// ReconcileNodeBalancerTags sets the missing tags of the cluster on its NodeBalancer, e.g. when it was created before
+// the ownership tags were introduced or the cluster was moved to another management cluster.
+func ReconcileNodeBalancerTags(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	if clusterScope.LinodeCluster.Spec.Network.NodeBalancerID == nil {
+		return nil
+	}
+	nodeBalancerID := *clusterScope.LinodeCluster.Spec.Network.NodeBalancerID
+
+	linodeNB, err := clusterScope.LinodeClient.GetNodeBalancer(ctx, nodeBalancerID)
+	if err != nil {
+		return err
+	}
+
+	tags, changed := util.MergeTags(linodeNB.Tags, clusterScope.Tags())
+	if !changed {
+		return nil
+	}
+	_, err = clusterScope.LinodeClient.UpdateNodeBalancer(ctx, nodeBalancerID, linodego.NodeBalancerUpdateOptions{Tags: &tags})
+
+	return err
+}
+
 // CreateNodeBalancerConfig creates NodeBalancer config if it does not exist
 func CreateNodeBalancerConfig(
 	ctx context.Context,
@@ -109,23 +139,15 @@ func AddNodeToNB(
 	ctx context.Context,
 	logger logr.Logger,
 	machineScope *scope.MachineScope,
+	gates featuregate.FeatureGate,
 ) error {
 	// Update the NB backend with the new instance if it's a control plane node
 	if !kutil.IsControlPlaneMachine(machineScope.Machine) {
 		return nil
 	}
 
-	// Get the private IP that was assigned
-	addresses, err := machineScope.LinodeClient.GetInstanceIPAddresses(ctx, *machineScope.LinodeMachine.Spec.InstanceID)
+	backendIP, err := nodeBalancerBackendIP(ctx, logger, machineScope, gates)
 	if err != nil {
-		logger.Error(err, "Failed get instance IP addresses")
-
-		return err
-	}
-	if len(addresses.IPv4.Private) == 0 {
-		err := errors.New("no private IP address")
-		logger.Error(err, "no private IPV4 addresses set for LinodeInstance")
-
 		return err
 	}
 
@@ -147,7 +169,7 @@ func AddNodeToNB(
 		*machineScope.LinodeCluster.Spec.Network.NodeBalancerConfigID,
 		linodego.NodeBalancerNodeCreateOptions{
 			Label:   machineScope.Cluster.Name,
-			Address: fmt.Sprintf("%s:%d", addresses.IPv4.Private[0].Address, lbPort),
+			Address: fmt.Sprintf("%s:%d", backendIP, lbPort),
 			Mode:    linodego.ModeAccept,
 		},
 	)
@@ -160,6 +182,66 @@ func AddNodeToNB(
 	return nil
 }
 
+// nodeBalancerBackendIP returns the address of the instance of the machine to register as a backend of the NodeBalancer,
+// from the source selected in the network of the LinodeCluster. The VPC source requires the NodeBalancerVPCBackends
+// feature, since the NodeBalancer is not attached to the VPC by CAPL.
+func nodeBalancerBackendIP(ctx context.Context, logger logr.Logger, machineScope *scope.MachineScope, gates featuregate.FeatureGate) (string, error) {
+	instanceID := *machineScope.LinodeMachine.Spec.InstanceID
+
+	if machineScope.LinodeCluster.Spec.Network.NodeBalancerBackendAddressSource == infrav1alpha1.NodeBalancerBackendAddressVPC {
+		if !gates.Enabled(feature.NodeBalancerVPCBackends) {
+			err := fmt.Errorf("the VPC backend address source requires the %s feature gate", feature.NodeBalancerVPCBackends)
+			logger.Error(err, "Failed to get the NodeBalancer backend address")
+
+			return "", err
+		}
+
+		// Get the VPC IP that was assigned
+		configs, err := machineScope.LinodeClient.ListInstanceConfigs(ctx, instanceID, &linodego.ListOptions{})
+		if err != nil {
+			logger.Error(err, "Failed to list instance configs")
+
+			return "", err
+		}
+		if len(configs) == 0 || len(VPCIPv4Addresses(configs[0])) == 0 {
+			err := errors.New("no VPC IP address")
+			logger.Error(err, "no VPC IPV4 addresses set for LinodeInstance")
+
+			return "", err
+		}
+
+		return VPCIPv4Addresses(configs[0])[0], nil
+	}
+
+	// Get the private IP that was assigned
+	addresses, err := machineScope.LinodeClient.GetInstanceIPAddresses(ctx, instanceID)
+	if err != nil {
+		logger.Error(err, "Failed get instance IP addresses")
+
+		return "", err
+	}
+	if len(addresses.IPv4.Private) == 0 {
+		err := errors.New("no private IP address")
+		logger.Error(err, "no private IPV4 addresses set for LinodeInstance")
+
+		return "", err
+	}
+
+	return addresses.IPv4.Private[0].Address, nil
+}
+
+// VPCIPv4Addresses returns the IPv4 addresses of the VPC interfaces of the instance config.
+func VPCIPv4Addresses(config linodego.InstanceConfig) []string {
+	var addresses []string
+	for _, iface := range config.Interfaces {
+		if iface.VPCID != nil && iface.IPv4 != nil && iface.IPv4.VPC != "" {
+			addresses = append(addresses, iface.IPv4.VPC)
+		}
+	}
+
+	return addresses
+}
+
 // DeleteNodeFromNB removes a backend Node from the Node Balancer configuration
 func DeleteNodeFromNB(
 	ctx context.Context,
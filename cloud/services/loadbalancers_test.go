@@ -8,6 +8,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/linode/linodego"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
@@ -15,6 +16,7 @@ import (
 
 	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
 	"github.com/linode/cluster-api-provider-linode/cloud/scope"
+	"github.com/linode/cluster-api-provider-linode/feature"
 	"github.com/linode/cluster-api-provider-linode/mock"
 )
 
@@ -113,6 +115,96 @@ func TestCreateNodeBalancer(t *testing.T) {
 			},
 			expectedError: fmt.Errorf("NodeBalancer conflict"),
 		},
+		{
+			name: "Error - List NodeBalancers returns a nodebalancer owned by another cluster",
+			clusterScope: &scope.ClusterScope{
+				LinodeCluster: &infrav1alpha1.LinodeCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-cluster",
+						UID:  "test-uid",
+					},
+				},
+			},
+			expects: func(mockClient *mock.MockLinodeClient) {
+				mockClient.EXPECT().ListNodeBalancers(gomock.Any(), gomock.Any()).Return([]linodego.NodeBalancer{
+					{
+						ID:    1234,
+						Label: ptr.To("test-cluster"),
+						Tags:  []string{"capl", "capl-uid=other-uid", "test-uid"},
+					},
+				}, nil)
+			},
+			expectedError: fmt.Errorf("NodeBalancer conflict"),
+		},
+		{
+			name: "Success - List NodeBalancers returns the legacy nodebalancer of the cluster by ID",
+			clusterScope: &scope.ClusterScope{
+				LinodeCluster: &infrav1alpha1.LinodeCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-cluster",
+						UID:  "test-uid",
+					},
+					Spec: infrav1alpha1.LinodeClusterSpec{
+						Network: infrav1alpha1.NetworkSpec{
+							NodeBalancerID: ptr.To(1234),
+						},
+					},
+				},
+			},
+			expects: func(mockClient *mock.MockLinodeClient) {
+				mockClient.EXPECT().ListNodeBalancers(gomock.Any(), gomock.Any()).Return([]linodego.NodeBalancer{
+					{
+						ID:    1234,
+						Label: ptr.To("test-cluster"),
+						Tags:  []string{"test-uid"},
+					},
+				}, nil)
+			},
+			expectedNodeBalancer: &linodego.NodeBalancer{
+				ID:    1234,
+				Label: ptr.To("test-cluster"),
+				Tags:  []string{"test-uid"},
+			},
+		},
+		{
+			name: "Error - List NodeBalancers returns a legacy nodebalancer by label",
+			clusterScope: &scope.ClusterScope{
+				LinodeCluster: &infrav1alpha1.LinodeCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-cluster",
+						UID:  "test-uid",
+					},
+				},
+			},
+			expects: func(mockClient *mock.MockLinodeClient) {
+				mockClient.EXPECT().ListNodeBalancers(gomock.Any(), gomock.Any()).Return([]linodego.NodeBalancer{
+					{
+						ID:    1234,
+						Label: ptr.To("test-cluster"),
+						Tags:  []string{"test-uid"},
+					},
+				}, nil)
+			},
+			expectedError: fmt.Errorf("NodeBalancer conflict"),
+		},
+		{
+			name: "Error - List NodeBalancers returns multiple nodebalancers",
+			clusterScope: &scope.ClusterScope{
+				LinodeCluster: &infrav1alpha1.LinodeCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-cluster",
+						UID:  "test-uid",
+					},
+				},
+			},
+			expects: func(mockClient *mock.MockLinodeClient) {
+				mockClient.EXPECT().ListNodeBalancers(gomock.Any(), gomock.Any()).Return([]linodego.NodeBalancer{
+					{ID: 1234, Label: ptr.To("test-cluster"), Tags: []string{"capl-uid=test-uid"}},
+					{ID: 5678, Label: ptr.To("test-cluster"), Tags: []string{"test-uid"}},
+				}, nil)
+			},
+			expectedError: fmt.Errorf("multiple matches: 1234, 5678"),
+		},
 		{
 			name: "Error - List NodeBalancers returns an error",
 			clusterScope: &scope.ClusterScope{
@@ -180,6 +272,77 @@ func TestCreateNodeBalancer(t *testing.T) {
 	}
 }
 
+func TestReconcileNodeBalancerTags(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		tags          []string
+		expects       func(*mock.MockLinodeClient)
+		expectedError error
+	}{
+		{
+			name: "Success - Tags are up to date",
+			tags: []string{"capl", "capl-mc=test-mc", "capl-ns=default", "capl-cluster=test-cluster", "capl-uid=test-uid"},
+		},
+		{
+			name: "Success - Legacy tags are migrated",
+			tags: []string{"test-uid"},
+			expects: func(mockClient *mock.MockLinodeClient) {
+				mockClient.EXPECT().UpdateNodeBalancer(gomock.Any(), 1234, linodego.NodeBalancerUpdateOptions{
+					Tags: &[]string{"test-uid", "capl", "capl-mc=test-mc", "capl-ns=default", "capl-cluster=test-cluster", "capl-uid=test-uid"},
+				}).Return(&linodego.NodeBalancer{ID: 1234}, nil)
+			},
+		},
+		{
+			name: "Error - Update NodeBalancer returns an error",
+			tags: []string{"test-uid"},
+			expects: func(mockClient *mock.MockLinodeClient) {
+				mockClient.EXPECT().UpdateNodeBalancer(gomock.Any(), 1234, gomock.Any()).Return(nil, fmt.Errorf("Unable to update NodeBalancer"))
+			},
+			expectedError: fmt.Errorf("Unable to update NodeBalancer"),
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			MockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			MockLinodeClient.EXPECT().GetNodeBalancer(gomock.Any(), 1234).Return(&linodego.NodeBalancer{ID: 1234, Tags: testcase.tags}, nil)
+			if testcase.expects != nil {
+				testcase.expects(MockLinodeClient)
+			}
+
+			clusterScope := &scope.ClusterScope{
+				LinodeClient:        MockLinodeClient,
+				ManagementClusterID: "test-mc",
+				LinodeCluster: &infrav1alpha1.LinodeCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-cluster",
+						Namespace: "default",
+						UID:       "test-uid",
+					},
+					Spec: infrav1alpha1.LinodeClusterSpec{
+						Network: infrav1alpha1.NetworkSpec{
+							NodeBalancerID: ptr.To(1234),
+						},
+					},
+				},
+			}
+
+			err := ReconcileNodeBalancerTags(context.Background(), clusterScope)
+			if testcase.expectedError != nil {
+				assert.ErrorContains(t, err, testcase.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestCreateNodeBalancerConfig(t *testing.T) {
 	t.Parallel()
 
@@ -315,10 +478,11 @@ func TestAddNodeToNBConditions(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		name          string
-		machineScope  *scope.MachineScope
-		expectedError error
-		expects       func(*mock.MockLinodeClient)
+		name                string
+		machineScope        *scope.MachineScope
+		vpcBackendsDisabled bool
+		expectedError       error
+		expects             func(*mock.MockLinodeClient)
 	}{
 		{
 			name: "Error - NodeBalancerConfigID are is set",
@@ -380,6 +544,12 @@ func TestAddNodeToNBConditions(t *testing.T) {
 						},
 					},
 				},
+				LinodeCluster: &infrav1alpha1.LinodeCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-cluster",
+						UID:  "test-uid",
+					},
+				},
 				LinodeMachine: &infrav1alpha1.LinodeMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "test-machine",
@@ -411,6 +581,12 @@ func TestAddNodeToNBConditions(t *testing.T) {
 						},
 					},
 				},
+				LinodeCluster: &infrav1alpha1.LinodeCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-cluster",
+						UID:  "test-uid",
+					},
+				},
 				LinodeMachine: &infrav1alpha1.LinodeMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "test-machine",
@@ -426,6 +602,83 @@ func TestAddNodeToNBConditions(t *testing.T) {
 				mockClient.EXPECT().GetInstanceIPAddresses(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("could not get instance IP addresses"))
 			},
 		},
+		{
+			name: "Error - VPC backend address source with the NodeBalancerVPCBackends feature disabled",
+			machineScope: &scope.MachineScope{
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-machine",
+						UID:  "test-uid",
+						Labels: map[string]string{
+							clusterv1.MachineControlPlaneLabel: "true",
+						},
+					},
+				},
+				LinodeCluster: &infrav1alpha1.LinodeCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-cluster",
+						UID:  "test-uid",
+					},
+					Spec: infrav1alpha1.LinodeClusterSpec{
+						Network: infrav1alpha1.NetworkSpec{
+							NodeBalancerBackendAddressSource: infrav1alpha1.NodeBalancerBackendAddressVPC,
+						},
+					},
+				},
+				LinodeMachine: &infrav1alpha1.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-machine",
+						UID:  "test-uid",
+					},
+					Spec: infrav1alpha1.LinodeMachineSpec{
+						InstanceID: ptr.To(123),
+					},
+				},
+			},
+			vpcBackendsDisabled: true,
+			expectedError:       fmt.Errorf("the VPC backend address source requires the NodeBalancerVPCBackends feature gate"),
+			expects:             func(*mock.MockLinodeClient) {},
+		},
+		{
+			name: "Error - No VPC IP addresses were set",
+			machineScope: &scope.MachineScope{
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-machine",
+						UID:  "test-uid",
+						Labels: map[string]string{
+							clusterv1.MachineControlPlaneLabel: "true",
+						},
+					},
+				},
+				LinodeCluster: &infrav1alpha1.LinodeCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-cluster",
+						UID:  "test-uid",
+					},
+					Spec: infrav1alpha1.LinodeClusterSpec{
+						Network: infrav1alpha1.NetworkSpec{
+							NodeBalancerBackendAddressSource: infrav1alpha1.NodeBalancerBackendAddressVPC,
+						},
+					},
+				},
+				LinodeMachine: &infrav1alpha1.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-machine",
+						UID:  "test-uid",
+					},
+					Spec: infrav1alpha1.LinodeMachineSpec{
+						InstanceID: ptr.To(123),
+					},
+				},
+			},
+			expectedError: fmt.Errorf("no VPC IP address"),
+			expects: func(mockClient *mock.MockLinodeClient) {
+				mockClient.EXPECT().ListInstanceConfigs(gomock.Any(), gomock.Any(), gomock.Any()).Return([]linodego.InstanceConfig{{
+					Interfaces: []linodego.InstanceConfigInterface{{Purpose: linodego.InterfacePurposePublic}},
+				}}, nil)
+			},
+		},
 	}
 	for _, tt := range tests {
 		testcase := tt
@@ -440,7 +693,10 @@ func TestAddNodeToNBConditions(t *testing.T) {
 
 			testcase.expects(MockLinodeClient)
 
-			err := AddNodeToNB(context.Background(), logr.Discard(), testcase.machineScope)
+			gates := feature.NewGates()
+			require.NoError(t, gates.Set(fmt.Sprintf("NodeBalancerVPCBackends=%t", !testcase.vpcBackendsDisabled)))
+
+			err := AddNodeToNB(context.Background(), logr.Discard(), testcase.machineScope, gates)
 			if testcase.expectedError != nil {
 				assert.ErrorContains(t, err, testcase.expectedError.Error())
 			}
@@ -528,6 +784,61 @@ func TestAddNodeToNBFullWorkflow(t *testing.T) {
 				mockClient.EXPECT().CreateNodeBalancerNode(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&linodego.NodeBalancerNode{}, nil)
 			},
 		},
+		{
+			name: "Success - If the backend address source is VPC, add the node with its VPC IP",
+			machineScope: &scope.MachineScope{
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-machine",
+						UID:  "test-uid",
+						Labels: map[string]string{
+							clusterv1.MachineControlPlaneLabel: "true",
+						},
+					},
+				},
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-cluster",
+						UID:  "test-uid",
+					},
+				},
+				LinodeCluster: &infrav1alpha1.LinodeCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-cluster",
+						UID:  "test-uid",
+					},
+					Spec: infrav1alpha1.LinodeClusterSpec{
+						Network: infrav1alpha1.NetworkSpec{
+							NodeBalancerID:                   ptr.To(1234),
+							NodeBalancerConfigID:             ptr.To(5678),
+							NodeBalancerBackendAddressSource: infrav1alpha1.NodeBalancerBackendAddressVPC,
+						},
+					},
+				},
+				LinodeMachine: &infrav1alpha1.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-machine",
+						UID:  "test-uid",
+					},
+					Spec: infrav1alpha1.LinodeMachineSpec{
+						InstanceID: ptr.To(123),
+					},
+				},
+			},
+			expects: func(mockClient *mock.MockLinodeClient) {
+				mockClient.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceConfig{{
+					Interfaces: []linodego.InstanceConfigInterface{
+						{Purpose: linodego.InterfacePurposePublic},
+						{Purpose: linodego.InterfacePurposeVPC, VPCID: ptr.To(1), IPv4: &linodego.VPCIPv4{VPC: "10.0.0.2"}},
+					},
+				}}, nil)
+				mockClient.EXPECT().CreateNodeBalancerNode(gomock.Any(), 1234, 5678, linodego.NodeBalancerNodeCreateOptions{
+					Label:   "test-cluster",
+					Address: "10.0.0.2:6443",
+					Mode:    linodego.ModeAccept,
+				}).Return(&linodego.NodeBalancerNode{}, nil)
+			},
+		},
 		{
 			name: "Error - CreateNodeBalancerNode() returns an error",
 			machineScope: &scope.MachineScope{
@@ -596,7 +907,10 @@ func TestAddNodeToNBFullWorkflow(t *testing.T) {
 
 			testcase.expects(MockLinodeClient)
 
-			err := AddNodeToNB(context.Background(), logr.Discard(), testcase.machineScope)
+			gates := feature.NewGates()
+			require.NoError(t, gates.Set("NodeBalancerVPCBackends=true"))
+
+			err := AddNodeToNB(context.Background(), logr.Discard(), testcase.machineScope, gates)
 			if testcase.expectedError != nil {
 				assert.ErrorContains(t, err, testcase.expectedError.Error())
 			}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/linode/linodego"
 
+	"github.com/linode/cluster-api-provider-linode/clients"
 	"github.com/linode/cluster-api-provider-linode/cloud/scope"
 	"github.com/linode/cluster-api-provider-linode/util"
 	"github.com/linode/cluster-api-provider-linode/version"
@@ -19,21 +20,20 @@ var stackscriptTemplate string
 
 func EnsureStackscript(ctx context.Context, machineScope *scope.MachineScope) (int, error) {
 	stackscriptName := fmt.Sprintf("CAPL-%s", version.GetVersion())
-	listFilter := util.Filter{
-		ID:    nil,
-		Label: stackscriptName,
-		Tags:  []string{},
-	}
+	// Public StackScripts can have the same label
+	listFilter := util.FilterAnd(util.FilterEq("label", stackscriptName), util.FilterEq("mine", true))
 	filter, err := listFilter.String()
 	if err != nil {
 		return 0, err
 	}
-	stackscripts, err := machineScope.LinodeClient.ListStackscripts(ctx, &linodego.ListOptions{Filter: filter})
+	stackscript, err := clients.FindUnique(ctx, machineScope.LinodeClient.ListStackscripts, filter, func(stackscript linodego.Stackscript) int {
+		return stackscript.ID
+	})
 	if util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
 		return 0, fmt.Errorf("failed to get stackscript with label %s: %w", stackscriptName, err)
 	}
-	if stackscripts != nil {
-		return stackscripts[0].ID, nil
+	if stackscript != nil {
+		return stackscript.ID, nil
 	}
 	stackscriptCreateOptions := linodego.StackscriptCreateOptions{
 		Label:       fmt.Sprintf("CAPL-%s", version.GetVersion()),
@@ -41,7 +41,7 @@ func EnsureStackscript(ctx context.Context, machineScope *scope.MachineScope) (i
 		Script:      stackscriptTemplate,
 		Images:      []string{"any/all"},
 	}
-	stackscript, err := machineScope.LinodeClient.CreateStackscript(ctx, stackscriptCreateOptions)
+	stackscript, err = machineScope.LinodeClient.CreateStackscript(ctx, stackscriptCreateOptions)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create StackScript: %w", err)
 	}
@@ -28,7 +28,7 @@ func TestEnsureStackscripts(t *testing.T) {
 			machineScope: &scope.MachineScope{},
 			want:         1234,
 			expects: func(mockClient *mock.MockLinodeClient) {
-				mockClient.EXPECT().ListStackscripts(gomock.Any(), &linodego.ListOptions{Filter: "{\"label\":\"CAPL-dev\"}"}).Return([]linodego.Stackscript{{
+				mockClient.EXPECT().ListStackscripts(gomock.Any(), &linodego.ListOptions{PageOptions: &linodego.PageOptions{Page: 1}, PageSize: 500, Filter: "{\"+and\":[{\"label\":\"CAPL-dev\"},{\"mine\":true}]}"}).Return([]linodego.Stackscript{{
 					Label: "CAPI Test 1",
 					ID:    1234,
 				}}, nil)
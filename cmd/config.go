@@ -0,0 +1,153 @@
+/*
+Copyright 2023 Akamai Technologies, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/yaml"
+)
+
+// controllerOptions are the tuning options of a controller, set with flags prefixed with the controller name.
+type controllerOptions struct {
+	watchFilter      string
+	concurrency      int
+	reconcileTimeout time.Duration
+	requeueDelay     time.Duration
+}
+
+// bindFlags registers the flags of the controller named name, reconciling the given objects. The requeue delay flag
+// is only registered for the controllers with a default requeue delay.
+func (o *controllerOptions) bindFlags(fs *flag.FlagSet, name, objects string, defaultRequeueDelay time.Duration) {
+	fs.StringVar(&o.watchFilter, name+"-watch-filter", "", fmt.Sprintf("The %s to watch by label.", objects))
+	fs.IntVar(&o.concurrency, name+"-concurrency", 1, fmt.Sprintf("The number of %s to reconcile concurrently.", objects))
+	fs.DurationVar(&o.reconcileTimeout, name+"-reconcile-timeout", 0,
+		fmt.Sprintf("The maximum duration of a reconcile of the %s, which also bounds how long failing operations "+
+			"are retried before being reported. Defaults to the built-in timeouts when 0.", objects))
+	if defaultRequeueDelay > 0 {
+		fs.DurationVar(&o.requeueDelay, name+"-requeue-delay", defaultRequeueDelay,
+			fmt.Sprintf("How long to wait before checking on a pending or failed operation on the %s again.", objects))
+	}
+}
+
+// validate returns an error naming the flag of the controller named name with an invalid value.
+func (o *controllerOptions) validate(name string) error {
+	switch {
+	case o.concurrency < 1:
+		return fmt.Errorf("--%s-concurrency must be at least 1", name)
+	case o.reconcileTimeout < 0:
+		return fmt.Errorf("--%s-reconcile-timeout must not be negative", name)
+	case o.requeueDelay < 0:
+		return fmt.Errorf("--%s-requeue-delay must not be negative", name)
+	}
+
+	return nil
+}
+
+// options returns the controller-runtime options of the controller.
+func (o *controllerOptions) options() crcontroller.Options {
+	return crcontroller.Options{MaxConcurrentReconciles: o.concurrency}
+}
+
+// parseNamespaces returns the namespaces of a comma-separated list, ignoring empty entries.
+func parseNamespaces(list string) []string {
+	var namespaces []string
+	for _, namespace := range strings.Split(list, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" && !slices.Contains(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+
+	return namespaces
+}
+
+// loadConfigFile sets the flags of fs from the YAML file at path, whose keys are flag names, e.g.
+//
+//	cluster-concurrency: 5
+//	namespace: [team-a, team-b]
+//
+// Flags set on the command line take precedence over the file. Lists are joined with commas.
+func loadConfigFile(fs *flag.FlagSet, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+	var config map[string]any
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	names := make([]string, 0, len(config))
+	for name := range config {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if fs.Lookup(name) == nil {
+			return fmt.Errorf("unknown flag %q in config file %s", name, path)
+		}
+		if explicit[name] {
+			continue
+		}
+		value, err := configValue(config[name])
+		if err != nil {
+			return fmt.Errorf("invalid value for %q in config file %s: %w", name, path, err)
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("invalid value for %q in config file %s: %w", name, path, err)
+		}
+	}
+
+	return nil
+}
+
+// configValue returns the flag value for a value of the config file.
+func configValue(value any) (string, error) {
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case []any:
+		items := make([]string, len(value))
+		for i, item := range value {
+			var err error
+			if items[i], err = configValue(item); err != nil {
+				return "", err
+			}
+		}
+
+		return strings.Join(items, ","), nil
+	default:
+		return "", errors.New("must be a string, a number, a boolean or a list of them")
+	}
+}
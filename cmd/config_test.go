@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfigFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		args        []string
+		config      string
+		expected    controllerOptions
+		expectedNS  string
+		expectedErr string
+	}{
+		{
+			name: "Success - flags are set from the file",
+			config: `cluster-concurrency: 5
+cluster-reconcile-timeout: 10m
+cluster-watch-filter: team-a
+namespace: [team-a, team-b]
+`,
+			expected:   controllerOptions{watchFilter: "team-a", concurrency: 5, reconcileTimeout: 10 * time.Minute, requeueDelay: time.Second},
+			expectedNS: "team-a,team-b",
+		},
+		{
+			name:       "Success - command line takes precedence",
+			args:       []string{"--cluster-concurrency=2"},
+			config:     "cluster-concurrency: 5\n",
+			expected:   controllerOptions{concurrency: 2, requeueDelay: time.Second},
+			expectedNS: "",
+		},
+		{
+			name:        "Error - unknown flag",
+			config:      "cluster-concurency: 5\n",
+			expectedErr: `unknown flag "cluster-concurency"`,
+		},
+		{
+			name:        "Error - invalid value",
+			config:      "cluster-concurrency: many\n",
+			expectedErr: `invalid value for "cluster-concurrency"`,
+		},
+		{
+			name:        "Error - unsupported value",
+			config:      "cluster-watch-filter: {team: a}\n",
+			expectedErr: "must be a string, a number, a boolean or a list of them",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				opts       controllerOptions
+				namespaces string
+			)
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			opts.bindFlags(fs, "cluster", "clusters", time.Second)
+			fs.StringVar(&namespaces, "namespace", "", "")
+			require.NoError(t, fs.Parse(testcase.args))
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(testcase.config), 0o600))
+
+			err := loadConfigFile(fs, path)
+			if testcase.expectedErr != "" {
+				require.ErrorContains(t, err, testcase.expectedErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expected, opts)
+			assert.Equal(t, testcase.expectedNS, namespaces)
+		})
+	}
+}
+
+func TestParseNamespaces(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"team-a", "team-b"}, parseNamespaces(" team-a,,team-b,team-a "))
+	assert.Empty(t, parseNamespaces(""))
+}
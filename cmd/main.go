@@ -17,22 +17,36 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	infrastructurev1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/cloud/events"
+	"github.com/linode/cluster-api-provider-linode/cloud/scope"
 	controller2 "github.com/linode/cluster-api-provider-linode/controller"
+	"github.com/linode/cluster-api-provider-linode/feature"
+	"github.com/linode/cluster-api-provider-linode/observability/metrics"
+	"github.com/linode/cluster-api-provider-linode/observability/tracing"
+	"github.com/linode/cluster-api-provider-linode/util/reconciler"
 	"github.com/linode/cluster-api-provider-linode/version"
 
 	_ "go.uber.org/automaxprocs"
@@ -42,6 +56,15 @@ import (
 	// +kubebuilder:scaffold:imports
 )
 
+const (
+	// linodeClientTimeout is the timeout for Linode API calls made with the controller credentials
+	linodeClientTimeout = 10 * time.Second
+	// linodeEventMirrorConfigMap is the name of the ConfigMap storing the ID of the last mirrored Linode event
+	linodeEventMirrorConfigMap = "capl-linode-event-mirror"
+	// linodeOrphanSweeperConfigMap is the name of the ConfigMap the orphan sweeper reports orphaned resources in
+	linodeOrphanSweeperConfigMap = "capl-orphan-sweeper"
+)
+
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
@@ -50,6 +73,7 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(capi.AddToScheme(scheme))
+	utilruntime.Must(ipamv1.AddToScheme(scheme))
 	utilruntime.Must(infrastructurev1alpha1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
@@ -58,39 +82,131 @@ func init() {
 func main() {
 	var (
 		// Environment variables
-		linodeToken string = os.Getenv("LINODE_TOKEN")
-
-		machineWatchFilter             string
-		clusterWatchFilter             string
-		objectStorageBucketWatchFilter string
-		metricsAddr                    string
-		enableLeaderElection           bool
-		probeAddr                      string
+		linodeToken  string = os.Getenv("LINODE_TOKEN")
+		podNamespace string = os.Getenv("POD_NAMESPACE")
+
+		clusterOpts              controllerOptions
+		machineOpts              controllerOptions
+		vpcOpts                  controllerOptions
+		objectStorageBucketOpts  controllerOptions
+		configFile               string
+		featureGates             string
+		namespaces               string
+		metricsAddr              string
+		enableLeaderElection     bool
+		probeAddr                string
+		tracingOpts              tracing.Options
+		linodeClientConfig       scope.ClientConfig
+		linodeEventsPollInterval time.Duration
+		mirrorLinodeEvents       bool
+		enableOrphanSweeper      bool
+		orphanSweeper            controller2.LinodeOrphanSweeper
+		managementClusterID      string
 	)
-	flag.StringVar(&machineWatchFilter, "machine-watch-filter", "", "The machines to watch by label.")
-	flag.StringVar(&clusterWatchFilter, "cluster-watch-filter", "", "The clusters to watch by label.")
-	flag.StringVar(&objectStorageBucketWatchFilter, "object-storage-bucket-watch-filter", "", "The object bucket storages to watch by label.")
+	clusterOpts.bindFlags(flag.CommandLine, "cluster", "clusters", reconciler.DefaultClusterControllerReconcileDelay)
+	machineOpts.bindFlags(flag.CommandLine, "machine", "machines", reconciler.DefaultMachineControllerWaitForRunningDelay)
+	vpcOpts.bindFlags(flag.CommandLine, "vpc", "VPCs", reconciler.DefaultVPCControllerReconcileDelay)
+	objectStorageBucketOpts.bindFlags(flag.CommandLine, "object-storage-bucket", "object storage buckets", 0)
+	flag.StringVar(&configFile, "config", "",
+		"A YAML file setting flags by name, e.g. \"cluster-concurrency: 5\". Flags on the command line take precedence.")
+	flag.StringVar(&featureGates, "feature-gates", "",
+		"A comma-separated list of feature=true|false pairs enabling or disabling features. Known features: "+
+			strings.Join(feature.MutableGates.KnownFeatures(), ", ")+".")
+	flag.StringVar(&namespaces, "namespace", "",
+		"A comma-separated list of the namespaces to watch. All namespaces are watched when empty.")
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&linodeClientConfig.BaseURL, "linode-api-url", "",
+		"The URL of the Linode API, e.g. to use a proxy. Defaults to the public API, or LINODE_URL when set.")
+	flag.StringVar(&linodeClientConfig.APIVersion, "linode-api-version", "",
+		"The Linode API version, e.g. v4beta. Defaults to v4, or LINODE_API_VERSION when set.")
+	flag.DurationVar(&linodeEventsPollInterval, "linode-events-poll-interval", events.DefaultPollInterval,
+		"How often to poll the Linode account events to detect completed operations. Polling is disabled when 0.")
+	flag.BoolVar(&mirrorLinodeEvents, "mirror-linode-events", false,
+		"Mirror the Linode account events into Kubernetes Events on the affected LinodeMachines, LinodeClusters and LinodeVPCs.")
+	flag.BoolVar(&enableOrphanSweeper, "enable-orphan-sweeper", false,
+		"Periodically report the Linode resources created by CAPL which are no longer managed by any object.")
+	flag.DurationVar(&orphanSweeper.Interval, "orphan-sweeper-interval", controller2.DefaultOrphanSweeperInterval,
+		"The time between two sweeps of the orphan sweeper.")
+	flag.DurationVar(&orphanSweeper.GracePeriod, "orphan-sweeper-grace-period", controller2.DefaultOrphanSweeperGracePeriod,
+		"How long a Linode resource has to be orphaned before the orphan sweeper deletes it.")
+	flag.BoolVar(&orphanSweeper.Delete, "orphan-sweeper-delete", false,
+		"Delete the orphaned instances and NodeBalancers once the grace period has passed.")
+	flag.BoolVar(&orphanSweeper.DryRun, "orphan-sweeper-dry-run", false,
+		"Log the orphaned Linode resources which would be deleted instead of deleting them.")
+	flag.StringVar(&managementClusterID, "management-cluster-id", "",
+		"The ID of the management cluster, added as a tag to the Linode resources. Defaults to the UID of the kube-system namespace.")
+	flag.StringVar(&tracingOpts.Endpoint, "tracing-endpoint", "",
+		"The host:port of an OTLP gRPC collector to export traces to. Tracing is disabled when empty.")
+	flag.BoolVar(&tracingOpts.Insecure, "tracing-insecure", false, "Disable TLS when connecting to the tracing endpoint.")
+	flag.Float64Var(&tracingOpts.SamplingRate, "tracing-sampling-rate", 1, "The fraction of reconciles to trace, between 0 and 1.")
 	opts := zap.Options{
 		Development: true,
 	}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
+	if configFile != "" {
+		if err := loadConfigFile(flag.CommandLine, configFile); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	if err := feature.MutableGates.Set(featureGates); err != nil {
+		setupLog.Error(err, "invalid feature gates")
+		os.Exit(1)
+	}
+	setupLog.Info("feature gates", "enabled", feature.Enabled(feature.Gates))
+	for name, spec := range feature.Known() {
+		metrics.SetFeatureEnabled(string(name), string(spec.PreRelease), feature.Gates.Enabled(name))
+	}
+	for name, controllerOpts := range map[string]*controllerOptions{
+		"cluster":               &clusterOpts,
+		"machine":               &machineOpts,
+		"vpc":                   &vpcOpts,
+		"object-storage-bucket": &objectStorageBucketOpts,
+	} {
+		if err := controllerOpts.validate(name); err != nil {
+			setupLog.Error(err, "invalid controller settings")
+			os.Exit(1)
+		}
+	}
 	setupLog.Info(fmt.Sprintf("CAPL version: %s", version.GetVersion()))
 	// Check environment variables
 	if linodeToken == "" {
 		setupLog.Error(errors.New("failed to get LINODE_TOKEN environment variable"), "unable to start operator")
 		os.Exit(1)
 	}
+	linodeClientConfig.Token = linodeToken
+	linodeClient, err := scope.CreateLinodeClient(linodeClientConfig, linodeClientTimeout)
+	if err != nil {
+		setupLog.Error(err, "invalid Linode API client settings")
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracingOpts)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
+	var cacheOpts cache.Options
+	if watchNamespaces := parseNamespaces(namespaces); len(watchNamespaces) > 0 {
+		setupLog.Info("watching namespaces", "namespaces", watchNamespaces)
+		cacheOpts.DefaultNamespaces = make(map[string]cache.Config, len(watchNamespaces))
+		for _, namespace := range watchNamespaces {
+			cacheOpts.DefaultNamespaces[namespace] = cache.Config{}
+		}
+		orphanSweeper.Namespaces = watchNamespaces
+	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  cacheOpts,
 		Metrics:                metricsserver.Options{BindAddress: metricsAddr},
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
@@ -112,45 +228,118 @@ func main() {
 		os.Exit(1)
 	}
 
+	if managementClusterID == "" {
+		ctx, cancel := context.WithTimeout(context.Background(), linodeClientTimeout)
+		var namespace corev1.Namespace
+		err = mgr.GetAPIReader().Get(ctx, client.ObjectKey{Name: metav1.NamespaceSystem}, &namespace)
+		cancel()
+		if err != nil {
+			setupLog.Error(err, "unable to get the management cluster ID, set --management-cluster-id")
+			os.Exit(1)
+		}
+		managementClusterID = string(namespace.UID)
+	}
+	setupLog.Info("management cluster", "id", managementClusterID)
+
+	var linodeEventPoller *events.Poller
+	if linodeEventsPollInterval > 0 {
+		linodeEventPoller = events.NewPoller(metrics.NewLinodeClient(linodeClient, "linodeeventpoller"), linodeEventsPollInterval)
+		if err = mgr.Add(linodeEventPoller); err != nil {
+			setupLog.Error(err, "unable to add Linode event poller")
+			os.Exit(1)
+		}
+	}
+
+	if mirrorLinodeEvents {
+		if linodeEventPoller == nil {
+			setupLog.Error(errors.New("--mirror-linode-events requires --linode-events-poll-interval to be set"), "unable to create Linode event mirror")
+			os.Exit(1)
+		}
+		if podNamespace == "" {
+			setupLog.Error(errors.New("failed to get POD_NAMESPACE environment variable"), "unable to create Linode event mirror")
+			os.Exit(1)
+		}
+		if err = (&controller2.LinodeEventMirror{
+			Client:    mgr.GetClient(),
+			Recorder:  mgr.GetEventRecorderFor("LinodeEventMirror"),
+			ConfigMap: client.ObjectKey{Namespace: podNamespace, Name: linodeEventMirrorConfigMap},
+		}).SetupWithManager(mgr, linodeEventPoller); err != nil {
+			setupLog.Error(err, "unable to create Linode event mirror")
+			os.Exit(1)
+		}
+	}
+
+	if enableOrphanSweeper {
+		if podNamespace == "" {
+			setupLog.Error(errors.New("failed to get POD_NAMESPACE environment variable"), "unable to create Linode orphan sweeper")
+			os.Exit(1)
+		}
+		if orphanSweeper.Interval <= 0 {
+			setupLog.Error(errors.New("--orphan-sweeper-interval must be positive"), "unable to create Linode orphan sweeper")
+			os.Exit(1)
+		}
+		orphanSweeper.Client = mgr.GetClient()
+		orphanSweeper.LinodeClient = metrics.NewLinodeClient(linodeClient, "linodeorphansweeper")
+		orphanSweeper.ConfigMap = client.ObjectKey{Namespace: podNamespace, Name: linodeOrphanSweeperConfigMap}
+		orphanSweeper.ManagementClusterID = managementClusterID
+		if err = orphanSweeper.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create Linode orphan sweeper")
+			os.Exit(1)
+		}
+	}
+
 	if err = (&controller2.LinodeClusterReconciler{
-		Client:           mgr.GetClient(),
-		Recorder:         mgr.GetEventRecorderFor("LinodeClusterReconciler"),
-		WatchFilterValue: clusterWatchFilter,
-		LinodeApiKey:     linodeToken,
-	}).SetupWithManager(mgr); err != nil {
+		Client:              mgr.GetClient(),
+		Recorder:            mgr.GetEventRecorderFor("LinodeClusterReconciler"),
+		WatchFilterValue:    clusterOpts.watchFilter,
+		LinodeClientConfig:  linodeClientConfig,
+		ReconcileTimeout:    clusterOpts.reconcileTimeout,
+		RequeueDelay:        clusterOpts.requeueDelay,
+		ManagementClusterID: managementClusterID,
+	}).SetupWithManager(mgr, clusterOpts.options()); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "LinodeCluster")
 		os.Exit(1)
 	}
 	if err = (&controller2.LinodeMachineReconciler{
-		Client:           mgr.GetClient(),
-		Scheme:           mgr.GetScheme(),
-		Recorder:         mgr.GetEventRecorderFor("LinodeMachineReconciler"),
-		WatchFilterValue: machineWatchFilter,
-		LinodeApiKey:     linodeToken,
-	}).SetupWithManager(mgr); err != nil {
+		Client:              mgr.GetClient(),
+		Scheme:              mgr.GetScheme(),
+		Recorder:            mgr.GetEventRecorderFor("LinodeMachineReconciler"),
+		WatchFilterValue:    machineOpts.watchFilter,
+		LinodeClientConfig:  linodeClientConfig,
+		ReconcileTimeout:    machineOpts.reconcileTimeout,
+		RequeueDelay:        machineOpts.requeueDelay,
+		LinodeEventPoller:   linodeEventPoller,
+		ManagementClusterID: managementClusterID,
+	}).SetupWithManager(mgr, machineOpts.options()); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "LinodeMachine")
 		os.Exit(1)
 	}
+	// The LinodeVPC controller only deletes existing LinodeVPCs when the LinodeVPC feature is disabled
 	if err = (&controller2.LinodeVPCReconciler{
-		Client:           mgr.GetClient(),
-		Recorder:         mgr.GetEventRecorderFor("LinodeVPCReconciler"),
-		WatchFilterValue: clusterWatchFilter,
-		LinodeApiKey:     linodeToken,
-	}).SetupWithManager(mgr); err != nil {
+		Client:             mgr.GetClient(),
+		Recorder:           mgr.GetEventRecorderFor("LinodeVPCReconciler"),
+		WatchFilterValue:   vpcOpts.watchFilter,
+		LinodeClientConfig: linodeClientConfig,
+		ReconcileTimeout:   vpcOpts.reconcileTimeout,
+		RequeueDelay:       vpcOpts.requeueDelay,
+	}).SetupWithManager(mgr, vpcOpts.options()); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "LinodeVPC")
 		os.Exit(1)
 	}
 	if err = (&controller2.LinodeObjectStorageBucketReconciler{
-		Client:           mgr.GetClient(),
-		Logger:           ctrl.Log.WithName("LinodeObjectStorageBucketReconciler"),
-		Recorder:         mgr.GetEventRecorderFor("LinodeObjectStorageBucketReconciler"),
-		WatchFilterValue: objectStorageBucketWatchFilter,
-		LinodeApiKey:     linodeToken,
-	}).SetupWithManager(mgr); err != nil {
+		Client:             mgr.GetClient(),
+		Logger:             ctrl.Log.WithName("LinodeObjectStorageBucketReconciler"),
+		Recorder:           mgr.GetEventRecorderFor("LinodeObjectStorageBucketReconciler"),
+		WatchFilterValue:   objectStorageBucketOpts.watchFilter,
+		LinodeClientConfig: linodeClientConfig,
+		ReconcileTimeout:   objectStorageBucketOpts.reconcileTimeout,
+	}).SetupWithManager(mgr, objectStorageBucketOpts.options()); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "LinodeObjectStorageBucket")
 		os.Exit(1)
 	}
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		infrastructurev1alpha1.SetDefaultLinodeClientEndpoint(linodeClientConfig.BaseURL, linodeClientConfig.APIVersion)
+		infrastructurev1alpha1.SetDefaultAccountLinodeClient(tracing.NewLinodeClient(metrics.NewLinodeClient(linodeClient, metrics.ReconcilerWebhook)))
 		if err = (&infrastructurev1alpha1.LinodeCluster{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "LinodeCluster")
 			os.Exit(1)
@@ -184,4 +373,8 @@ func main() {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+
+	if err := shutdownTracing(context.Background()); err != nil {
+		setupLog.Error(err, "failed to flush traces")
+	}
 }
@@ -0,0 +1,33 @@
+/*
+Copyright 2023 Akamai Technologies, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/linode/cluster-api-provider-linode/clients"
+	"github.com/linode/cluster-api-provider-linode/clients/cache"
+	"github.com/linode/cluster-api-provider-linode/observability/metrics"
+	"github.com/linode/cluster-api-provider-linode/observability/tracing"
+)
+
+// wrapLinodeClient decorates a scope's Linode client with the layers shared by all reconcilers. Every call is
+// traced, while catalog lookups are served from the shared cache of the client's endpoint first, so only calls
+// that reach the Linode API are recorded in metrics.
+func wrapLinodeClient(client clients.LinodeClient, endpoint, reconciler string) clients.LinodeClient {
+	return tracing.NewLinodeClient(
+		cache.NewLinodeClient(metrics.NewLinodeClient(client, reconciler), cache.DefaultCatalog, endpoint),
+	)
+}
@@ -18,11 +18,13 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -35,12 +37,14 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
 	"github.com/linode/cluster-api-provider-linode/cloud/scope"
 	"github.com/linode/cluster-api-provider-linode/cloud/services"
+	"github.com/linode/cluster-api-provider-linode/observability/tracing"
 	"github.com/linode/cluster-api-provider-linode/util"
 	"github.com/linode/cluster-api-provider-linode/util/reconciler"
 )
@@ -48,24 +52,35 @@ import (
 // LinodeClusterReconciler reconciles a LinodeCluster object
 type LinodeClusterReconciler struct {
 	client.Client
-	Recorder         record.EventRecorder
-	LinodeApiKey     string
-	WatchFilterValue string
-	ReconcileTimeout time.Duration
+	Recorder           record.EventRecorder
+	LinodeClientConfig scope.ClientConfig
+	WatchFilterValue   string
+	ReconcileTimeout   time.Duration
+	// RequeueDelay is how long to wait before checking on a pending or failed operation again.
+	RequeueDelay time.Duration
+	// ManagementClusterID identifies the management cluster in the tags of the Linode resources.
+	ManagementClusterID string
 }
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=linodeclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=linodeclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=linodeclusters/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=linodevpcs,verbs=get;list;watch;create;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=linodemachines,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 
-func (r *LinodeClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *LinodeClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
 	defer cancel()
 
+	ctx, span := tracing.Start(ctx, "LinodeClusterReconciler.Reconcile", attribute.String("name", req.NamespacedName.String()))
+	defer tracing.End(span, &err)
+
 	logger := ctrl.LoggerFrom(ctx).WithName("LinodeClusterReconciler").WithValues("name", req.NamespacedName.String())
+	logger = tracing.Logger(ctx, logger)
 	linodeCluster := &infrav1alpha1.LinodeCluster{}
 	if err := r.Client.Get(ctx, req.NamespacedName, linodeCluster); err != nil {
 		logger.Info("Failed to fetch Linode cluster", "error", err.Error())
@@ -87,11 +102,12 @@ func (r *LinodeClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	// Create the cluster scope.
 	clusterScope, err := scope.NewClusterScope(
 		ctx,
-		r.LinodeApiKey,
+		r.LinodeClientConfig,
 		scope.ClusterScopeParams{
-			Client:        r.Client,
-			Cluster:       cluster,
-			LinodeCluster: linodeCluster,
+			Client:              r.Client,
+			Cluster:             cluster,
+			LinodeCluster:       linodeCluster,
+			ManagementClusterID: r.ManagementClusterID,
 		},
 	)
 
@@ -99,6 +115,7 @@ func (r *LinodeClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		logger.Info("Failed to create cluster scope", "error", err.Error())
 		return ctrl.Result{}, fmt.Errorf("failed to create cluster scope: %w", err)
 	}
+	clusterScope.LinodeClient = wrapLinodeClient(clusterScope.LinodeClient, clusterScope.LinodeEndpoint, "linodecluster")
 
 	return r.reconcile(ctx, clusterScope, logger)
 }
@@ -128,7 +145,7 @@ func (r *LinodeClusterReconciler) reconcile(
 		if err := r.reconcileDelete(ctx, logger, clusterScope); err != nil {
 			if !reconciler.HasConditionSeverity(clusterScope.LinodeCluster, clusterv1.ReadyCondition, clusterv1.ConditionSeverityError) {
 				logger.Info("re-queuing cluster/nb deletion")
-				return ctrl.Result{RequeueAfter: reconciler.DefaultClusterControllerReconcileDelay}, nil
+				return ctrl.Result{RequeueAfter: r.requeueDelay()}, nil
 			}
 			return res, err
 		}
@@ -145,11 +162,37 @@ func (r *LinodeClusterReconciler) reconcile(
 		if err := r.reconcileCreate(ctx, logger, clusterScope); err != nil {
 			if !reconciler.HasConditionSeverity(clusterScope.LinodeCluster, clusterv1.ReadyCondition, clusterv1.ConditionSeverityError) {
 				logger.Info("re-queuing cluster/nb creation")
-				return ctrl.Result{RequeueAfter: reconciler.DefaultClusterControllerReconcileDelay}, nil
+				return ctrl.Result{RequeueAfter: r.requeueDelay()}, nil
 			}
 			return res, err
 		}
-		r.Recorder.Event(clusterScope.LinodeCluster, corev1.EventTypeNormal, string(clusterv1.ReadyCondition), "Load balancer is ready")
+		tracing.Event(ctx, r.Recorder, clusterScope.LinodeCluster, corev1.EventTypeNormal, string(clusterv1.ReadyCondition), "Load balancer is ready")
+	} else if err := services.ReconcileNodeBalancerTags(ctx, clusterScope); err != nil {
+		// Failing to tag the NodeBalancer does not affect the cluster
+		logger.Error(err, "failed to update NodeBalancer tags")
+		res = ctrl.Result{RequeueAfter: r.requeueDelay()}
+	}
+
+	if clusterScope.LinodeCluster.Spec.Network.VPC != nil {
+		if err := r.reconcileVPC(ctx, logger, clusterScope); errors.Is(err, errVPCNotReady) {
+			logger.Info("waiting for LinodeVPC to be ready")
+			conditions.MarkFalse(clusterScope.LinodeCluster, clusterv1.ReadyCondition, WaitingForVPCReason, clusterv1.ConditionSeverityInfo, "waiting for LinodeVPC %s to be ready", clusterScope.LinodeCluster.Spec.VPCRef.Name)
+
+			return ctrl.Result{RequeueAfter: r.requeueDelay()}, nil
+		} else if err != nil {
+			setFailureReason(ctx, clusterScope, cerrs.CreateClusterError, err, r)
+			if !reconciler.HasConditionSeverity(clusterScope.LinodeCluster, clusterv1.ReadyCondition, clusterv1.ConditionSeverityError) {
+				logger.Info("re-queuing cluster/vpc creation")
+				return ctrl.Result{RequeueAfter: r.requeueDelay()}, nil
+			}
+			return res, err
+		}
+	} else if clusterScope.LinodeCluster.Spec.VPCRef != nil {
+		// Failing to label the LinodeVPC does not affect the cluster
+		if err := r.reconcileVPCLabel(ctx, logger, clusterScope); err != nil {
+			logger.Error(err, "failed to label LinodeVPC")
+			res = ctrl.Result{RequeueAfter: r.requeueDelay()}
+		}
 	}
 
 	clusterScope.LinodeCluster.Status.Ready = true
@@ -158,32 +201,38 @@ func (r *LinodeClusterReconciler) reconcile(
 	return res, nil
 }
 
-func setFailureReason(clusterScope *scope.ClusterScope, failureReason cerrs.ClusterStatusError, err error, lcr *LinodeClusterReconciler) {
-	clusterScope.LinodeCluster.Status.FailureReason = util.Pointer(failureReason)
-	clusterScope.LinodeCluster.Status.FailureMessage = util.Pointer(err.Error())
+// setFailureReason records err on the Ready condition. Transient errors decay into a failure once the reconcile
+// timeout passes, while terminal errors fail the LinodeCluster immediately.
+func setFailureReason(ctx context.Context, clusterScope *scope.ClusterScope, failureReason cerrs.ClusterStatusError, err error, lcr *LinodeClusterReconciler) {
+	if reason := util.ClusterFailureReason(err, failureReason); reason != nil {
+		clusterScope.LinodeCluster.Status.FailureReason = reason
+		clusterScope.LinodeCluster.Status.FailureMessage = util.Pointer(err.Error())
 
-	reconciler.RecordDecayingCondition(clusterScope.LinodeCluster, clusterv1.ReadyCondition, string(failureReason), err.Error(), reconciler.DefaultTimeout(lcr.ReconcileTimeout, reconciler.DefaultClusterControllerReconcileTimeout))
+		conditions.MarkFalse(clusterScope.LinodeCluster, clusterv1.ReadyCondition, string(failureReason), clusterv1.ConditionSeverityError, "%s", err.Error())
+	} else {
+		reconciler.RecordDecayingCondition(clusterScope.LinodeCluster, clusterv1.ReadyCondition, string(failureReason), err.Error(), reconciler.DefaultTimeout(lcr.ReconcileTimeout, reconciler.DefaultClusterControllerReconcileTimeout))
+	}
 
-	lcr.Recorder.Event(clusterScope.LinodeCluster, corev1.EventTypeWarning, string(failureReason), err.Error())
+	tracing.Event(ctx, lcr.Recorder, clusterScope.LinodeCluster, corev1.EventTypeWarning, string(failureReason), err.Error())
 }
 
 func (r *LinodeClusterReconciler) reconcileCreate(ctx context.Context, logger logr.Logger, clusterScope *scope.ClusterScope) error {
 	if err := clusterScope.AddCredentialsRefFinalizer(ctx); err != nil {
 		logger.Error(err, "failed to update credentials finalizer")
-		setFailureReason(clusterScope, cerrs.CreateClusterError, err, r)
+		setFailureReason(ctx, clusterScope, cerrs.CreateClusterError, err, r)
 		return err
 	}
 
 	linodeNB, err := services.CreateNodeBalancer(ctx, clusterScope, logger)
 	if err != nil {
 		logger.Error(err, "failed to create nodebalancer")
-		setFailureReason(clusterScope, cerrs.CreateClusterError, err, r)
+		setFailureReason(ctx, clusterScope, cerrs.CreateClusterError, err, r)
 		return err
 	}
 
 	if linodeNB == nil {
 		err = fmt.Errorf("nodeBalancer created was nil")
-		setFailureReason(clusterScope, cerrs.CreateClusterError, err, r)
+		setFailureReason(ctx, clusterScope, cerrs.CreateClusterError, err, r)
 		return err
 	}
 
@@ -192,7 +241,7 @@ func (r *LinodeClusterReconciler) reconcileCreate(ctx context.Context, logger lo
 	linodeNBConfig, err := services.CreateNodeBalancerConfig(ctx, clusterScope, logger)
 	if err != nil {
 		logger.Error(err, "failed to create nodebalancer config")
-		setFailureReason(clusterScope, cerrs.CreateClusterError, err, r)
+		setFailureReason(ctx, clusterScope, cerrs.CreateClusterError, err, r)
 		return err
 	}
 
@@ -208,16 +257,26 @@ func (r *LinodeClusterReconciler) reconcileCreate(ctx context.Context, logger lo
 
 func (r *LinodeClusterReconciler) reconcileDelete(ctx context.Context, logger logr.Logger, clusterScope *scope.ClusterScope) error {
 	logger.Info("deleting cluster")
+
+	if clusterScope.LinodeCluster.Spec.Network.VPC != nil {
+		if err := r.deleteVPC(ctx, logger, clusterScope); err != nil {
+			if !errors.Is(err, errVPCDeleting) {
+				setFailureReason(ctx, clusterScope, cerrs.DeleteClusterError, err, r)
+			}
+			return err
+		}
+	}
+
 	if clusterScope.LinodeCluster.Spec.Network.NodeBalancerID == nil {
 		logger.Info("NodeBalancer ID is missing, nothing to do")
 
 		if err := clusterScope.RemoveCredentialsRefFinalizer(ctx); err != nil {
 			logger.Error(err, "failed to remove credentials finalizer")
-			setFailureReason(clusterScope, cerrs.DeleteClusterError, err, r)
+			setFailureReason(ctx, clusterScope, cerrs.DeleteClusterError, err, r)
 			return err
 		}
 		controllerutil.RemoveFinalizer(clusterScope.LinodeCluster, infrav1alpha1.GroupVersion.String())
-		r.Recorder.Event(clusterScope.LinodeCluster, corev1.EventTypeWarning, "NodeBalancerIDMissing", "NodeBalancer ID is missing, nothing to do")
+		tracing.Event(ctx, r.Recorder, clusterScope.LinodeCluster, corev1.EventTypeWarning, "NodeBalancerIDMissing", "NodeBalancer ID is missing, nothing to do")
 
 		return nil
 	}
@@ -225,19 +284,19 @@ func (r *LinodeClusterReconciler) reconcileDelete(ctx context.Context, logger lo
 	err := clusterScope.LinodeClient.DeleteNodeBalancer(ctx, *clusterScope.LinodeCluster.Spec.Network.NodeBalancerID)
 	if util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
 		logger.Error(err, "failed to delete NodeBalancer")
-		setFailureReason(clusterScope, cerrs.DeleteClusterError, err, r)
+		setFailureReason(ctx, clusterScope, cerrs.DeleteClusterError, err, r)
 		return err
 	}
 
 	conditions.MarkFalse(clusterScope.LinodeCluster, clusterv1.ReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "Load balancer deleted")
-	r.Recorder.Event(clusterScope.LinodeCluster, corev1.EventTypeNormal, clusterv1.DeletedReason, "Load balancer deleted")
+	tracing.Event(ctx, r.Recorder, clusterScope.LinodeCluster, corev1.EventTypeNormal, clusterv1.DeletedReason, "Load balancer deleted")
 
 	clusterScope.LinodeCluster.Spec.Network.NodeBalancerID = nil
 	clusterScope.LinodeCluster.Spec.Network.NodeBalancerConfigID = nil
 
 	if err := clusterScope.RemoveCredentialsRefFinalizer(ctx); err != nil {
 		logger.Error(err, "failed to remove credentials finalizer")
-		setFailureReason(clusterScope, cerrs.DeleteClusterError, err, r)
+		setFailureReason(ctx, clusterScope, cerrs.DeleteClusterError, err, r)
 		return err
 	}
 	controllerutil.RemoveFinalizer(clusterScope.LinodeCluster, infrav1alpha1.GroupVersion.String())
@@ -246,9 +305,11 @@ func (r *LinodeClusterReconciler) reconcileDelete(ctx context.Context, logger lo
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *LinodeClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *LinodeClusterReconciler) SetupWithManager(mgr ctrl.Manager, options crcontroller.Options) error {
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&infrav1alpha1.LinodeCluster{}).
+		WithOptions(options).
+		Owns(&infrav1alpha1.LinodeVPC{}).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(mgr.GetLogger(), r.WatchFilterValue)).
 		Watches(
 			&clusterv1.Cluster{},
@@ -263,3 +324,7 @@ func (r *LinodeClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	return nil
 }
+
+func (r *LinodeClusterReconciler) requeueDelay() time.Duration {
+	return reconciler.DefaultTimeout(r.RequeueDelay, reconciler.DefaultClusterControllerReconcileDelay)
+}
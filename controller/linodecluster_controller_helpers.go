@@ -0,0 +1,203 @@
+/*
+Copyright 2023 Akamai Technologies, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/cloud/scope"
+	"github.com/linode/cluster-api-provider-linode/observability/tracing"
+)
+
+// WaitingForVPCReason is the reason of the Ready condition of a LinodeCluster waiting for its LinodeVPC.
+const WaitingForVPCReason = "WaitingForVPC"
+
+var (
+	// errVPCNotReady is returned while the LinodeVPC of a LinodeCluster is not ready.
+	errVPCNotReady = errors.New("waiting for the LinodeVPC to be ready")
+	// errVPCDeleting is returned while the LinodeVPC of a deleted LinodeCluster, or its LinodeMachines, are not gone.
+	errVPCDeleting = errors.New("waiting for the LinodeVPC to be deleted")
+)
+
+// clusterVPC returns the LinodeVPC created for the inline VPC of the LinodeCluster, which has the same name.
+func clusterVPC(linodeCluster *infrav1alpha1.LinodeCluster) *infrav1alpha1.LinodeVPC {
+	return &infrav1alpha1.LinodeVPC{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      linodeCluster.Name,
+			Namespace: linodeCluster.Namespace,
+		},
+	}
+}
+
+// vpcLabelInvalidChars matches the runs of characters that are not allowed in a VPC label.
+var vpcLabelInvalidChars = regexp.MustCompile(`[^[:alnum:]]+`)
+
+// clusterVPCLabel returns the label of the inline VPC of the LinodeCluster. It ends with the start of the UID of the
+// LinodeCluster, so that the inline VPC never shares its label with a VPC it would otherwise adopt.
+func clusterVPCLabel(linodeCluster *infrav1alpha1.LinodeCluster) string {
+	const maxNameLength = 64 - len("-") - 8
+
+	uid := vpcLabelInvalidChars.ReplaceAllString(string(linodeCluster.UID), "")
+	if len(uid) > 8 {
+		uid = uid[:8]
+	}
+	name := vpcLabelInvalidChars.ReplaceAllString(linodeCluster.Name, "-")
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	name = strings.Trim(name, "-")
+
+	return strings.Trim(name+"-"+uid, "-")
+}
+
+// reconcileVPC creates the LinodeVPC of the inline VPC of the LinodeCluster, owned by the LinodeCluster, and points
+// the VPCRef of the LinodeCluster at it. It returns errVPCNotReady until the LinodeVPC is ready.
+func (r *LinodeClusterReconciler) reconcileVPC(ctx context.Context, logger logr.Logger, clusterScope *scope.ClusterScope) error {
+	linodeCluster := clusterScope.LinodeCluster
+	linodeVPC := clusterVPC(linodeCluster)
+	logger = logger.WithValues("linodeVPC", linodeVPC.Name)
+
+	if err := r.Get(ctx, client.ObjectKeyFromObject(linodeVPC), linodeVPC); apierrors.IsNotFound(err) {
+		linodeVPC.Labels = map[string]string{clusterv1.ClusterNameLabel: clusterScope.Cluster.Name}
+		if watchFilter, ok := linodeCluster.Labels[clusterv1.WatchLabel]; ok {
+			linodeVPC.Labels[clusterv1.WatchLabel] = watchFilter
+		}
+		linodeVPC.Spec = infrav1alpha1.LinodeVPCSpec{
+			Label:          clusterVPCLabel(linodeCluster),
+			Description:    linodeCluster.Spec.Network.VPC.Description,
+			Region:         linodeCluster.Spec.Region,
+			Subnets:        linodeCluster.Spec.Network.VPC.Subnets,
+			CredentialsRef: linodeCluster.Spec.CredentialsRef,
+		}
+		if err := controllerutil.SetControllerReference(linodeCluster, linodeVPC, r.Scheme()); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, linodeVPC); err != nil {
+			logger.Error(err, "Failed to create LinodeVPC")
+
+			return err
+		}
+		logger.Info("created LinodeVPC")
+		tracing.Event(ctx, r.Recorder, linodeCluster, corev1.EventTypeNormal, "VPCCreated", fmt.Sprintf("Created LinodeVPC %s", linodeVPC.Name))
+	} else if err != nil {
+		logger.Error(err, "Failed to fetch LinodeVPC")
+
+		return err
+	} else if !metav1.IsControlledBy(linodeVPC, linodeCluster) {
+		return fmt.Errorf("LinodeVPC %s already exists and is not owned by the LinodeCluster", linodeVPC.Name)
+	}
+
+	linodeCluster.Spec.VPCRef = &corev1.ObjectReference{
+		APIVersion: infrav1alpha1.GroupVersion.String(),
+		Kind:       "LinodeVPC",
+		Name:       linodeVPC.Name,
+		Namespace:  linodeVPC.Namespace,
+	}
+
+	if !linodeVPC.Status.Ready {
+		return errVPCNotReady
+	}
+
+	return nil
+}
+
+// reconcileVPCLabel sets the cluster name label on the LinodeVPC referenced by the LinodeCluster, so that the LinodeVPC
+// is paused with its Cluster. LinodeVPCs in another namespace, or already labeled, e.g. for another cluster sharing
+// the VPC, are left untouched.
+func (r *LinodeClusterReconciler) reconcileVPCLabel(ctx context.Context, logger logr.Logger, clusterScope *scope.ClusterScope) error {
+	linodeCluster := clusterScope.LinodeCluster
+	vpcRef := linodeCluster.Spec.VPCRef
+	if vpcRef.Namespace != "" && vpcRef.Namespace != linodeCluster.Namespace {
+		return nil
+	}
+
+	var linodeVPC infrav1alpha1.LinodeVPC
+	if err := r.Get(ctx, client.ObjectKey{Namespace: linodeCluster.Namespace, Name: vpcRef.Name}, &linodeVPC); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if _, ok := linodeVPC.Labels[clusterv1.ClusterNameLabel]; ok {
+		return nil
+	}
+
+	patch := client.MergeFrom(linodeVPC.DeepCopy())
+	if linodeVPC.Labels == nil {
+		linodeVPC.Labels = map[string]string{}
+	}
+	linodeVPC.Labels[clusterv1.ClusterNameLabel] = clusterScope.Cluster.Name
+	if err := r.Patch(ctx, &linodeVPC, patch); err != nil {
+		return err
+	}
+	logger.Info("labeled LinodeVPC", "linodeVPC", linodeVPC.Name)
+
+	return nil
+}
+
+// deleteVPC deletes the LinodeVPC of the inline VPC of the LinodeCluster once the LinodeMachines of the cluster are
+// gone. It returns errVPCDeleting until the LinodeVPC is gone.
+func (r *LinodeClusterReconciler) deleteVPC(ctx context.Context, logger logr.Logger, clusterScope *scope.ClusterScope) error {
+	linodeCluster := clusterScope.LinodeCluster
+
+	var machines infrav1alpha1.LinodeMachineList
+	if err := r.List(ctx, &machines, client.InNamespace(linodeCluster.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: clusterScope.Cluster.Name}); err != nil {
+		logger.Error(err, "Failed to list LinodeMachines")
+
+		return err
+	}
+	if len(machines.Items) != 0 {
+		logger.Info("waiting for LinodeMachines to be deleted", "count", len(machines.Items))
+		conditions.MarkFalse(linodeCluster, clusterv1.ReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "waiting for %d LinodeMachine(s) to be deleted", len(machines.Items))
+
+		return errVPCDeleting
+	}
+
+	linodeVPC := clusterVPC(linodeCluster)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(linodeVPC), linodeVPC); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		logger.Error(err, "Failed to fetch LinodeVPC")
+
+		return err
+	}
+	if !metav1.IsControlledBy(linodeVPC, linodeCluster) {
+		return nil
+	}
+	if linodeVPC.DeletionTimestamp.IsZero() {
+		if err := r.Delete(ctx, linodeVPC); client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "Failed to delete LinodeVPC")
+
+			return err
+		}
+		logger.Info("deleted LinodeVPC", "linodeVPC", linodeVPC.Name)
+	}
+	conditions.MarkFalse(linodeCluster, clusterv1.ReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "waiting for LinodeVPC %s to be deleted", linodeVPC.Name)
+
+	return errVPCDeleting
+}
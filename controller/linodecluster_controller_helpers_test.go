@@ -0,0 +1,277 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/cloud/scope"
+)
+
+func newClusterVPCTestScope(t *testing.T, objects ...client.Object) (*LinodeClusterReconciler, *scope.ClusterScope) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, infrav1alpha1.AddToScheme(scheme))
+
+	linodeCluster := &infrav1alpha1.LinodeCluster{
+		TypeMeta: metav1.TypeMeta{APIVersion: infrav1alpha1.GroupVersion.String(), Kind: "LinodeCluster"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: "default",
+			UID:       "test-uid",
+		},
+		Spec: infrav1alpha1.LinodeClusterSpec{
+			Region: "us-east",
+			Network: infrav1alpha1.NetworkSpec{
+				VPC: &infrav1alpha1.ClusterVPCSpec{
+					Subnets: []infrav1alpha1.VPCSubnetCreateOptions{{Label: "default", IPv4: "10.0.0.0/8"}},
+				},
+			},
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	return &LinodeClusterReconciler{Client: k8sClient, Recorder: record.NewFakeRecorder(10)}, &scope.ClusterScope{
+		Cluster:       &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"}},
+		LinodeCluster: linodeCluster,
+	}
+}
+
+func ownedClusterVPC(ready bool) *infrav1alpha1.LinodeVPC {
+	return &infrav1alpha1.LinodeVPC{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: infrav1alpha1.GroupVersion.String(),
+				Kind:       "LinodeCluster",
+				Name:       "test-cluster",
+				UID:        "test-uid",
+				Controller: ptr.To(true),
+			}},
+		},
+		Spec:   infrav1alpha1.LinodeVPCSpec{Region: "us-east"},
+		Status: infrav1alpha1.LinodeVPCStatus{Ready: ready},
+	}
+}
+
+func TestReconcileClusterVPC(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		objects     []client.Object
+		expectedErr error
+		expectedMsg string
+	}{
+		{
+			name:        "Success - LinodeVPC is created",
+			expectedErr: errVPCNotReady,
+		},
+		{
+			name:        "Success - LinodeVPC is not ready",
+			objects:     []client.Object{ownedClusterVPC(false)},
+			expectedErr: errVPCNotReady,
+		},
+		{
+			name:    "Success - LinodeVPC is ready",
+			objects: []client.Object{ownedClusterVPC(true)},
+		},
+		{
+			name: "Error - LinodeVPC is not owned by the LinodeCluster",
+			objects: []client.Object{&infrav1alpha1.LinodeVPC{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+			}},
+			expectedMsg: "LinodeVPC test-cluster already exists and is not owned by the LinodeCluster",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, clusterScope := newClusterVPCTestScope(t, testcase.objects...)
+
+			err := r.reconcileVPC(context.Background(), logr.Discard(), clusterScope)
+			if testcase.expectedMsg != "" {
+				require.ErrorContains(t, err, testcase.expectedMsg)
+
+				return
+			}
+			require.ErrorIs(t, err, testcase.expectedErr)
+			require.NotNil(t, clusterScope.LinodeCluster.Spec.VPCRef)
+			assert.Equal(t, "test-cluster", clusterScope.LinodeCluster.Spec.VPCRef.Name)
+
+			var linodeVPC infrav1alpha1.LinodeVPC
+			require.NoError(t, r.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "test-cluster"}, &linodeVPC))
+			assert.True(t, metav1.IsControlledBy(&linodeVPC, clusterScope.LinodeCluster))
+			assert.Equal(t, "us-east", linodeVPC.Spec.Region)
+			if testcase.objects == nil {
+				assert.Equal(t, "test-cluster-testuid", linodeVPC.Spec.Label)
+			}
+		})
+	}
+}
+
+func TestClusterVPCLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		clusterName   string
+		uid           string
+		expectedLabel string
+	}{
+		{
+			name:          "Success - name and UID",
+			clusterName:   "test-cluster",
+			uid:           "1b4e28ba-2fa1-11d2-883f-0016d3cca427",
+			expectedLabel: "test-cluster-1b4e28ba",
+		},
+		{
+			name:          "Success - invalid characters are replaced",
+			clusterName:   "test..cluster.",
+			uid:           "1b4e28ba-2fa1-11d2-883f-0016d3cca427",
+			expectedLabel: "test-cluster-1b4e28ba",
+		},
+		{
+			name:          "Success - long name is truncated",
+			clusterName:   strings.Repeat("a", 100),
+			uid:           "1b4e28ba-2fa1-11d2-883f-0016d3cca427",
+			expectedLabel: strings.Repeat("a", 55) + "-1b4e28ba",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			label := clusterVPCLabel(&infrav1alpha1.LinodeCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: testcase.clusterName, UID: types.UID(testcase.uid)},
+			})
+			assert.Equal(t, testcase.expectedLabel, label)
+			assert.LessOrEqual(t, len(label), 64)
+		})
+	}
+}
+
+func TestReconcileClusterVPCLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		vpcRef        *corev1.ObjectReference
+		labels        map[string]string
+		expectedLabel string
+	}{
+		{
+			name:          "Success - LinodeVPC is labeled",
+			vpcRef:        &corev1.ObjectReference{Name: "test-vpc"},
+			expectedLabel: "test-cluster",
+		},
+		{
+			name:          "Success - LinodeVPC of another cluster is left untouched",
+			vpcRef:        &corev1.ObjectReference{Name: "test-vpc"},
+			labels:        map[string]string{clusterv1.ClusterNameLabel: "other-cluster"},
+			expectedLabel: "other-cluster",
+		},
+		{
+			name:   "Success - LinodeVPC in another namespace is left untouched",
+			vpcRef: &corev1.ObjectReference{Name: "test-vpc", Namespace: "other"},
+		},
+		{
+			name:   "Success - missing LinodeVPC is ignored",
+			vpcRef: &corev1.ObjectReference{Name: "missing-vpc"},
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, clusterScope := newClusterVPCTestScope(t, &infrav1alpha1.LinodeVPC{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-vpc", Namespace: "default", Labels: testcase.labels},
+			}, &infrav1alpha1.LinodeVPC{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-vpc", Namespace: "other"},
+			})
+			clusterScope.LinodeCluster.Spec.Network.VPC = nil
+			clusterScope.LinodeCluster.Spec.VPCRef = testcase.vpcRef
+
+			require.NoError(t, r.reconcileVPCLabel(context.Background(), logr.Discard(), clusterScope))
+
+			var linodeVPC infrav1alpha1.LinodeVPC
+			require.NoError(t, r.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "test-vpc"}, &linodeVPC))
+			assert.Equal(t, testcase.expectedLabel, linodeVPC.Labels[clusterv1.ClusterNameLabel])
+			require.NoError(t, r.Get(context.Background(), client.ObjectKey{Namespace: "other", Name: "test-vpc"}, &linodeVPC))
+			assert.NotContains(t, linodeVPC.Labels, clusterv1.ClusterNameLabel)
+		})
+	}
+}
+
+func TestDeleteClusterVPC(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		objects     []client.Object
+		expectedErr error
+		vpcDeleted  bool
+	}{
+		{
+			name: "Success - waiting for LinodeMachines",
+			objects: []client.Object{
+				ownedClusterVPC(true),
+				&infrav1alpha1.LinodeMachine{ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-machine",
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.ClusterNameLabel: "test-cluster"},
+				}},
+			},
+			expectedErr: errVPCDeleting,
+		},
+		{
+			name:        "Success - LinodeVPC is deleted",
+			objects:     []client.Object{ownedClusterVPC(true)},
+			expectedErr: errVPCDeleting,
+			vpcDeleted:  true,
+		},
+		{
+			name:       "Success - LinodeVPC is gone",
+			vpcDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, clusterScope := newClusterVPCTestScope(t, testcase.objects...)
+
+			err := r.deleteVPC(context.Background(), logr.Discard(), clusterScope)
+			if testcase.expectedErr != nil {
+				require.ErrorIs(t, err, testcase.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			err = r.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "test-cluster"}, &infrav1alpha1.LinodeVPC{})
+			assert.Equal(t, testcase.vpcDeleted, apierrors.IsNotFound(err))
+		})
+	}
+}
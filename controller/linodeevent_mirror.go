@@ -0,0 +1,244 @@
+/*
+Copyright 2023 Akamai Technologies, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/linode/linodego"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/cloud/events"
+	"github.com/linode/cluster-api-provider-linode/observability/tracing"
+)
+
+const (
+	// lastEventIDKey is the key of the high-water mark in the LinodeEventMirror ConfigMap.
+	lastEventIDKey = "lastEventID"
+)
+
+// LinodeEventMirror mirrors the Linode account events into Kubernetes Events on the CAPL objects of the affected
+// Linodes, NodeBalancers and VPCs, so that changes made out of band (e.g. in Cloud Manager) are visible from the
+// management cluster.
+//
+// The ID of the last mirrored event is stored in a ConfigMap so events are not mirrored again after a restart. The
+// ConfigMap must be in the manager namespace, where the leader election Role grants access to ConfigMaps.
+type LinodeEventMirror struct {
+	client.Client
+	Recorder record.EventRecorder
+	// ConfigMap is the ConfigMap storing the ID of the last mirrored event.
+	ConfigMap client.ObjectKey
+
+	// apiReader reads the ConfigMap without caching all ConfigMaps of the cluster.
+	apiReader client.Reader
+	queue     chan linodego.Event
+}
+
+// SetupWithManager subscribes the mirror to the events of poller and adds it to the Manager.
+func (m *LinodeEventMirror) SetupWithManager(mgr ctrl.Manager, poller *events.Poller) error {
+	m.apiReader = mgr.GetAPIReader()
+	m.queue = make(chan linodego.Event, linodeEventQueueSize)
+	poller.Subscribe(func(ctx context.Context, linodeEvent linodego.Event) {
+		select {
+		case m.queue <- linodeEvent:
+		case <-ctx.Done():
+		}
+	})
+
+	if err := mgr.Add(m); err != nil {
+		return fmt.Errorf("failed to add Linode event mirror: %w", err)
+	}
+
+	return nil
+}
+
+// Start mirrors the events received from the poller until ctx is done. It implements manager.Runnable.
+func (m *LinodeEventMirror) Start(ctx context.Context) error {
+	logger := ctrl.LoggerFrom(ctx).WithName("LinodeEventMirror")
+
+	// Events up to the persisted high-water mark were mirrored before a restart. Later events are only dispatched
+	// once by the poller, and may finish out of order, so the mark is not used to skip them.
+	mirroredEventID, err := m.loadLastEventID(ctx)
+	if err != nil {
+		return err
+	}
+	lastEventID := mirroredEventID
+
+	for {
+		var linodeEvent linodego.Event
+		select {
+		case <-ctx.Done():
+			return nil
+		case linodeEvent = <-m.queue:
+		}
+
+		// Mirror everything already queued before persisting the high-water mark once.
+		latestEventID := lastEventID
+		for queued := true; queued; {
+			if linodeEvent.ID > mirroredEventID {
+				m.mirror(ctx, logger, linodeEvent)
+				latestEventID = max(latestEventID, linodeEvent.ID)
+			}
+
+			select {
+			case linodeEvent = <-m.queue:
+			default:
+				queued = false
+			}
+		}
+
+		if latestEventID == lastEventID {
+			continue
+		}
+		if err := m.saveLastEventID(ctx, latestEventID); err != nil {
+			logger.Error(err, "Failed to save the last mirrored Linode event ID")
+		}
+		lastEventID = latestEventID
+	}
+}
+
+// mirror emits a Kubernetes Event for linodeEvent on every CAPL object of its entity.
+func (m *LinodeEventMirror) mirror(ctx context.Context, logger logr.Logger, linodeEvent linodego.Event) {
+	objs, err := m.objectsForEntity(ctx, linodeEvent.Entity)
+	if err != nil {
+		logger.Error(err, "Failed to map Linode event", "event", linodeEvent.ID)
+
+		return
+	}
+
+	eventType := corev1.EventTypeNormal
+	if linodeEvent.Status == linodego.EventFailed {
+		eventType = corev1.EventTypeWarning
+	}
+	for _, obj := range objs {
+		tracing.Event(ctx, m.Recorder, obj, eventType, eventReason(linodeEvent.Action), events.Message(linodeEvent))
+	}
+}
+
+// objectsForEntity returns the CAPL objects managing the Linode resource entity.
+func (m *LinodeEventMirror) objectsForEntity(ctx context.Context, entity *linodego.EventEntity) ([]client.Object, error) {
+	id := events.EntityID(entity)
+	if id == 0 {
+		return nil, nil
+	}
+
+	var objs []client.Object
+	switch entity.Type {
+	case linodego.EntityLinode:
+		var linodeMachines infrav1alpha1.LinodeMachineList
+		if err := m.Client.List(ctx, &linodeMachines); err != nil {
+			return nil, fmt.Errorf("list LinodeMachines: %w", err)
+		}
+		for i, linodeMachine := range linodeMachines.Items {
+			if linodeMachine.Spec.InstanceID != nil && *linodeMachine.Spec.InstanceID == id {
+				objs = append(objs, &linodeMachines.Items[i])
+			}
+		}
+	case linodego.EntityNodebalancer:
+		var linodeClusters infrav1alpha1.LinodeClusterList
+		if err := m.Client.List(ctx, &linodeClusters); err != nil {
+			return nil, fmt.Errorf("list LinodeClusters: %w", err)
+		}
+		for i, linodeCluster := range linodeClusters.Items {
+			if linodeCluster.Spec.Network.NodeBalancerID != nil && *linodeCluster.Spec.Network.NodeBalancerID == id {
+				objs = append(objs, &linodeClusters.Items[i])
+			}
+		}
+	case linodego.EntityVPC:
+		var linodeVPCs infrav1alpha1.LinodeVPCList
+		if err := m.Client.List(ctx, &linodeVPCs); err != nil {
+			return nil, fmt.Errorf("list LinodeVPCs: %w", err)
+		}
+		for i, linodeVPC := range linodeVPCs.Items {
+			if linodeVPC.Spec.VPCID != nil && *linodeVPC.Spec.VPCID == id {
+				objs = append(objs, &linodeVPCs.Items[i])
+			}
+		}
+	}
+
+	return objs, nil
+}
+
+func (m *LinodeEventMirror) loadLastEventID(ctx context.Context) (int, error) {
+	var configMap corev1.ConfigMap
+	if err := m.apiReader.Get(ctx, m.ConfigMap, &configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("get ConfigMap %s: %w", m.ConfigMap, err)
+	}
+
+	lastEventID, err := strconv.Atoi(configMap.Data[lastEventIDKey])
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s in ConfigMap %s: %w", lastEventIDKey, m.ConfigMap, err)
+	}
+
+	return lastEventID, nil
+}
+
+func (m *LinodeEventMirror) saveLastEventID(ctx context.Context, lastEventID int) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.ConfigMap.Name,
+			Namespace: m.ConfigMap.Namespace,
+		},
+	}
+	data := map[string]string{lastEventIDKey: strconv.Itoa(lastEventID)}
+
+	if err := m.apiReader.Get(ctx, m.ConfigMap, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("get ConfigMap %s: %w", m.ConfigMap, err)
+		}
+
+		configMap.Data = data
+		if err := m.Client.Create(ctx, configMap); err != nil {
+			return fmt.Errorf("create ConfigMap %s: %w", m.ConfigMap, err)
+		}
+
+		return nil
+	}
+
+	configMap.Data = data
+	if err := m.Client.Update(ctx, configMap); err != nil {
+		return fmt.Errorf("update ConfigMap %s: %w", m.ConfigMap, err)
+	}
+
+	return nil
+}
+
+// eventReason converts a Linode event action (e.g. linode_reboot) to an Event reason (e.g. LinodeReboot).
+func eventReason(action linodego.EventAction) string {
+	words := strings.Split(string(action), "_")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+
+	return strings.Join(words, "")
+}
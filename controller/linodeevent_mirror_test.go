@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/util"
+)
+
+func newLinodeEventMirror(t *testing.T, objs ...client.Object) (*LinodeEventMirror, *record.FakeRecorder) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, infrav1alpha1.AddToScheme(scheme))
+
+	objs = append(objs,
+		&infrav1alpha1.LinodeMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default"},
+			Spec:       infrav1alpha1.LinodeMachineSpec{InstanceID: util.Pointer(123)},
+		},
+		&infrav1alpha1.LinodeCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+			Spec: infrav1alpha1.LinodeClusterSpec{
+				Network: infrav1alpha1.NetworkSpec{NodeBalancerID: util.Pointer(456)},
+			},
+		},
+		&infrav1alpha1.LinodeVPC{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-vpc", Namespace: "default"},
+			Spec:       infrav1alpha1.LinodeVPCSpec{VPCID: util.Pointer(789)},
+		},
+	)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	return &LinodeEventMirror{
+		Client:    k8sClient,
+		Recorder:  recorder,
+		ConfigMap: client.ObjectKey{Namespace: "capl-system", Name: "capl-linode-events"},
+		apiReader: k8sClient,
+		queue:     make(chan linodego.Event, linodeEventQueueSize),
+	}, recorder
+}
+
+func TestLinodeEventMirrorObjectsForEntity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		entity        *linodego.EventEntity
+		expectedNames []string
+	}{
+		{
+			name:          "Linode",
+			entity:        &linodego.EventEntity{ID: float64(123), Type: linodego.EntityLinode},
+			expectedNames: []string{"test-machine"},
+		},
+		{
+			name:          "NodeBalancer",
+			entity:        &linodego.EventEntity{ID: float64(456), Type: linodego.EntityNodebalancer},
+			expectedNames: []string{"test-cluster"},
+		},
+		{
+			name:          "VPC",
+			entity:        &linodego.EventEntity{ID: float64(789), Type: linodego.EntityVPC},
+			expectedNames: []string{"test-vpc"},
+		},
+		{
+			name:   "unmanaged Linode",
+			entity: &linodego.EventEntity{ID: float64(999), Type: linodego.EntityLinode},
+		},
+		{
+			name:   "unsupported entity type",
+			entity: &linodego.EventEntity{ID: float64(123), Type: linodego.EntityVolume},
+		},
+		{
+			name: "no entity",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mirror, _ := newLinodeEventMirror(t)
+
+			objs, err := mirror.objectsForEntity(context.Background(), testcase.entity)
+			require.NoError(t, err)
+
+			var names []string
+			for _, obj := range objs {
+				names = append(names, obj.GetName())
+			}
+			assert.Equal(t, testcase.expectedNames, names)
+		})
+	}
+}
+
+func TestLinodeEventMirrorStart(t *testing.T) {
+	t.Parallel()
+
+	mirror, recorder := newLinodeEventMirror(t, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "capl-system", Name: "capl-linode-events"},
+		Data:       map[string]string{lastEventIDKey: "10"},
+	})
+
+	reboot := linodego.Event{
+		ID:       12,
+		Action:   linodego.ActionLinodeReboot,
+		Status:   linodego.EventFinished,
+		Username: "someone",
+		Entity:   &linodego.EventEntity{ID: float64(123), Type: linodego.EntityLinode, Label: "test-machine"},
+	}
+	nodeBalancerUpdate := linodego.Event{
+		ID:       11,
+		Action:   linodego.ActionNodebalancerConfigUpdate,
+		Status:   linodego.EventFailed,
+		Username: "someone",
+		Entity:   &linodego.EventEntity{ID: float64(456), Type: linodego.EntityNodebalancer, Label: "test-cluster"},
+	}
+	alreadyMirrored := linodego.Event{
+		ID:     10,
+		Action: linodego.ActionLinodeBoot,
+		Status: linodego.EventFinished,
+		Entity: &linodego.EventEntity{ID: float64(123), Type: linodego.EntityLinode, Label: "test-machine"},
+	}
+	mirror.queue <- alreadyMirrored
+	mirror.queue <- reboot
+	mirror.queue <- nodeBalancerUpdate
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- mirror.Start(ctx) }()
+
+	assert.Equal(t, `Normal LinodeReboot linode_reboot finished on linode "test-machine" by someone (event 12)`, <-recorder.Events)
+	assert.Equal(t, `Warning NodebalancerConfigUpdate nodebalancer_config_update failed on nodebalancer "test-cluster" by someone (event 11)`, <-recorder.Events)
+
+	// Events may finish out of order, so the high-water mark only skips events mirrored before the restart.
+	assert.Eventually(t, func() bool {
+		lastEventID, err := mirror.loadLastEventID(context.Background())
+		return err == nil && lastEventID == 12
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.Empty(t, recorder.Events)
+}
+
+func TestLinodeEventMirrorSaveLastEventID(t *testing.T) {
+	t.Parallel()
+
+	mirror, _ := newLinodeEventMirror(t)
+	ctx := context.Background()
+
+	lastEventID, err := mirror.loadLastEventID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, lastEventID)
+
+	require.NoError(t, mirror.saveLastEventID(ctx, 42))
+	require.NoError(t, mirror.saveLastEventID(ctx, 43))
+
+	lastEventID, err = mirror.loadLastEventID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 43, lastEventID)
+}
+
+func TestEventReason(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "LinodeReboot", eventReason(linodego.ActionLinodeReboot))
+	assert.Equal(t, "NodebalancerConfigUpdate", eventReason(linodego.ActionNodebalancerConfigUpdate))
+	assert.Equal(t, "VpcCreate", eventReason(linodego.ActionVPCCreate))
+}
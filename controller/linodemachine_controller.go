@@ -21,11 +21,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/netip"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/linode/linodego"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -34,19 +37,27 @@ import (
 	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	cerrs "sigs.k8s.io/cluster-api/errors"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1beta1"
 	kutil "sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/clients"
+	"github.com/linode/cluster-api-provider-linode/cloud/events"
 	"github.com/linode/cluster-api-provider-linode/cloud/scope"
 	"github.com/linode/cluster-api-provider-linode/cloud/services"
+	"github.com/linode/cluster-api-provider-linode/feature"
+	"github.com/linode/cluster-api-provider-linode/observability/tracing"
 	"github.com/linode/cluster-api-provider-linode/util"
 	"github.com/linode/cluster-api-provider-linode/util/reconciler"
 )
@@ -88,11 +99,24 @@ var requeueInstanceStatuses = map[linodego.InstanceStatus]bool{
 // LinodeMachineReconciler reconciles a LinodeMachine object
 type LinodeMachineReconciler struct {
 	client.Client
-	Recorder         record.EventRecorder
-	LinodeApiKey     string
-	WatchFilterValue string
-	Scheme           *runtime.Scheme
-	ReconcileTimeout time.Duration
+	Recorder           record.EventRecorder
+	LinodeClientConfig scope.ClientConfig
+	WatchFilterValue   string
+	Scheme             *runtime.Scheme
+	ReconcileTimeout   time.Duration
+	// RequeueDelay is how long to wait before checking on a pending or failed operation again.
+	RequeueDelay time.Duration
+	// ManagementClusterID identifies the management cluster in the tags of the Linode resources.
+	ManagementClusterID string
+	// LinodeEventPoller enqueues LinodeMachines when operations on their instance finish, if set.
+	LinodeEventPoller *events.Poller
+
+	linodeEvents *linodeEventStore
+
+	// allocations holds the prefixes allocated to machines by cluster, pool and machine name, as the allocations may
+	// not be in the cache yet.
+	allocations   map[allocationKey]map[string]netip.Prefix
+	allocationsMu sync.Mutex
 }
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=linodemachines,verbs=get;list;watch;create;update;patch;delete
@@ -101,6 +125,8 @@ type LinodeMachineReconciler struct {
 
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=get;watch;list
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;watch;list
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddresses,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
 
@@ -109,11 +135,15 @@ type LinodeMachineReconciler struct {
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.16.0/pkg/reconcile
-func (r *LinodeMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *LinodeMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
 	defer cancel()
 
+	ctx, span := tracing.Start(ctx, "LinodeMachineReconciler.Reconcile", attribute.String("name", req.NamespacedName.String()))
+	defer tracing.End(span, &err)
+
 	log := ctrl.LoggerFrom(ctx).WithName("LinodeMachineReconciler").WithValues("name", req.NamespacedName.String())
+	log = tracing.Logger(ctx, log)
 
 	linodeMachine := &infrav1alpha1.LinodeMachine{}
 	if err := r.Client.Get(ctx, req.NamespacedName, linodeMachine); err != nil {
@@ -137,13 +167,14 @@ func (r *LinodeMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 	machineScope, err := scope.NewMachineScope(
 		ctx,
-		r.LinodeApiKey,
+		r.LinodeClientConfig,
 		scope.MachineScopeParams{
-			Client:        r.Client,
-			Cluster:       cluster,
-			Machine:       machine,
-			LinodeCluster: &infrav1alpha1.LinodeCluster{},
-			LinodeMachine: linodeMachine,
+			Client:              r.Client,
+			Cluster:             cluster,
+			Machine:             machine,
+			LinodeCluster:       &infrav1alpha1.LinodeCluster{},
+			LinodeMachine:       linodeMachine,
+			ManagementClusterID: r.ManagementClusterID,
 		},
 	)
 	if err != nil {
@@ -151,6 +182,7 @@ func (r *LinodeMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 		return ctrl.Result{}, fmt.Errorf("failed to create machine scope: %w", err)
 	}
+	machineScope.LinodeClient = wrapLinodeClient(machineScope.LinodeClient, machineScope.LinodeEndpoint, "linodemachine")
 
 	return r.reconcile(ctx, log, machineScope)
 }
@@ -170,12 +202,15 @@ func (r *LinodeMachineReconciler) reconcile(
 	//nolint:dupl // Code duplication is simplicity in this case.
 	defer func() {
 		if err != nil {
-			machineScope.LinodeMachine.Status.FailureReason = util.Pointer(failureReason)
-			machineScope.LinodeMachine.Status.FailureMessage = util.Pointer(err.Error())
+			// Only terminal errors are failures, CAPI does not reconcile a Machine with a FailureReason again
+			if reason := util.MachineFailureReason(err, failureReason); reason != nil {
+				machineScope.LinodeMachine.Status.FailureReason = reason
+				machineScope.LinodeMachine.Status.FailureMessage = util.Pointer(err.Error())
+			}
 
 			conditions.MarkFalse(machineScope.LinodeMachine, clusterv1.ReadyCondition, string(failureReason), clusterv1.ConditionSeverityError, err.Error())
 
-			r.Recorder.Event(machineScope.LinodeMachine, corev1.EventTypeWarning, string(failureReason), err.Error())
+			tracing.Event(ctx, r.Recorder, machineScope.LinodeMachine, corev1.EventTypeWarning, string(failureReason), err.Error())
 		}
 
 		// Always close the scope when exiting this function so we can persist any LinodeMachine changes.
@@ -187,6 +222,8 @@ func (r *LinodeMachineReconciler) reconcile(
 		}
 	}()
 
+	r.applyLinodeEvent(machineScope)
+
 	// Add the finalizer if not already there
 	err = machineScope.AddFinalizer(ctx)
 	if err != nil {
@@ -242,7 +279,7 @@ func (r *LinodeMachineReconciler) reconcile(
 	// Make sure bootstrap data is available and populated.
 	if machineScope.Machine.Spec.Bootstrap.DataSecretName == nil {
 		logger.Info("Bootstrap data secret is not yet available")
-		res = ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerWaitForBootstrapDelay}
+		res = ctrl.Result{RequeueAfter: r.requeueDelay()}
 
 		return
 	}
@@ -263,34 +300,59 @@ func (r *LinodeMachineReconciler) reconcileCreate(
 		return ctrl.Result{}, err
 	}
 
-	tags := []string{machineScope.LinodeCluster.Name}
+	tags := machineScope.Tags()
+	clusterUID := string(machineScope.LinodeCluster.UID)
 
-	listFilter := util.Filter{
-		ID:    machineScope.LinodeMachine.Spec.InstanceID,
-		Label: machineScope.LinodeMachine.Name,
-		Tags:  tags,
+	// Instances created before the ownership tags were introduced are tagged with the cluster name only
+	listFilter := util.FilterAnd(
+		util.FilterEq("label", machineScope.LinodeMachine.Name),
+		util.FilterOr(
+			util.FilterEq("tags", util.ClusterUIDTag(clusterUID)),
+			util.FilterEq("tags", machineScope.LinodeCluster.Name),
+		),
+	)
+	if instanceID := machineScope.LinodeMachine.Spec.InstanceID; instanceID != nil {
+		listFilter = util.FilterEq("id", *instanceID)
 	}
 	filter, err := listFilter.String()
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	linodeInstances, err := machineScope.LinodeClient.ListInstances(ctx, linodego.NewListOptions(1, filter))
-	if err != nil {
+	linodeInstance, err := clients.FindUnique(ctx, machineScope.LinodeClient.ListInstances, filter, func(instance linodego.Instance) int {
+		return instance.ID
+	})
+	var multipleMatches *clients.MultipleMatchesError
+	if errors.As(err, &multipleMatches) {
+		err = fmt.Errorf("multiple instances: %w", err)
+		logger.Error(err, "multiple instances found", "instanceIDs", multipleMatches.IDs, "tags", tags)
+
+		return ctrl.Result{}, err
+	} else if err != nil {
 		logger.Error(err, "Failed to list Linode machine instances")
 
-		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerWaitForRunningDelay}, nil
+		return ctrl.Result{RequeueAfter: r.requeueDelay()}, nil
 	}
 
-	var linodeInstance *linodego.Instance
-	switch len(linodeInstances) {
-	case 1:
+	switch {
+	case linodeInstance != nil:
 		logger.Info("Linode instance already exists")
 
-		linodeInstance = &linodeInstances[0]
-	case 0:
+		// Instances found by their legacy tag may belong to a cluster with the same name in another namespace or
+		// management cluster, so only the instances with the UID of the cluster are adopted.
+		if machineScope.LinodeMachine.Spec.InstanceID == nil && !util.IsOwnedBy(linodeInstance.Tags, clusterUID, machineScope.LinodeCluster.Name, false) {
+			err = errors.New("instance conflict")
+			logger.Error(err, "Linode instance is not owned by this cluster", "instanceID", linodeInstance.ID, "tags", linodeInstance.Tags)
+
+			return ctrl.Result{}, err
+		}
+	default:
 		// get the bootstrap data for the Linode instance and set it for create config
 		createOpts, err := r.newCreateConfig(ctx, machineScope, tags, logger)
-		if err != nil {
+		if errors.Is(err, errIPAddressNotAllocated) {
+			logger.Info("Waiting for the VPC IPv4 address to be allocated")
+
+			return ctrl.Result{RequeueAfter: r.requeueDelay()}, nil
+		} else if err != nil {
 			logger.Error(err, "Failed to create Linode machine InstanceCreateOptions")
 
 			return ctrl.Result{}, err
@@ -300,23 +362,24 @@ func (r *LinodeMachineReconciler) reconcileCreate(
 		if err != nil {
 			logger.Error(err, "Failed to create Linode machine instance")
 
+			// Retrying a request the Linode API rejected, e.g. for an invalid region or type, cannot succeed
+			if util.IsTerminalError(err) {
+				conditions.MarkFalse(machineScope.LinodeMachine, ConditionPreflightCreated, string(cerrs.CreateMachineError), clusterv1.ConditionSeverityError, "%s", err.Error())
+
+				return ctrl.Result{}, err
+			}
+
 			if reconciler.RecordDecayingCondition(machineScope.LinodeMachine,
 				ConditionPreflightCreated, string(cerrs.CreateMachineError), err.Error(),
 				reconciler.DefaultTimeout(r.ReconcileTimeout, reconciler.DefaultMachineControllerWaitForPreflightTimeout)) {
 				return ctrl.Result{}, err
 			}
 
-			return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerWaitForRunningDelay}, nil
+			return ctrl.Result{RequeueAfter: r.requeueDelay()}, nil
 		}
 
 		conditions.MarkTrue(machineScope.LinodeMachine, ConditionPreflightCreated)
 		machineScope.LinodeMachine.Spec.InstanceID = &linodeInstance.ID
-
-	default:
-		err = errors.New("multiple instances")
-		logger.Error(err, "multiple instances found", "tags", tags)
-
-		return ctrl.Result{}, err
 	}
 
 	return r.reconcileInstanceCreate(ctx, logger, machineScope, linodeInstance)
@@ -329,21 +392,27 @@ func (r *LinodeMachineReconciler) reconcileInstanceCreate(
 	linodeInstance *linodego.Instance,
 ) (ctrl.Result, error) {
 	if !reconciler.ConditionTrue(machineScope.LinodeMachine, ConditionPreflightConfigured) {
-		if err := r.configureDisks(ctx, logger, machineScope, linodeInstance.ID); err != nil {
+		stepCtx, span := tracing.Start(ctx, string(ConditionPreflightConfigured))
+		err := r.configureDisks(stepCtx, logger, machineScope, linodeInstance.ID)
+		tracing.End(span, &err)
+		if err != nil {
 			if reconciler.RecordDecayingCondition(machineScope.LinodeMachine,
 				ConditionPreflightConfigured, string(cerrs.CreateMachineError), err.Error(),
 				reconciler.DefaultTimeout(r.ReconcileTimeout, reconciler.DefaultMachineControllerWaitForPreflightTimeout)) {
 				return ctrl.Result{}, err
 			}
 
-			return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerWaitForRunningDelay}, nil
+			return ctrl.Result{RequeueAfter: r.waitForLinodeDelay(machineScope)}, nil
 		}
 
 		conditions.MarkTrue(machineScope.LinodeMachine, ConditionPreflightConfigured)
 	}
 
 	if !reconciler.ConditionTrue(machineScope.LinodeMachine, ConditionPreflightBootTriggered) {
-		if err := machineScope.LinodeClient.BootInstance(ctx, linodeInstance.ID, 0); err != nil && !strings.HasSuffix(err.Error(), "already booted.") {
+		stepCtx, span := tracing.Start(ctx, string(ConditionPreflightBootTriggered))
+		err := machineScope.LinodeClient.BootInstance(stepCtx, linodeInstance.ID, 0)
+		tracing.End(span, &err)
+		if err != nil && !strings.HasSuffix(err.Error(), "already booted.") {
 			logger.Error(err, "Failed to boot instance")
 
 			if reconciler.RecordDecayingCondition(machineScope.LinodeMachine,
@@ -352,14 +421,17 @@ func (r *LinodeMachineReconciler) reconcileInstanceCreate(
 				return ctrl.Result{}, err
 			}
 
-			return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerWaitForRunningDelay}, nil
+			return ctrl.Result{RequeueAfter: r.waitForLinodeDelay(machineScope)}, nil
 		}
 
 		conditions.MarkTrue(machineScope.LinodeMachine, ConditionPreflightBootTriggered)
 	}
 
 	if !reconciler.ConditionTrue(machineScope.LinodeMachine, ConditionPreflightNetworking) {
-		if err := services.AddNodeToNB(ctx, logger, machineScope); err != nil {
+		stepCtx, span := tracing.Start(ctx, string(ConditionPreflightNetworking))
+		err := services.AddNodeToNB(stepCtx, logger, machineScope, feature.Gates)
+		tracing.End(span, &err)
+		if err != nil {
 			logger.Error(err, "Failed to add instance to Node Balancer backend")
 
 			if reconciler.RecordDecayingCondition(machineScope.LinodeMachine,
@@ -368,14 +440,16 @@ func (r *LinodeMachineReconciler) reconcileInstanceCreate(
 				return ctrl.Result{}, err
 			}
 
-			return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerWaitForRunningDelay}, nil
+			return ctrl.Result{RequeueAfter: r.requeueDelay()}, nil
 		}
 
 		conditions.MarkTrue(machineScope.LinodeMachine, ConditionPreflightNetworking)
 	}
 
 	if !reconciler.ConditionTrue(machineScope.LinodeMachine, ConditionPreflightReady) {
-		addrs, err := r.buildInstanceAddrs(ctx, machineScope, linodeInstance.ID)
+		stepCtx, span := tracing.Start(ctx, string(ConditionPreflightReady))
+		addrs, err := r.buildInstanceAddrs(stepCtx, machineScope, linodeInstance.ID)
+		tracing.End(span, &err)
 		if err != nil {
 			logger.Error(err, "Failed to get instance ip addresses")
 
@@ -385,7 +459,7 @@ func (r *LinodeMachineReconciler) reconcileInstanceCreate(
 				return ctrl.Result{}, err
 			}
 
-			return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerWaitForRunningDelay}, nil
+			return ctrl.Result{RequeueAfter: r.requeueDelay()}, nil
 		}
 		machineScope.LinodeMachine.Status.Addresses = addrs
 
@@ -410,11 +484,19 @@ func (r *LinodeMachineReconciler) configureDisks(
 		return nil
 	}
 
-	if err := r.resizeRootDisk(ctx, logger, machineScope, linodeInstanceID); err != nil {
-		return err
+	if !reconciler.ConditionTrue(machineScope.LinodeMachine, ConditionPreflightRootDiskResized) {
+		stepCtx, span := tracing.Start(ctx, string(ConditionPreflightRootDiskResized))
+		err := r.resizeRootDisk(stepCtx, logger, machineScope, linodeInstanceID)
+		tracing.End(span, &err)
+		if err != nil {
+			return err
+		}
 	}
 	if !reconciler.ConditionTrue(machineScope.LinodeMachine, ConditionPreflightAdditionalDisksCreated) {
-		if err := r.createDisks(ctx, logger, machineScope, linodeInstanceID); err != nil {
+		stepCtx, span := tracing.Start(ctx, string(ConditionPreflightAdditionalDisksCreated))
+		err := r.createDisks(stepCtx, logger, machineScope, linodeInstanceID)
+		tracing.End(span, &err)
+		if err != nil {
 			return err
 		}
 	}
@@ -600,18 +682,37 @@ func (r *LinodeMachineReconciler) reconcileUpdate(
 		return res, nil, err
 	}
 
+	// Failing to tag the instance does not affect the machine
+	if err := reconcileInstanceTags(ctx, machineScope, linodeInstance); err != nil {
+		logger.Error(err, "Failed to update Linode instance tags")
+
+		res = ctrl.Result{RequeueAfter: r.requeueDelay()}
+	}
+
 	if _, ok := requeueInstanceStatuses[linodeInstance.Status]; ok {
-		if linodeInstance.Updated.Add(reconciler.DefaultMachineControllerWaitForRunningTimeout).After(time.Now()) {
-			logger.Info("Instance has one operaton running, re-queuing reconciliation", "status", linodeInstance.Status)
+		// The event of the operation enqueues the LinodeMachine again once it finishes or fails
+		if conditions.IsFalse(machineScope.LinodeMachine, ConditionLinodeOperationSucceeded) {
+			logger.Info("Instance operation failed, skipping reconciliation", "status", linodeInstance.Status)
+
+			conditions.MarkFalse(machineScope.LinodeMachine, clusterv1.ReadyCondition, string(linodeInstance.Status), clusterv1.ConditionSeverityInfo, "skipped due to failed operation")
 
-			return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerWaitForRunningDelay}, linodeInstance, nil
+			return res, linodeInstance, nil
 		}
 
-		logger.Info("Instance has one operaton long running, skipping reconciliation", "status", linodeInstance.Status)
+		// Without the events of the Linode, a failed operation is never reported, so the wait is bounded instead
+		if !r.watchesLinodeEvents(machineScope) &&
+			(reconciler.HasConditionSeverity(machineScope.LinodeMachine, clusterv1.ReadyCondition, clusterv1.ConditionSeverityError) ||
+				reconciler.RecordDecayingCondition(machineScope.LinodeMachine,
+					clusterv1.ReadyCondition, string(linodeInstance.Status), "waiting for the instance operation",
+					reconciler.DefaultTimeout(r.ReconcileTimeout, reconciler.DefaultMachineControllerWaitForRunningTimeout))) {
+			logger.Info("Instance has one operation long running, skipping reconciliation", "status", linodeInstance.Status)
+
+			return res, linodeInstance, nil
+		}
 
-		conditions.MarkFalse(machineScope.LinodeMachine, clusterv1.ReadyCondition, string(linodeInstance.Status), clusterv1.ConditionSeverityInfo, "skipped due to long running operation")
+		logger.Info("Instance has one operation running, re-queuing reconciliation", "status", linodeInstance.Status)
 
-		return res, linodeInstance, nil
+		return ctrl.Result{RequeueAfter: r.waitForLinodeDelay(machineScope)}, linodeInstance, nil
 	} else if linodeInstance.Status != linodego.InstanceRunning {
 		logger.Info("Instance has incompatible status, skipping reconciliation", "status", linodeInstance.Status)
 
@@ -637,6 +738,11 @@ func (r *LinodeMachineReconciler) reconcileDelete(
 	if machineScope.LinodeMachine.Spec.InstanceID == nil {
 		logger.Info("Machine ID is missing, nothing to do")
 
+		if err := r.releaseVPCIPAddress(ctx, machineScope); err != nil {
+			logger.Error(err, "Failed to release VPC IPv4 address")
+			return err
+		}
+		r.releaseAllocations(machineScope)
 		if err := machineScope.RemoveCredentialsRefFinalizer(ctx); err != nil {
 			logger.Error(err, "Failed to update credentials secret")
 			return err
@@ -662,12 +768,17 @@ func (r *LinodeMachineReconciler) reconcileDelete(
 
 	conditions.MarkFalse(machineScope.LinodeMachine, clusterv1.ReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "instance deleted")
 
-	r.Recorder.Event(machineScope.LinodeMachine, corev1.EventTypeNormal, clusterv1.DeletedReason, "instance has cleaned up")
+	tracing.Event(ctx, r.Recorder, machineScope.LinodeMachine, corev1.EventTypeNormal, clusterv1.DeletedReason, "instance has cleaned up")
 
 	machineScope.LinodeMachine.Spec.ProviderID = nil
 	machineScope.LinodeMachine.Spec.InstanceID = nil
 	machineScope.LinodeMachine.Status.InstanceState = nil
 
+	if err := r.releaseVPCIPAddress(ctx, machineScope); err != nil {
+		logger.Error(err, "Failed to release VPC IPv4 address")
+		return err
+	}
+	r.releaseAllocations(machineScope)
 	if err := machineScope.RemoveCredentialsRefFinalizer(ctx); err != nil {
 		logger.Error(err, "Failed to update credentials secret")
 		return err
@@ -678,18 +789,20 @@ func (r *LinodeMachineReconciler) reconcileDelete(
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *LinodeMachineReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *LinodeMachineReconciler) SetupWithManager(mgr ctrl.Manager, options crcontroller.Options) error {
 	linodeMachineMapper, err := kutil.ClusterToTypedObjectsMapper(r.Client, &infrav1alpha1.LinodeMachineList{}, mgr.GetScheme())
 	if err != nil {
 		return fmt.Errorf("failed to create mapper for LinodeMachines: %w", err)
 	}
 
-	err = ctrl.NewControllerManagedBy(mgr).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&infrav1alpha1.LinodeMachine{}).
+		WithOptions(options).
 		Watches(
 			&clusterv1.Machine{},
 			handler.EnqueueRequestsFromMapFunc(kutil.MachineToInfrastructureMapFunc(infrav1alpha1.GroupVersion.WithKind("LinodeMachine"))),
 		).
+		Owns(&ipamv1.IPAddressClaim{}).
 		Watches(
 			&infrav1alpha1.LinodeCluster{},
 			handler.EnqueueRequestsFromMapFunc(r.linodeClusterToLinodeMachines(mgr.GetLogger())),
@@ -699,11 +812,31 @@ func (r *LinodeMachineReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(linodeMachineMapper),
 			builder.WithPredicates(predicates.ClusterUnpausedAndInfrastructureReady(mgr.GetLogger())),
 		).
-		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(mgr.GetLogger(), r.WatchFilterValue)).
-		Complete(r)
-	if err != nil {
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(mgr.GetLogger(), r.WatchFilterValue))
+
+	if r.LinodeEventPoller != nil {
+		if err := mgr.GetFieldIndexer().IndexField(context.Background(), &infrav1alpha1.LinodeMachine{}, instanceIDIndex, indexLinodeMachineByInstanceID); err != nil {
+			return fmt.Errorf("failed to index LinodeMachines by instance ID: %w", err)
+		}
+
+		r.linodeEvents = newLinodeEventStore()
+		linodeEventQueue := make(chan event.GenericEvent, linodeEventQueueSize)
+		r.LinodeEventPoller.Subscribe(r.linodeEventHandler(mgr.GetLogger(), linodeEventQueue))
+
+		controllerBuilder = controllerBuilder.WatchesRawSource(source.Channel(
+			linodeEventQueue,
+			&handler.EnqueueRequestForObject{},
+			source.WithPredicates[client.Object](predicates.ResourceNotPausedAndHasFilterLabel(mgr.GetLogger(), r.WatchFilterValue)),
+		))
+	}
+
+	if err := controllerBuilder.Complete(r); err != nil {
 		return fmt.Errorf("failed to build controller: %w", err)
 	}
 
 	return nil
 }
+
+func (r *LinodeMachineReconciler) requeueDelay() time.Duration {
+	return reconciler.DefaultTimeout(r.RequeueDelay, reconciler.DefaultMachineControllerWaitForRunningDelay)
+}
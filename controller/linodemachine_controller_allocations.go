@@ -0,0 +1,235 @@
+/*
+Copyright 2023 Akamai Technologies, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net/netip"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/cloud/scope"
+)
+
+// errPoolExhausted is returned when no prefix is left to allocate from a pool.
+var errPoolExhausted = errors.New("no prefix left to allocate")
+
+// prefixPool is a kind of IPv4 prefix allocated to the machines of a cluster and recorded in their status.
+type prefixPool struct {
+	// name identifies the pool in the allocations of the reconciler.
+	name string
+	// status returns the status field of a LinodeMachine recording its prefix from the pool.
+	status func(*infrav1alpha1.LinodeMachine) *string
+	// hostAddresses makes the pool allocate single addresses, other than the network and broadcast addresses of their
+	// block, which are recorded with the length of their block.
+	hostAddresses bool
+}
+
+var (
+	podCIDRPool = prefixPool{
+		name:   "podCIDR",
+		status: func(linodeMachine *infrav1alpha1.LinodeMachine) *string { return &linodeMachine.Status.PodCIDR },
+	}
+	vlanAddressPool = prefixPool{
+		name:          "vlanAddress",
+		status:        func(linodeMachine *infrav1alpha1.LinodeMachine) *string { return &linodeMachine.Status.VLANAddress },
+		hostAddresses: true,
+	}
+)
+
+// allocationKey identifies the prefixes allocated from a pool to the machines of a cluster.
+type allocationKey struct {
+	cluster types.NamespacedName
+	pool    string
+}
+
+// allocatePodCIDR returns the pod CIDR range of the machine. A range is allocated the first time: the first range of
+// the configured size in the IPv4 pod CIDRs of the Cluster which does not overlap the ranges of the other machines of
+// the Cluster. The range is recorded in the status of the LinodeMachine.
+func (r *LinodeMachineReconciler) allocatePodCIDR(ctx context.Context, machineScope *scope.MachineScope, logger logr.Logger) (string, error) {
+	if podCIDR := machineScope.LinodeMachine.Status.PodCIDR; podCIDR != "" {
+		return podCIDR, nil
+	}
+	maskSize := *machineScope.LinodeMachine.Spec.VPC.PodCIDRMaskSize
+
+	var blocks []netip.Prefix
+	if network := machineScope.Cluster.Spec.ClusterNetwork; network != nil && network.Pods != nil {
+		for _, block := range network.Pods.CIDRBlocks {
+			prefix, err := netip.ParsePrefix(block)
+			if err == nil && prefix.Addr().Is4() && prefix.Bits() <= maskSize {
+				blocks = append(blocks, prefix.Masked())
+			}
+		}
+	}
+	if len(blocks) == 0 {
+		return "", fmt.Errorf("cluster %s has no IPv4 pod CIDR to allocate a /%d range from", machineScope.Cluster.Name, maskSize)
+	}
+
+	podCIDR, err := r.allocatePrefix(ctx, machineScope, podCIDRPool, blocks, maskSize, logger)
+	if errors.Is(err, errPoolExhausted) {
+		return "", fmt.Errorf("no /%d range left in the pod CIDRs of cluster %s", maskSize, machineScope.Cluster.Name)
+	}
+
+	return podCIDR, err
+}
+
+// allocateVLANAddress returns the address of the machine on the VLAN of its LinodeCluster, in CIDR notation. An
+// address is allocated the first time: the first host address of the CIDR of the VLAN which is not used by another
+// machine of the Cluster. The address is recorded in the status of the LinodeMachine.
+func (r *LinodeMachineReconciler) allocateVLANAddress(ctx context.Context, machineScope *scope.MachineScope, logger logr.Logger) (string, error) {
+	if vlanAddress := machineScope.LinodeMachine.Status.VLANAddress; vlanAddress != "" {
+		return vlanAddress, nil
+	}
+	vlan := machineScope.LinodeCluster.Spec.Network.VLAN
+
+	block, err := netip.ParsePrefix(vlan.CIDR)
+	if err != nil || !block.Addr().Is4() {
+		return "", fmt.Errorf("VLAN %s has no IPv4 CIDR to allocate an address from", vlan.Label)
+	}
+
+	vlanAddress, err := r.allocatePrefix(ctx, machineScope, vlanAddressPool, []netip.Prefix{block.Masked()}, 32, logger)
+	if errors.Is(err, errPoolExhausted) {
+		return "", fmt.Errorf("no address left in the CIDR of VLAN %s", vlan.Label)
+	}
+
+	return vlanAddress, err
+}
+
+// allocatePrefix allocates to the machine the first prefix of length bits in blocks which does not overlap the
+// prefixes allocated from pool to the other machines of the Cluster, and records it in the status of the
+// LinodeMachine. It returns errPoolExhausted if there is none.
+func (r *LinodeMachineReconciler) allocatePrefix(ctx context.Context, machineScope *scope.MachineScope, pool prefixPool, blocks []netip.Prefix, bits int, logger logr.Logger) (string, error) {
+	linodeMachine := machineScope.LinodeMachine
+	status := pool.status(linodeMachine)
+
+	r.allocationsMu.Lock()
+	defer r.allocationsMu.Unlock()
+
+	key := allocationKey{
+		cluster: types.NamespacedName{Namespace: linodeMachine.Namespace, Name: machineScope.Cluster.Name},
+		pool:    pool.name,
+	}
+	if prefix, ok := r.allocations[key][linodeMachine.Name]; ok {
+		*status = pool.format(prefix, blocks)
+
+		return *status, nil
+	}
+
+	var machines infrav1alpha1.LinodeMachineList
+	if err := r.List(ctx, &machines, client.InNamespace(key.cluster.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: key.cluster.Name}); err != nil {
+		logger.Error(err, "Failed to list LinodeMachines")
+
+		return "", err
+	}
+	var allocated []netip.Prefix
+	for i := range machines.Items {
+		machine := &machines.Items[i]
+		if prefix, err := pool.parse(*pool.status(machine)); err == nil && machine.Name != linodeMachine.Name {
+			allocated = append(allocated, prefix)
+		}
+	}
+	for _, prefix := range r.allocations[key] {
+		allocated = append(allocated, prefix)
+	}
+
+	for _, block := range blocks {
+		for prefix, ok := netip.PrefixFrom(block.Addr(), bits), true; ok && block.Contains(prefix.Addr()); prefix, ok = nextPrefix(prefix) {
+			if slices.ContainsFunc(allocated, prefix.Overlaps) {
+				continue
+			}
+			if pool.hostAddresses && (prefix.Addr() == block.Addr() || !block.Contains(prefix.Addr().Next())) {
+				continue
+			}
+			if r.allocations == nil {
+				r.allocations = make(map[allocationKey]map[string]netip.Prefix)
+			}
+			if r.allocations[key] == nil {
+				r.allocations[key] = make(map[string]netip.Prefix)
+			}
+			r.allocations[key][linodeMachine.Name] = prefix
+			*status = pool.format(prefix, blocks)
+			logger.Info("allocated prefix", pool.name, *status)
+
+			return *status, nil
+		}
+	}
+
+	return "", errPoolExhausted
+}
+
+// parse returns the prefix allocated from the pool recorded as value.
+func (p prefixPool) parse(value string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil || !p.hostAddresses {
+		return prefix, err
+	}
+
+	return netip.PrefixFrom(prefix.Addr(), prefix.Addr().BitLen()), nil
+}
+
+// format returns how the prefix allocated from the pool is recorded.
+func (p prefixPool) format(prefix netip.Prefix, blocks []netip.Prefix) string {
+	if p.hostAddresses {
+		for _, block := range blocks {
+			if block.Contains(prefix.Addr()) {
+				return netip.PrefixFrom(prefix.Addr(), block.Bits()).String()
+			}
+		}
+	}
+
+	return prefix.String()
+}
+
+// nextPrefix returns the IPv4 prefix of the same length following prefix, and false if there is none.
+func nextPrefix(prefix netip.Prefix) (netip.Prefix, bool) {
+	addr := prefix.Addr().As4()
+	next := uint64(binary.BigEndian.Uint32(addr[:])) + 1<<(32-prefix.Bits())
+	if next > math.MaxUint32 {
+		return netip.Prefix{}, false
+	}
+	binary.BigEndian.PutUint32(addr[:], uint32(next))
+
+	return netip.PrefixFrom(netip.AddrFrom4(addr), prefix.Bits()), true
+}
+
+// releaseAllocations frees the prefixes allocated to the machine from every pool, if any.
+func (r *LinodeMachineReconciler) releaseAllocations(machineScope *scope.MachineScope) {
+	r.allocationsMu.Lock()
+	defer r.allocationsMu.Unlock()
+
+	linodeMachine := machineScope.LinodeMachine
+	for _, pool := range []prefixPool{podCIDRPool, vlanAddressPool} {
+		key := allocationKey{
+			cluster: types.NamespacedName{Namespace: linodeMachine.Namespace, Name: machineScope.Cluster.Name},
+			pool:    pool.name,
+		}
+		delete(r.allocations[key], linodeMachine.Name)
+		if len(r.allocations[key]) == 0 {
+			delete(r.allocations, key)
+		}
+		*pool.status(linodeMachine) = ""
+	}
+}
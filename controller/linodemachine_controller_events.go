@@ -0,0 +1,182 @@
+/*
+Copyright 2023 Akamai Technologies, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/linode/linodego"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/cloud/events"
+	"github.com/linode/cluster-api-provider-linode/cloud/scope"
+	"github.com/linode/cluster-api-provider-linode/util/reconciler"
+)
+
+const (
+	// ConditionLinodeOperationSucceeded reports whether the last Linode operation on the instance succeeded.
+	ConditionLinodeOperationSucceeded clusterv1.ConditionType = "LinodeOperationSucceeded"
+
+	// linodeEventFailedReason is the condition reason for a failed Linode event.
+	linodeEventFailedReason = "LinodeEventFailed"
+
+	// instanceIDIndex is the field index of LinodeMachines by Linode instance ID.
+	instanceIDIndex = "spec.instanceID"
+
+	// linodeEventQueueSize is the number of LinodeMachines which can be waiting to be enqueued for a Linode event.
+	linodeEventQueueSize = 128
+)
+
+// machineEventActions are the Linode event actions a LinodeMachine may be waiting on.
+var machineEventActions = map[linodego.EventAction]bool{
+	linodego.ActionLinodeCreate:   true,
+	linodego.ActionLinodeBoot:     true,
+	linodego.ActionLinodeReboot:   true,
+	linodego.ActionLinodeShutdown: true,
+	linodego.ActionLinodeRebuild:  true,
+	linodego.ActionLinodeResize:   true,
+	linodego.ActionDiskCreate:     true,
+	linodego.ActionDiskResize:     true,
+	linodego.ActionDiskDelete:     true,
+}
+
+// linodeEventStore keeps the last terminal Linode event of each instance until it is reconciled.
+type linodeEventStore struct {
+	mu     sync.Mutex
+	events map[int]linodego.Event
+}
+
+func newLinodeEventStore() *linodeEventStore {
+	return &linodeEventStore{events: make(map[int]linodego.Event)}
+}
+
+func (s *linodeEventStore) record(instanceID int, event linodego.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events[instanceID] = event
+}
+
+func (s *linodeEventStore) pop(instanceID int) (linodego.Event, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event, ok := s.events[instanceID]
+	delete(s.events, instanceID)
+
+	return event, ok
+}
+
+// indexLinodeMachineByInstanceID indexes LinodeMachines by the ID of their Linode instance.
+func indexLinodeMachineByInstanceID(obj client.Object) []string {
+	linodeMachine, ok := obj.(*infrav1alpha1.LinodeMachine)
+	if !ok || linodeMachine.Spec.InstanceID == nil {
+		return nil
+	}
+
+	return []string{strconv.Itoa(*linodeMachine.Spec.InstanceID)}
+}
+
+// linodeEventHandler returns the event poller handler that enqueues the LinodeMachines whose instance has an
+// operation which finished or failed.
+func (r *LinodeMachineReconciler) linodeEventHandler(logger logr.Logger, queue chan<- event.GenericEvent) events.Handler {
+	logger = logger.WithName("LinodeMachineReconciler").WithName("linodeEventHandler")
+
+	return func(ctx context.Context, linodeEvent linodego.Event) {
+		if !machineEventActions[linodeEvent.Action] ||
+			linodeEvent.Entity == nil || linodeEvent.Entity.Type != linodego.EntityLinode {
+			return
+		}
+		instanceID := events.EntityID(linodeEvent.Entity)
+		if instanceID == 0 {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultMappingTimeout)
+		defer cancel()
+
+		var linodeMachines infrav1alpha1.LinodeMachineList
+		if err := r.Client.List(ctx, &linodeMachines, client.MatchingFields{instanceIDIndex: strconv.Itoa(instanceID)}); err != nil {
+			logger.Error(err, "Failed to list LinodeMachines", "instanceID", instanceID)
+
+			return
+		}
+		if len(linodeMachines.Items) == 0 {
+			return
+		}
+
+		r.linodeEvents.record(instanceID, linodeEvent)
+
+		for i := range linodeMachines.Items {
+			select {
+			case queue <- event.GenericEvent{Object: &linodeMachines.Items[i]}:
+			case <-ctx.Done():
+				logger.Info("Timed out enqueueing LinodeMachine", "name", linodeMachines.Items[i].Name)
+
+				return
+			}
+		}
+	}
+}
+
+// applyLinodeEvent records the outcome of the last Linode event of the instance in the
+// ConditionLinodeOperationSucceeded condition.
+func (r *LinodeMachineReconciler) applyLinodeEvent(machineScope *scope.MachineScope) {
+	if r.linodeEvents == nil || machineScope.LinodeMachine.Spec.InstanceID == nil {
+		return
+	}
+
+	linodeEvent, ok := r.linodeEvents.pop(*machineScope.LinodeMachine.Spec.InstanceID)
+	if !ok {
+		return
+	}
+
+	switch linodeEvent.Status {
+	case linodego.EventFailed:
+		conditions.MarkFalse(machineScope.LinodeMachine, ConditionLinodeOperationSucceeded, linodeEventFailedReason, clusterv1.ConditionSeverityWarning, events.Message(linodeEvent))
+	case linodego.EventFinished:
+		if conditions.Has(machineScope.LinodeMachine, ConditionLinodeOperationSucceeded) {
+			conditions.MarkTrue(machineScope.LinodeMachine, ConditionLinodeOperationSucceeded)
+		}
+	}
+}
+
+// waitForLinodeDelay returns how long to wait before checking on a Linode operation again. When the account events
+// are polled with the controller credentials, the LinodeMachine is enqueued as soon as the operation completes, so
+// the requeue is only a fallback.
+func (r *LinodeMachineReconciler) waitForLinodeDelay(machineScope *scope.MachineScope) time.Duration {
+	if !r.watchesLinodeEvents(machineScope) {
+		return r.requeueDelay()
+	}
+
+	return reconciler.DefaultMachineControllerWaitForEventDelay
+}
+
+// watchesLinodeEvents returns true if the LinodeMachine is enqueued by the events of its Linode, which are only polled
+// with the controller credentials.
+func (r *LinodeMachineReconciler) watchesLinodeEvents(machineScope *scope.MachineScope) bool {
+	return r.LinodeEventPoller != nil &&
+		machineScope.LinodeMachine.Spec.CredentialsRef == nil && machineScope.LinodeCluster.Spec.CredentialsRef == nil
+}
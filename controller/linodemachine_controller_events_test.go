@@ -0,0 +1,300 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/cloud/events"
+	"github.com/linode/cluster-api-provider-linode/cloud/scope"
+	"github.com/linode/cluster-api-provider-linode/mock"
+	"github.com/linode/cluster-api-provider-linode/util"
+	"github.com/linode/cluster-api-provider-linode/util/reconciler"
+)
+
+func TestLinodeEventHandler(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, infrav1alpha1.AddToScheme(scheme))
+
+	tests := []struct {
+		name         string
+		event        linodego.Event
+		expectedName string
+	}{
+		{
+			name: "finished boot enqueues the machine",
+			event: linodego.Event{
+				ID:     1,
+				Action: linodego.ActionLinodeBoot,
+				Status: linodego.EventFinished,
+				Entity: &linodego.EventEntity{ID: float64(123), Type: linodego.EntityLinode},
+			},
+			expectedName: "machine-123",
+		},
+		{
+			name: "failed disk resize enqueues the machine",
+			event: linodego.Event{
+				ID:     2,
+				Action: linodego.ActionDiskResize,
+				Status: linodego.EventFailed,
+				Entity: &linodego.EventEntity{ID: float64(123), Type: linodego.EntityLinode},
+			},
+			expectedName: "machine-123",
+		},
+		{
+			name: "unknown instance is ignored",
+			event: linodego.Event{
+				ID:     3,
+				Action: linodego.ActionLinodeBoot,
+				Status: linodego.EventFinished,
+				Entity: &linodego.EventEntity{ID: float64(999), Type: linodego.EntityLinode},
+			},
+		},
+		{
+			name: "unrelated action is ignored",
+			event: linodego.Event{
+				ID:     4,
+				Action: linodego.ActionLinodeAddIP,
+				Status: linodego.EventFinished,
+				Entity: &linodego.EventEntity{ID: float64(123), Type: linodego.EntityLinode},
+			},
+		},
+		{
+			name: "other entity type is ignored",
+			event: linodego.Event{
+				ID:     5,
+				Action: linodego.ActionDiskCreate,
+				Status: linodego.EventFinished,
+				Entity: &linodego.EventEntity{ID: float64(123), Type: linodego.EntityVolume},
+			},
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithIndex(&infrav1alpha1.LinodeMachine{}, instanceIDIndex, indexLinodeMachineByInstanceID).
+				WithObjects(
+					&infrav1alpha1.LinodeMachine{
+						ObjectMeta: metav1.ObjectMeta{Name: "machine-123", Namespace: "default"},
+						Spec:       infrav1alpha1.LinodeMachineSpec{InstanceID: util.Pointer(123)},
+					},
+					&infrav1alpha1.LinodeMachine{
+						ObjectMeta: metav1.ObjectMeta{Name: "machine-456", Namespace: "default"},
+						Spec:       infrav1alpha1.LinodeMachineSpec{InstanceID: util.Pointer(456)},
+					},
+				).
+				Build()
+
+			r := &LinodeMachineReconciler{Client: k8sClient, linodeEvents: newLinodeEventStore()}
+			queue := make(chan event.GenericEvent, 1)
+
+			r.linodeEventHandler(logr.Discard(), queue)(context.Background(), testcase.event)
+
+			if testcase.expectedName == "" {
+				assert.Empty(t, queue)
+
+				return
+			}
+			require.Len(t, queue, 1)
+			assert.Equal(t, testcase.expectedName, (<-queue).Object.GetName())
+
+			recorded, ok := r.linodeEvents.pop(123)
+			assert.True(t, ok)
+			assert.Equal(t, testcase.event.ID, recorded.ID)
+		})
+	}
+}
+
+func TestApplyLinodeEvent(t *testing.T) {
+	t.Parallel()
+
+	failed := linodego.Event{
+		ID:     1,
+		Action: linodego.ActionDiskResize,
+		Status: linodego.EventFailed,
+		Entity: &linodego.EventEntity{ID: float64(123), Type: linodego.EntityLinode, Label: "machine-123"},
+	}
+	finished := linodego.Event{
+		ID:     2,
+		Action: linodego.ActionDiskResize,
+		Status: linodego.EventFinished,
+		Entity: &linodego.EventEntity{ID: float64(123), Type: linodego.EntityLinode, Label: "machine-123"},
+	}
+
+	r := &LinodeMachineReconciler{linodeEvents: newLinodeEventStore()}
+	machineScope := &scope.MachineScope{
+		LinodeMachine: &infrav1alpha1.LinodeMachine{
+			Spec: infrav1alpha1.LinodeMachineSpec{InstanceID: util.Pointer(123)},
+		},
+	}
+
+	// A finished event does not add the condition
+	r.linodeEvents.record(123, finished)
+	r.applyLinodeEvent(machineScope)
+	assert.False(t, conditions.Has(machineScope.LinodeMachine, ConditionLinodeOperationSucceeded))
+
+	// A failed event marks the condition false with the event message
+	r.linodeEvents.record(123, failed)
+	r.applyLinodeEvent(machineScope)
+	condition := conditions.Get(machineScope.LinodeMachine, ConditionLinodeOperationSucceeded)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, linodeEventFailedReason, condition.Reason)
+	assert.Equal(t, clusterv1.ConditionSeverityWarning, condition.Severity)
+	assert.Equal(t, events.Message(failed), condition.Message)
+
+	// Events are only applied once
+	_, ok := r.linodeEvents.pop(123)
+	assert.False(t, ok)
+
+	// A later finished event clears the failure
+	r.linodeEvents.record(123, finished)
+	r.applyLinodeEvent(machineScope)
+	assert.True(t, conditions.IsTrue(machineScope.LinodeMachine, ConditionLinodeOperationSucceeded))
+}
+
+func TestWaitForLinodeDelay(t *testing.T) {
+	t.Parallel()
+
+	newMachineScope := func(credentialsRef *corev1.SecretReference) *scope.MachineScope {
+		return &scope.MachineScope{
+			LinodeCluster: &infrav1alpha1.LinodeCluster{},
+			LinodeMachine: &infrav1alpha1.LinodeMachine{
+				Spec: infrav1alpha1.LinodeMachineSpec{CredentialsRef: credentialsRef},
+			},
+		}
+	}
+
+	withoutPoller := &LinodeMachineReconciler{}
+	withPoller := &LinodeMachineReconciler{LinodeEventPoller: events.NewPoller(nil, 0)}
+
+	assert.Equal(t, reconciler.DefaultMachineControllerWaitForRunningDelay, withoutPoller.waitForLinodeDelay(newMachineScope(nil)))
+	assert.Equal(t, reconciler.DefaultMachineControllerWaitForEventDelay, withPoller.waitForLinodeDelay(newMachineScope(nil)))
+	assert.Equal(t, reconciler.DefaultMachineControllerWaitForRunningDelay,
+		withPoller.waitForLinodeDelay(newMachineScope(&corev1.SecretReference{Name: "other-account"})))
+}
+
+func TestReconcileUpdateWaitsForLinodeEvent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		status          linodego.InstanceStatus
+		operationFailed bool
+		poller          bool
+		waitingSince    time.Duration
+		timedOut        bool
+		expectRequeue   bool
+		expectedReady   bool
+		expectedMessage string
+	}{
+		{
+			name:          "running instance is ready",
+			status:        linodego.InstanceRunning,
+			expectedReady: true,
+		},
+		{
+			name:          "running operation is waited on",
+			status:        linodego.InstanceBooting,
+			expectRequeue: true,
+		},
+		{
+			name:          "operation is waited on until the timeout without events",
+			status:        linodego.InstanceBooting,
+			waitingSince:  10 * time.Minute,
+			expectRequeue: true,
+		},
+		{
+			name:            "long running operation is not waited on without events",
+			status:          linodego.InstanceBooting,
+			waitingSince:    time.Hour,
+			expectedMessage: "waiting for the instance operation",
+		},
+		{
+			name:            "operation is not waited on once timed out without events",
+			status:          linodego.InstanceBooting,
+			timedOut:        true,
+			expectedMessage: "waiting for the instance operation",
+		},
+		{
+			name:          "long running operation is waited on with events",
+			status:        linodego.InstanceBooting,
+			poller:        true,
+			waitingSince:  time.Hour,
+			expectRequeue: true,
+		},
+		{
+			name:            "failed operation is not waited on",
+			status:          linodego.InstanceOffline,
+			operationFailed: true,
+			expectedMessage: "skipped due to failed operation",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			linodeClient := mock.NewMockLinodeClient(gomock.NewController(t))
+			linodeClient.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: testcase.status}, nil)
+			linodeClient.EXPECT().UpdateInstance(gomock.Any(), 123, gomock.Any()).Return(&linodego.Instance{ID: 123}, nil).AnyTimes()
+
+			machineScope := &scope.MachineScope{
+				LinodeClient:  linodeClient,
+				LinodeCluster: &infrav1alpha1.LinodeCluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"}},
+				LinodeMachine: &infrav1alpha1.LinodeMachine{
+					Spec: infrav1alpha1.LinodeMachineSpec{InstanceID: util.Pointer(123)},
+				},
+			}
+			if testcase.operationFailed {
+				conditions.MarkFalse(machineScope.LinodeMachine, ConditionLinodeOperationSucceeded, linodeEventFailedReason, clusterv1.ConditionSeverityWarning, "disk_resize failed")
+			}
+			if testcase.waitingSince != 0 {
+				machineScope.LinodeMachine.Status.Conditions = clusterv1.Conditions{{
+					Type:               clusterv1.ReadyCondition,
+					Status:             corev1.ConditionFalse,
+					Severity:           clusterv1.ConditionSeverityWarning,
+					Reason:             string(testcase.status),
+					Message:            "waiting for the instance operation",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-testcase.waitingSince)),
+				}}
+			}
+
+			if testcase.timedOut {
+				conditions.MarkFalse(machineScope.LinodeMachine, clusterv1.ReadyCondition, string(testcase.status), clusterv1.ConditionSeverityError, "waiting for the instance operation")
+			}
+
+			r := &LinodeMachineReconciler{}
+			if testcase.poller {
+				r.LinodeEventPoller = events.NewPoller(nil, 0)
+			}
+			res, _, err := r.reconcileUpdate(context.Background(), logr.Discard(), machineScope)
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expectRequeue, res.RequeueAfter > 0)
+			assert.Equal(t, testcase.expectedReady, conditions.IsTrue(machineScope.LinodeMachine, clusterv1.ReadyCondition))
+			if testcase.expectedMessage != "" {
+				assert.Equal(t, testcase.expectedMessage, conditions.GetMessage(machineScope.LinodeMachine, clusterv1.ReadyCondition))
+			}
+		})
+	}
+}
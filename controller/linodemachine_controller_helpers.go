@@ -23,27 +23,34 @@ import (
 	"encoding/gob"
 	"errors"
 	"fmt"
+	"net/netip"
 	"slices"
-	"sort"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
 	"github.com/linode/linodego"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/component-base/featuregate"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1beta1"
 	kutil "sigs.k8s.io/cluster-api/util"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
 	"github.com/linode/cluster-api-provider-linode/cloud/scope"
 	"github.com/linode/cluster-api-provider-linode/cloud/services"
+	"github.com/linode/cluster-api-provider-linode/feature"
 	"github.com/linode/cluster-api-provider-linode/util"
 	"github.com/linode/cluster-api-provider-linode/util/reconciler"
 )
 
+// errIPAddressNotAllocated is returned while the VPC IPv4 address of a machine is not allocated by its IPAM pool yet.
+var errIPAddressNotAllocated = errors.New("waiting for the VPC IPv4 address to be allocated")
+
 // Size limit in bytes on the decoded metadata.user_data for cloud-init
 // The decoded user_data must not exceed 16384 bytes per the Linode API
 const maxBootstrapDataBytes = 16384
@@ -62,7 +69,7 @@ func (r *LinodeMachineReconciler) newCreateConfig(ctx context.Context, machineSc
 
 	createConfig.Booted = util.Pointer(false)
 
-	if err := setUserData(ctx, machineScope, createConfig, logger); err != nil {
+	if err := setUserData(ctx, machineScope, createConfig, feature.Gates, logger); err != nil {
 		return nil, err
 	}
 
@@ -72,10 +79,7 @@ func (r *LinodeMachineReconciler) newCreateConfig(ctx context.Context, machineSc
 		createConfig.PrivateIP = true
 	}
 
-	if createConfig.Tags == nil {
-		createConfig.Tags = []string{}
-	}
-	createConfig.Tags = append(createConfig.Tags, tags...)
+	createConfig.Tags, _ = util.MergeTags(createConfig.Tags, tags)
 
 	if createConfig.Label == "" {
 		createConfig.Label = machineScope.LinodeMachine.Name
@@ -88,7 +92,8 @@ func (r *LinodeMachineReconciler) newCreateConfig(ctx context.Context, machineSc
 		createConfig.RootPass = uuid.NewString()
 	}
 
-	// if vpc, attach additional interface to linode (eth1)
+	// if vpc, attach additional interface to linode (eth1). Existing VPCRefs are honoured when the LinodeVPC feature is
+	// disabled, so that new machines of a cluster are attached to its VPC like the others
 	if machineScope.LinodeCluster.Spec.VPCRef != nil {
 		iface, err := r.getVPCInterfaceConfig(ctx, machineScope, createConfig.Interfaces, logger)
 		if err != nil {
@@ -99,9 +104,53 @@ func (r *LinodeMachineReconciler) newCreateConfig(ctx context.Context, machineSc
 		createConfig.Interfaces = append(createConfig.Interfaces, *iface)
 	}
 
+	// if the cluster has a vlan, attach the machine to it unless its spec already does
+	if machineScope.LinodeCluster.Spec.Network.VLAN != nil {
+		iface, err := r.getVLANInterfaceConfig(ctx, machineScope, createConfig.Interfaces, logger)
+		if err != nil {
+			logger.Error(err, "Failed to get VLAN interface config")
+
+			return nil, err
+		}
+		if iface != nil {
+			if len(createConfig.Interfaces) == 0 {
+				// keep the public interface the instance gets without interfaces as eth0
+				createConfig.Interfaces = append(createConfig.Interfaces, linodego.InstanceConfigInterfaceCreateOptions{
+					Purpose: linodego.InterfacePurposePublic,
+					Primary: true,
+				})
+			}
+			createConfig.Interfaces = append(createConfig.Interfaces, *iface)
+		}
+	}
+
 	return createConfig, nil
 }
 
+// getVLANInterfaceConfig returns the interface attaching the machine to the VLAN of its LinodeCluster with an address
+// allocated from the CIDR of the VLAN, or nil if existingIfaces already attach it to the VLAN.
+func (r *LinodeMachineReconciler) getVLANInterfaceConfig(ctx context.Context, machineScope *scope.MachineScope, existingIfaces []linodego.InstanceConfigInterfaceCreateOptions, logger logr.Logger) (*linodego.InstanceConfigInterfaceCreateOptions, error) {
+	vlan := machineScope.LinodeCluster.Spec.Network.VLAN
+	for i := range existingIfaces {
+		if existingIfaces[i].Purpose == linodego.InterfacePurposeVLAN && existingIfaces[i].Label == vlan.Label {
+			logger.Info("Machine is already attached to the VLAN", "vlan", vlan.Label)
+
+			return nil, nil
+		}
+	}
+
+	vlanAddress, err := r.allocateVLANAddress(ctx, machineScope, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &linodego.InstanceConfigInterfaceCreateOptions{
+		Purpose:     linodego.InterfacePurposeVLAN,
+		Label:       vlan.Label,
+		IPAMAddress: vlanAddress,
+	}, nil
+}
+
 func (r *LinodeMachineReconciler) buildInstanceAddrs(ctx context.Context, machineScope *scope.MachineScope, instanceID int) ([]clusterv1.MachineAddress, error) {
 	addresses, err := machineScope.LinodeClient.GetInstanceIPAddresses(ctx, instanceID)
 	if err != nil {
@@ -121,9 +170,17 @@ func (r *LinodeMachineReconciler) buildInstanceAddrs(ctx context.Context, machin
 	}
 
 	// Iterate over interfaces in config and find VPC specific ips
+	for _, address := range services.VPCIPv4Addresses(configs[0]) {
+		ips = append(ips, clusterv1.MachineAddress{Address: address, Type: clusterv1.MachineInternalIP})
+	}
+
+	// store the addresses of the machine on vlans after the VPC ips
 	for _, iface := range configs[0].Interfaces {
-		if iface.VPCID != nil && iface.IPv4.VPC != "" {
-			ips = append(ips, clusterv1.MachineAddress{Address: iface.IPv4.VPC, Type: clusterv1.MachineInternalIP})
+		if iface.Purpose != linodego.InterfacePurposeVLAN || iface.IPAMAddress == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(iface.IPAMAddress); err == nil {
+			ips = append(ips, clusterv1.MachineAddress{Address: prefix.Addr().String(), Type: clusterv1.MachineInternalIP})
 		}
 	}
 
@@ -298,7 +355,6 @@ func (r *LinodeMachineReconciler) getVPCInterfaceConfig(ctx context.Context, mac
 		}
 	}
 
-	var subnetID int
 	vpc, err := machineScope.LinodeClient.GetVPC(ctx, *linodeVPC.Spec.VPCID)
 	if err != nil {
 		logger.Error(err, "Failed to fetch LinodeVPC")
@@ -310,25 +366,177 @@ func (r *LinodeMachineReconciler) getVPCInterfaceConfig(ctx context.Context, mac
 
 		return nil, errors.New("failed to fetch VPC")
 	}
-	if len(vpc.Subnets) == 0 {
-		logger.Error(nil, "Failed to find subnet")
 
-		return nil, errors.New("failed to find subnet")
+	subnets := vpc.Subnets
+	var ipv4 *linodego.VPCIPv4
+	if opts := machineScope.LinodeMachine.Spec.VPC; opts != nil && opts.IPv4AddressPoolRef != nil {
+		addr, err := r.reconcileVPCIPAddress(ctx, machineScope, logger)
+		if err != nil {
+			return nil, err
+		}
+		subnets = slices.DeleteFunc(slices.Clone(subnets), func(subnet linodego.VPCSubnet) bool {
+			prefix, err := netip.ParsePrefix(subnet.IPv4)
+
+			return err != nil || !prefix.Contains(addr)
+		})
+		if len(subnets) == 0 {
+			err := fmt.Errorf("no subnet of VPC %d contains address %s", vpc.ID, addr)
+			logger.Error(err, "Failed to find subnet")
+
+			return nil, err
+		}
+		ipv4 = &linodego.VPCIPv4{VPC: addr.String()}
 	}
-	// Place node into the least busy subnet
-	sort.Slice(vpc.Subnets, func(i, j int) bool {
-		return len(vpc.Subnets[i].Linodes) > len(vpc.Subnets[j].Linodes)
-	})
 
-	subnetID = vpc.Subnets[0].ID
+	var ipRanges []string
+	if opts := machineScope.LinodeMachine.Spec.VPC; opts != nil && opts.PodCIDRMaskSize != nil {
+		podCIDR, err := r.allocatePodCIDR(ctx, machineScope, logger)
+		if err != nil {
+			logger.Error(err, "Failed to allocate pod CIDR")
+
+			return nil, err
+		}
+		ipRanges = []string{podCIDR}
+	}
+
+	subnet, err := selectVPCSubnet(subnets, machineScope.LinodeMachine.Spec.VPC)
+	if err != nil {
+		logger.Error(err, "Failed to find subnet")
+
+		return nil, err
+	}
+	subnetID := subnet.ID
 
 	return &linodego.InstanceConfigInterfaceCreateOptions{
 		Purpose:  linodego.InterfacePurposeVPC,
 		Primary:  !hasPrimary,
 		SubnetID: &subnetID,
+		IPv4:     ipv4,
+		IPRanges: ipRanges,
 	}, nil
 }
 
+// vpcIPAddressClaimName returns the name of the IPAddressClaim for the VPC IPv4 address of linodeMachine.
+func vpcIPAddressClaimName(linodeMachine *infrav1alpha1.LinodeMachine) string {
+	return linodeMachine.Name + "-vpc-ipv4"
+}
+
+// reconcileVPCIPAddress claims the VPC IPv4 address of the machine from the pool referenced by its spec, and returns
+// the address once it is allocated. The claim is owned by the LinodeMachine.
+func (r *LinodeMachineReconciler) reconcileVPCIPAddress(ctx context.Context, machineScope *scope.MachineScope, logger logr.Logger) (netip.Addr, error) {
+	claim := &ipamv1.IPAddressClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      vpcIPAddressClaimName(machineScope.LinodeMachine),
+			Namespace: machineScope.LinodeMachine.Namespace,
+		},
+	}
+	logger = logger.WithValues("ipAddressClaim", claim.Name)
+
+	if err := r.Get(ctx, client.ObjectKeyFromObject(claim), claim); apierrors.IsNotFound(err) {
+		claim.Labels = map[string]string{clusterv1.ClusterNameLabel: machineScope.Cluster.Name}
+		claim.Spec.PoolRef = *machineScope.LinodeMachine.Spec.VPC.IPv4AddressPoolRef
+		if err := controllerutil.SetControllerReference(machineScope.LinodeMachine, claim, r.Scheme); err != nil {
+			return netip.Addr{}, err
+		}
+		if err := r.Create(ctx, claim); err != nil {
+			logger.Error(err, "Failed to create IPAddressClaim")
+
+			return netip.Addr{}, err
+		}
+		logger.Info("created IPAddressClaim")
+
+		return netip.Addr{}, errIPAddressNotAllocated
+	} else if err != nil {
+		logger.Error(err, "Failed to fetch IPAddressClaim")
+
+		return netip.Addr{}, err
+	}
+
+	if claim.Status.AddressRef.Name == "" {
+		return netip.Addr{}, errIPAddressNotAllocated
+	}
+	var address ipamv1.IPAddress
+	if err := r.Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: claim.Status.AddressRef.Name}, &address); err != nil {
+		logger.Error(err, "Failed to fetch IPAddress")
+
+		return netip.Addr{}, err
+	}
+	addr, err := netip.ParseAddr(address.Spec.Address)
+	if err != nil || !addr.Is4() {
+		return netip.Addr{}, fmt.Errorf("IPAddress %s is not an IPv4 address: %q", address.Name, address.Spec.Address)
+	}
+
+	return addr, nil
+}
+
+// releaseVPCIPAddress deletes the IPAddressClaim for the VPC IPv4 address of the machine, if any.
+func (r *LinodeMachineReconciler) releaseVPCIPAddress(ctx context.Context, machineScope *scope.MachineScope) error {
+	if opts := machineScope.LinodeMachine.Spec.VPC; opts == nil || opts.IPv4AddressPoolRef == nil {
+		return nil
+	}
+	claim := &ipamv1.IPAddressClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      vpcIPAddressClaimName(machineScope.LinodeMachine),
+			Namespace: machineScope.LinodeMachine.Namespace,
+		},
+	}
+
+	return client.IgnoreNotFound(r.Delete(ctx, claim))
+}
+
+// selectVPCSubnet returns the subnet selected by opts, or else the subnet chosen by the placement policy of opts
+// among the subnets with available addresses. The policy defaults to least-used.
+func selectVPCSubnet(subnets []linodego.VPCSubnet, opts *infrav1alpha1.MachineVPCOptions) (*linodego.VPCSubnet, error) {
+	if opts != nil && opts.Subnet != nil {
+		for i := range subnets {
+			if (opts.Subnet.ID != nil && subnets[i].ID == *opts.Subnet.ID) ||
+				(opts.Subnet.Label != "" && subnets[i].Label == opts.Subnet.Label) {
+				return &subnets[i], nil
+			}
+		}
+
+		return nil, fmt.Errorf("failed to find subnet %s", formatSubnetSelector(*opts.Subnet))
+	}
+
+	// Order by ID so that ties are broken the same way on every reconcile
+	candidates := make([]linodego.VPCSubnet, 0, len(subnets))
+	attached := 0
+	for _, subnet := range subnets {
+		attached += len(subnet.Linodes)
+		if availableIPv4Addresses(subnet) > 0 {
+			candidates = append(candidates, subnet)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, errors.New("failed to find subnet with available addresses")
+	}
+	slices.SortFunc(candidates, func(a, b linodego.VPCSubnet) int { return a.ID - b.ID })
+
+	policy := infrav1alpha1.SubnetPlacementLeastUsed
+	if opts != nil && opts.SubnetPlacement != "" {
+		policy = opts.SubnetPlacement
+	}
+	switch policy {
+	case infrav1alpha1.SubnetPlacementMostUsed:
+		slices.SortStableFunc(candidates, func(a, b linodego.VPCSubnet) int { return len(b.Linodes) - len(a.Linodes) })
+	case infrav1alpha1.SubnetPlacementRoundRobin:
+		return &candidates[attached%len(candidates)], nil
+	default:
+		slices.SortStableFunc(candidates, func(a, b linodego.VPCSubnet) int { return len(a.Linodes) - len(b.Linodes) })
+	}
+
+	return &candidates[0], nil
+}
+
+// formatSubnetSelector returns a description of the subnet selected by selector for error messages.
+func formatSubnetSelector(selector infrav1alpha1.VPCSubnetSelector) string {
+	if selector.ID != nil {
+		return fmt.Sprintf("with ID %d", *selector.ID)
+	}
+
+	return fmt.Sprintf("with label %s", selector.Label)
+}
+
 func linodeMachineSpecToInstanceCreateConfig(machineSpec infrav1alpha1.LinodeMachineSpec) *linodego.InstanceCreateOptions {
 	var buf bytes.Buffer
 	enc := gob.NewEncoder(&buf)
@@ -347,7 +555,7 @@ func linodeMachineSpecToInstanceCreateConfig(machineSpec infrav1alpha1.LinodeMac
 	return &createConfig
 }
 
-func setUserData(ctx context.Context, machineScope *scope.MachineScope, createConfig *linodego.InstanceCreateOptions, logger logr.Logger) error {
+func setUserData(ctx context.Context, machineScope *scope.MachineScope, createConfig *linodego.InstanceCreateOptions, gates featuregate.FeatureGate, logger logr.Logger) error {
 	bootstrapData, err := machineScope.GetBootstrapData(ctx)
 	if err != nil {
 		logger.Error(err, "Failed to get bootstrap data")
@@ -382,6 +590,10 @@ func setUserData(ctx context.Context, machineScope *scope.MachineScope, createCo
 			UserData: b64.StdEncoding.EncodeToString(bootstrapData),
 		}
 	} else {
+		if !gates.Enabled(feature.StackScriptBootstrap) {
+			return fmt.Errorf("region %s or image %s does not support cloud-init metadata and the %s feature is disabled",
+				machineScope.LinodeMachine.Spec.Region, imageName, feature.StackScriptBootstrap)
+		}
 		logger.Info("using StackScripts for bootstrapping",
 			"imageMetadataSupport", imageMetadataSupport,
 			"regionMetadataSupport", regionMetadataSupport,
@@ -429,3 +641,19 @@ func createInstanceConfigDeviceMap(instanceDisks map[string]*infrav1alpha1.Insta
 
 	return nil
 }
+
+// reconcileInstanceTags sets the missing tags of the machine's cluster on linodeInstance, e.g. when it was created
+// before the ownership tags were introduced or the cluster was moved to another management cluster.
+func reconcileInstanceTags(ctx context.Context, machineScope *scope.MachineScope, linodeInstance *linodego.Instance) error {
+	tags, changed := util.MergeTags(linodeInstance.Tags, machineScope.Tags())
+	if !changed {
+		return nil
+	}
+
+	if _, err := machineScope.LinodeClient.UpdateInstance(ctx, linodeInstance.ID, linodego.InstanceUpdateOptions{Tags: &tags}); err != nil {
+		return err
+	}
+	linodeInstance.Tags = tags
+
+	return nil
+}
@@ -14,15 +14,20 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/cluster-api/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
 	"github.com/linode/cluster-api-provider-linode/cloud/scope"
+	"github.com/linode/cluster-api-provider-linode/feature"
 	"github.com/linode/cluster-api-provider-linode/mock"
 	"github.com/linode/cluster-api-provider-linode/util"
 )
@@ -85,6 +90,7 @@ func TestSetUserData(t *testing.T) {
 		machineScope  *scope.MachineScope
 		createConfig  *linodego.InstanceCreateOptions
 		wantConfig    *linodego.InstanceCreateOptions
+		features      string
 		expectedError error
 		expects       func(client *mock.MockLinodeClient, kClient *mock.MockK8sClient)
 	}{
@@ -165,7 +171,7 @@ func TestSetUserData(t *testing.T) {
 					Capabilities: []string{"Metadata"},
 				}, nil)
 				mockClient.EXPECT().GetImage(gomock.Any(), "linode/ubuntu22.04").Return(&linodego.Image{}, nil)
-				mockClient.EXPECT().ListStackscripts(gomock.Any(), &linodego.ListOptions{Filter: "{\"label\":\"CAPL-dev\"}"}).Return([]linodego.Stackscript{{
+				mockClient.EXPECT().ListStackscripts(gomock.Any(), &linodego.ListOptions{PageOptions: &linodego.PageOptions{Page: 1}, PageSize: 500, Filter: "{\"+and\":[{\"label\":\"CAPL-dev\"},{\"mine\":true}]}"}).Return([]linodego.Stackscript{{
 					Label: "CAPI Test 1",
 					ID:    1234,
 				}}, nil)
@@ -337,10 +343,40 @@ func TestSetUserData(t *testing.T) {
 					Capabilities: []string{"Metadata"},
 				}, nil)
 				mockClient.EXPECT().GetImage(gomock.Any(), "linode/ubuntu22.04").Return(&linodego.Image{}, nil)
-				mockClient.EXPECT().ListStackscripts(gomock.Any(), &linodego.ListOptions{Filter: "{\"label\":\"CAPL-dev\"}"}).Return(nil, fmt.Errorf("failed to get stackscripts"))
+				mockClient.EXPECT().ListStackscripts(gomock.Any(), &linodego.ListOptions{PageOptions: &linodego.PageOptions{Page: 1}, PageSize: 500, Filter: "{\"+and\":[{\"label\":\"CAPL-dev\"},{\"mine\":true}]}"}).Return(nil, fmt.Errorf("failed to get stackscripts"))
 			},
 			expectedError: fmt.Errorf("ensure stackscript: failed to get stackscript with label CAPL-dev: failed to get stackscripts"),
 		},
+		{
+			name: "Error - SetUserData StackScripts disabled",
+			machineScope: &scope.MachineScope{Machine: &v1beta1.Machine{
+				Spec: v1beta1.MachineSpec{
+					ClusterName: "",
+					Bootstrap: v1beta1.Bootstrap{
+						DataSecretName: ptr.To("test-data"),
+					},
+					InfrastructureRef: corev1.ObjectReference{},
+				},
+			}, LinodeMachine: &infrav1alpha1.LinodeMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "default",
+				},
+				Spec:   infrav1alpha1.LinodeMachineSpec{Region: "us-east", Image: "linode/ubuntu22.04", Type: "g6-standard-1"},
+				Status: infrav1alpha1.LinodeMachineStatus{},
+			}},
+			createConfig: &linodego.InstanceCreateOptions{},
+			features:     "StackScriptBootstrap=false",
+			expects: func(mockClient *mock.MockLinodeClient, kMock *mock.MockK8sClient) {
+				kMock.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, key types.NamespacedName, obj *corev1.Secret, opts ...client.GetOption) error {
+					*obj = corev1.Secret{Data: map[string][]byte{"value": []byte("test-data")}}
+					return nil
+				})
+				mockClient.EXPECT().GetRegion(gomock.Any(), "us-east").Return(&linodego.Region{}, nil)
+				mockClient.EXPECT().GetImage(gomock.Any(), "linode/ubuntu22.04").Return(&linodego.Image{}, nil)
+			},
+			expectedError: fmt.Errorf("the StackScriptBootstrap feature is disabled"),
+		},
 	}
 	for _, tt := range tests {
 		testcase := tt
@@ -357,7 +393,10 @@ func TestSetUserData(t *testing.T) {
 			testcase.expects(mockClient, mockK8sClient)
 			logger := logr.Logger{}
 
-			err := setUserData(context.Background(), testcase.machineScope, testcase.createConfig, logger)
+			gates := feature.NewGates()
+			require.NoError(t, gates.Set(testcase.features))
+
+			err := setUserData(context.Background(), testcase.machineScope, testcase.createConfig, gates, logger)
 			if testcase.expectedError != nil {
 				assert.ErrorContains(t, err, testcase.expectedError.Error())
 			} else {
@@ -466,3 +505,485 @@ func TestCreateInstanceConfigDeviceMap(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileInstanceTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		tags          []string
+		clusterLabels map[string]string
+		expectedTags  []string
+		expectedError error
+		expects       func(client *mock.MockLinodeClient)
+	}{
+		{
+			name:         "Success - Tags are up to date",
+			tags:         []string{"capl", "capl-mc=test-mc", "capl-ns=default", "capl-cluster=test-cluster", "capl-uid=test-uid"},
+			expectedTags: []string{"capl", "capl-mc=test-mc", "capl-ns=default", "capl-cluster=test-cluster", "capl-uid=test-uid"},
+		},
+		{
+			name:          "Success - Legacy tags are migrated",
+			tags:          []string{"test-cluster"},
+			clusterLabels: map[string]string{util.TagLabelPrefix + "env": "prod"},
+			expectedTags:  []string{"test-cluster", "capl", "capl-mc=test-mc", "capl-ns=default", "capl-cluster=test-cluster", "capl-uid=test-uid", "env=prod"},
+			expects: func(mockClient *mock.MockLinodeClient) {
+				mockClient.EXPECT().UpdateInstance(gomock.Any(), 123, linodego.InstanceUpdateOptions{
+					Tags: &[]string{"test-cluster", "capl", "capl-mc=test-mc", "capl-ns=default", "capl-cluster=test-cluster", "capl-uid=test-uid", "env=prod"},
+				}).Return(&linodego.Instance{ID: 123}, nil)
+			},
+		},
+		{
+			name:          "Error - UpdateInstance returns an error",
+			tags:          []string{"test-cluster"},
+			expectedTags:  []string{"test-cluster"},
+			expectedError: fmt.Errorf("failed to update instance"),
+			expects: func(mockClient *mock.MockLinodeClient) {
+				mockClient.EXPECT().UpdateInstance(gomock.Any(), 123, gomock.Any()).Return(nil, fmt.Errorf("failed to update instance"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock.NewMockLinodeClient(ctrl)
+			if testcase.expects != nil {
+				testcase.expects(mockClient)
+			}
+
+			machineScope := &scope.MachineScope{
+				LinodeClient:        mockClient,
+				ManagementClusterID: "test-mc",
+				Cluster: &v1beta1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "test-cluster",
+						Labels: testcase.clusterLabels,
+					},
+				},
+				LinodeCluster: &infrav1alpha1.LinodeCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default", UID: "test-uid"},
+				},
+			}
+			linodeInstance := &linodego.Instance{ID: 123, Tags: testcase.tags}
+
+			err := reconcileInstanceTags(context.Background(), machineScope, linodeInstance)
+			if testcase.expectedError != nil {
+				assert.ErrorContains(t, err, testcase.expectedError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, testcase.expectedTags, linodeInstance.Tags)
+		})
+	}
+}
+
+func TestSelectVPCSubnet(t *testing.T) {
+	t.Parallel()
+
+	linodes := func(n int) []linodego.VPCSubnetLinode {
+		result := make([]linodego.VPCSubnetLinode, n)
+		for i := range result {
+			result[i] = linodego.VPCSubnetLinode{ID: i + 1, Interfaces: []linodego.VPCSubnetLinodeInterface{{ID: i + 1}}}
+		}
+
+		return result
+	}
+	subnets := []linodego.VPCSubnet{
+		{ID: 3, Label: "busy", IPv4: "10.0.2.0/24", Linodes: linodes(2)},
+		{ID: 1, Label: "idle", IPv4: "10.0.0.0/24"},
+		{ID: 2, Label: "used", IPv4: "10.0.1.0/24", Linodes: linodes(1)},
+		{ID: 4, Label: "full", IPv4: "10.0.3.0/30", Linodes: linodes(1)},
+	}
+
+	tests := []struct {
+		name           string
+		subnets        []linodego.VPCSubnet
+		opts           *infrav1alpha1.MachineVPCOptions
+		expectedSubnet int
+		expectedErr    string
+	}{
+		{
+			name:           "Success - least used by default",
+			subnets:        subnets,
+			expectedSubnet: 1,
+		},
+		{
+			name:           "Success - most used skips full subnets",
+			subnets:        subnets,
+			opts:           &infrav1alpha1.MachineVPCOptions{SubnetPlacement: infrav1alpha1.SubnetPlacementMostUsed},
+			expectedSubnet: 3,
+		},
+		{
+			name:           "Success - round robin",
+			subnets:        subnets,
+			opts:           &infrav1alpha1.MachineVPCOptions{SubnetPlacement: infrav1alpha1.SubnetPlacementRoundRobin},
+			expectedSubnet: 2,
+		},
+		{
+			name:           "Success - selected by label",
+			subnets:        subnets,
+			opts:           &infrav1alpha1.MachineVPCOptions{Subnet: &infrav1alpha1.VPCSubnetSelector{Label: "busy"}},
+			expectedSubnet: 3,
+		},
+		{
+			name:           "Success - selected by ID",
+			subnets:        subnets,
+			opts:           &infrav1alpha1.MachineVPCOptions{Subnet: &infrav1alpha1.VPCSubnetSelector{ID: ptr.To(2)}},
+			expectedSubnet: 2,
+		},
+		{
+			name:        "Error - selected subnet does not exist",
+			subnets:     subnets,
+			opts:        &infrav1alpha1.MachineVPCOptions{Subnet: &infrav1alpha1.VPCSubnetSelector{Label: "missing"}},
+			expectedErr: "failed to find subnet with label missing",
+		},
+		{
+			name:        "Error - no subnets",
+			expectedErr: "failed to find subnet with available addresses",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			subnet, err := selectVPCSubnet(testcase.subnets, testcase.opts)
+			if testcase.expectedErr != "" {
+				require.ErrorContains(t, err, testcase.expectedErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expectedSubnet, subnet.ID)
+		})
+	}
+}
+
+func TestReconcileVPCIPAddress(t *testing.T) {
+	t.Parallel()
+
+	poolRef := corev1.TypedLocalObjectReference{APIGroup: ptr.To("ipam.cluster.x-k8s.io"), Kind: "InClusterIPPool", Name: "vpc-pool"}
+	claimMeta := metav1.ObjectMeta{Name: "test-machine-vpc-ipv4", Namespace: "default"}
+
+	tests := []struct {
+		name         string
+		objects      []client.Object
+		expectedAddr string
+		expectedErr  string
+	}{
+		{
+			name:        "Success - claim is created",
+			expectedErr: errIPAddressNotAllocated.Error(),
+		},
+		{
+			name:        "Success - claim is pending",
+			objects:     []client.Object{&ipamv1.IPAddressClaim{ObjectMeta: claimMeta, Spec: ipamv1.IPAddressClaimSpec{PoolRef: poolRef}}},
+			expectedErr: errIPAddressNotAllocated.Error(),
+		},
+		{
+			name: "Success - address is allocated",
+			objects: []client.Object{
+				&ipamv1.IPAddressClaim{
+					ObjectMeta: claimMeta,
+					Spec:       ipamv1.IPAddressClaimSpec{PoolRef: poolRef},
+					Status:     ipamv1.IPAddressClaimStatus{AddressRef: corev1.LocalObjectReference{Name: "vpc-pool-1"}},
+				},
+				&ipamv1.IPAddress{
+					ObjectMeta: metav1.ObjectMeta{Name: "vpc-pool-1", Namespace: "default"},
+					Spec:       ipamv1.IPAddressSpec{Address: "10.0.0.10", Prefix: 24, PoolRef: poolRef},
+				},
+			},
+			expectedAddr: "10.0.0.10",
+		},
+		{
+			name: "Error - address is not IPv4",
+			objects: []client.Object{
+				&ipamv1.IPAddressClaim{
+					ObjectMeta: claimMeta,
+					Spec:       ipamv1.IPAddressClaimSpec{PoolRef: poolRef},
+					Status:     ipamv1.IPAddressClaimStatus{AddressRef: corev1.LocalObjectReference{Name: "vpc-pool-1"}},
+				},
+				&ipamv1.IPAddress{
+					ObjectMeta: metav1.ObjectMeta{Name: "vpc-pool-1", Namespace: "default"},
+					Spec:       ipamv1.IPAddressSpec{Address: "fd00::10", Prefix: 64, PoolRef: poolRef},
+				},
+			},
+			expectedErr: "IPAddress vpc-pool-1 is not an IPv4 address",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, infrav1alpha1.AddToScheme(scheme))
+			require.NoError(t, ipamv1.AddToScheme(scheme))
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(testcase.objects...).WithStatusSubresource(&ipamv1.IPAddressClaim{}).Build()
+
+			reconciler := LinodeMachineReconciler{Client: k8sClient, Scheme: scheme}
+			machineScope := &scope.MachineScope{
+				Cluster: &v1beta1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"}},
+				LinodeMachine: &infrav1alpha1.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default", UID: "test-uid"},
+					Spec: infrav1alpha1.LinodeMachineSpec{
+						VPC: &infrav1alpha1.MachineVPCOptions{IPv4AddressPoolRef: &poolRef},
+					},
+				},
+			}
+
+			addr, err := reconciler.reconcileVPCIPAddress(context.Background(), machineScope, logr.Discard())
+			if testcase.expectedErr != "" {
+				require.ErrorContains(t, err, testcase.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, testcase.expectedAddr, addr.String())
+			}
+
+			var claim ipamv1.IPAddressClaim
+			require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "test-machine-vpc-ipv4"}, &claim))
+			assert.Equal(t, poolRef, claim.Spec.PoolRef)
+
+			require.NoError(t, reconciler.releaseVPCIPAddress(context.Background(), machineScope))
+			err = k8sClient.Get(context.Background(), client.ObjectKeyFromObject(&claim), &claim)
+			assert.True(t, apierrors.IsNotFound(err))
+		})
+	}
+}
+
+func TestAllocatePodCIDR(t *testing.T) {
+	t.Parallel()
+
+	otherMachine := func(podCIDR string) *infrav1alpha1.LinodeMachine {
+		return &infrav1alpha1.LinodeMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-machine", Namespace: "default", Labels: map[string]string{v1beta1.ClusterNameLabel: "test-cluster"}},
+			Status:     infrav1alpha1.LinodeMachineStatus{PodCIDR: podCIDR},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		podCIDRs        []string
+		maskSize        int
+		podCIDR         string
+		objects         []client.Object
+		expectedPodCIDR string
+		expectedErr     string
+	}{
+		{
+			name:            "Success - first range",
+			podCIDRs:        []string{"fd00::/56", "10.192.0.0/10"},
+			maskSize:        24,
+			expectedPodCIDR: "10.192.0.0/24",
+		},
+		{
+			name:            "Success - ranges of other machines are skipped",
+			podCIDRs:        []string{"10.192.0.0/10"},
+			maskSize:        24,
+			objects:         []client.Object{otherMachine("10.192.0.0/23")},
+			expectedPodCIDR: "10.192.2.0/24",
+		},
+		{
+			name:            "Success - already allocated",
+			podCIDRs:        []string{"10.192.0.0/10"},
+			maskSize:        24,
+			podCIDR:         "10.192.7.0/24",
+			expectedPodCIDR: "10.192.7.0/24",
+		},
+		{
+			name:            "Success - last range of the address space",
+			podCIDRs:        []string{"255.255.255.0/24"},
+			maskSize:        25,
+			objects:         []client.Object{otherMachine("255.255.255.0/25")},
+			expectedPodCIDR: "255.255.255.128/25",
+		},
+		{
+			name:        "Error - no IPv4 pod CIDR",
+			podCIDRs:    []string{"fd00::/56"},
+			maskSize:    24,
+			expectedErr: "cluster test-cluster has no IPv4 pod CIDR to allocate a /24 range from",
+		},
+		{
+			name:        "Error - pod CIDR exhausted",
+			podCIDRs:    []string{"255.255.255.0/24"},
+			maskSize:    24,
+			objects:     []client.Object{otherMachine("255.255.255.0/24")},
+			expectedErr: "no /24 range left in the pod CIDRs of cluster test-cluster",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, infrav1alpha1.AddToScheme(scheme))
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(testcase.objects...).Build()
+
+			reconciler := LinodeMachineReconciler{Client: k8sClient, Scheme: scheme}
+			machineScope := &scope.MachineScope{
+				Cluster: &v1beta1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+					Spec: v1beta1.ClusterSpec{
+						ClusterNetwork: &v1beta1.ClusterNetwork{Pods: &v1beta1.NetworkRanges{CIDRBlocks: testcase.podCIDRs}},
+					},
+				},
+				LinodeMachine: &infrav1alpha1.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default"},
+					Spec: infrav1alpha1.LinodeMachineSpec{
+						VPC: &infrav1alpha1.MachineVPCOptions{PodCIDRMaskSize: ptr.To(testcase.maskSize)},
+					},
+					Status: infrav1alpha1.LinodeMachineStatus{PodCIDR: testcase.podCIDR},
+				},
+			}
+
+			podCIDR, err := reconciler.allocatePodCIDR(context.Background(), machineScope, logr.Discard())
+			if testcase.expectedErr != "" {
+				require.ErrorContains(t, err, testcase.expectedErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expectedPodCIDR, podCIDR)
+			assert.Equal(t, testcase.expectedPodCIDR, machineScope.LinodeMachine.Status.PodCIDR)
+		})
+	}
+}
+
+func TestReleasePodCIDR(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, infrav1alpha1.AddToScheme(scheme))
+	reconciler := LinodeMachineReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build(), Scheme: scheme}
+	cluster := &v1beta1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		Spec: v1beta1.ClusterSpec{
+			ClusterNetwork: &v1beta1.ClusterNetwork{Pods: &v1beta1.NetworkRanges{CIDRBlocks: []string{"10.192.0.0/10"}}},
+		},
+	}
+	newMachineScope := func(name string) *scope.MachineScope {
+		return &scope.MachineScope{
+			Cluster: cluster,
+			LinodeMachine: &infrav1alpha1.LinodeMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: infrav1alpha1.LinodeMachineSpec{
+					VPC: &infrav1alpha1.MachineVPCOptions{PodCIDRMaskSize: ptr.To(24)},
+				},
+			},
+		}
+	}
+
+	// Allocations not in the cache yet are not handed out twice
+	first := newMachineScope("first")
+	podCIDR, err := reconciler.allocatePodCIDR(context.Background(), first, logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, "10.192.0.0/24", podCIDR)
+	podCIDR, err = reconciler.allocatePodCIDR(context.Background(), newMachineScope("second"), logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, "10.192.1.0/24", podCIDR)
+
+	reconciler.releaseAllocations(first)
+	assert.Empty(t, first.LinodeMachine.Status.PodCIDR)
+	podCIDR, err = reconciler.allocatePodCIDR(context.Background(), newMachineScope("third"), logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, "10.192.0.0/24", podCIDR)
+}
+
+func TestGetVLANInterfaceConfig(t *testing.T) {
+	t.Parallel()
+
+	otherMachine := func(vlanAddress string) *infrav1alpha1.LinodeMachine {
+		return &infrav1alpha1.LinodeMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-machine", Namespace: "default", Labels: map[string]string{v1beta1.ClusterNameLabel: "test-cluster"}},
+			Status:     infrav1alpha1.LinodeMachineStatus{VLANAddress: vlanAddress},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		cidr           string
+		vlanAddress    string
+		existingIfaces []linodego.InstanceConfigInterfaceCreateOptions
+		objects        []client.Object
+		expectedIface  *linodego.InstanceConfigInterfaceCreateOptions
+		expectedErr    string
+	}{
+		{
+			name:          "Success - first host address is allocated",
+			cidr:          "192.168.0.0/24",
+			expectedIface: &linodego.InstanceConfigInterfaceCreateOptions{Purpose: linodego.InterfacePurposeVLAN, Label: "test-vlan", IPAMAddress: "192.168.0.1/24"},
+		},
+		{
+			name:          "Success - addresses of other machines are skipped",
+			cidr:          "192.168.0.0/24",
+			objects:       []client.Object{otherMachine("192.168.0.1/24")},
+			expectedIface: &linodego.InstanceConfigInterfaceCreateOptions{Purpose: linodego.InterfacePurposeVLAN, Label: "test-vlan", IPAMAddress: "192.168.0.2/24"},
+		},
+		{
+			name:          "Success - existing address is kept",
+			cidr:          "192.168.0.0/24",
+			vlanAddress:   "192.168.0.7/24",
+			expectedIface: &linodego.InstanceConfigInterfaceCreateOptions{Purpose: linodego.InterfacePurposeVLAN, Label: "test-vlan", IPAMAddress: "192.168.0.7/24"},
+		},
+		{
+			name:           "Success - machine is already attached to the VLAN",
+			cidr:           "192.168.0.0/24",
+			existingIfaces: []linodego.InstanceConfigInterfaceCreateOptions{{Purpose: linodego.InterfacePurposeVLAN, Label: "test-vlan"}},
+		},
+		{
+			name:        "Error - broadcast address is not allocated",
+			cidr:        "192.168.0.0/30",
+			objects:     []client.Object{otherMachine("192.168.0.1/30"), otherMachine("192.168.0.2/30")},
+			expectedErr: "no address left in the CIDR of VLAN test-vlan",
+		},
+		{
+			name:        "Error - CIDR is not IPv4",
+			cidr:        "fd00::/64",
+			expectedErr: "VLAN test-vlan has no IPv4 CIDR to allocate an address from",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			// machines are listed by name, so give each one its own
+			for i, obj := range testcase.objects {
+				obj.SetName(fmt.Sprintf("other-machine-%d", i))
+			}
+			scheme := runtime.NewScheme()
+			require.NoError(t, infrav1alpha1.AddToScheme(scheme))
+			reconciler := LinodeMachineReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(testcase.objects...).Build(), Scheme: scheme}
+			machineScope := &scope.MachineScope{
+				Cluster: &v1beta1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"}},
+				LinodeCluster: &infrav1alpha1.LinodeCluster{
+					Spec: infrav1alpha1.LinodeClusterSpec{
+						Network: infrav1alpha1.NetworkSpec{VLAN: &infrav1alpha1.ClusterVLANSpec{Label: "test-vlan", CIDR: testcase.cidr}},
+					},
+				},
+				LinodeMachine: &infrav1alpha1.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default"},
+					Status:     infrav1alpha1.LinodeMachineStatus{VLANAddress: testcase.vlanAddress},
+				},
+			}
+
+			iface, err := reconciler.getVLANInterfaceConfig(context.Background(), machineScope, testcase.existingIfaces, logr.Discard())
+			if testcase.expectedErr != "" {
+				require.ErrorContains(t, err, testcase.expectedErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expectedIface, iface)
+			if iface != nil {
+				assert.Equal(t, iface.IPAMAddress, machineScope.LinodeMachine.Status.VLANAddress)
+			}
+		})
+	}
+}
@@ -248,6 +248,42 @@ var _ = Describe("create", Label("machine", "create"), func() {
 		})
 	})
 
+	It("fails immediately when the instance is rejected", func(ctx SpecContext) {
+		mockLinodeClient := mock.NewMockLinodeClient(mockCtrl)
+		listInst := mockLinodeClient.EXPECT().
+			ListInstances(ctx, gomock.Any()).
+			Return([]linodego.Instance{}, nil)
+		getRegion := mockLinodeClient.EXPECT().
+			GetRegion(ctx, gomock.Any()).
+			After(listInst).
+			Return(&linodego.Region{Capabilities: []string{"Metadata"}}, nil)
+		getImage := mockLinodeClient.EXPECT().
+			GetImage(ctx, gomock.Any()).
+			After(getRegion).
+			Return(&linodego.Image{Capabilities: []string{"cloud-init"}}, nil)
+		mockLinodeClient.EXPECT().
+			CreateInstance(ctx, gomock.Any()).
+			After(getImage).
+			Return(nil, &linodego.Error{Code: 400, Message: "[type] A valid plan type by that ID was not found"})
+
+		mScope := scope.MachineScope{
+			Client:        k8sClient,
+			LinodeClient:  mockLinodeClient,
+			Cluster:       &cluster,
+			Machine:       &machine,
+			LinodeCluster: &linodeCluster,
+			LinodeMachine: &linodeMachine,
+		}
+
+		res, err := reconciler.reconcileCreate(ctx, logger, &mScope)
+		Expect(res.RequeueAfter).To(BeZero())
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("A valid plan type by that ID was not found"))
+
+		Expect(rutil.ConditionTrue(&linodeMachine, ConditionPreflightCreated)).To(BeFalse())
+		Expect(conditions.Get(&linodeMachine, ConditionPreflightCreated).Severity).To(Equal(clusterv1.ConditionSeverityError))
+	})
+
 	Context("creates a instance with disks", func() {
 		It("in a single call when disks aren't delayed", func(ctx SpecContext) {
 			machine.Labels[clusterv1.MachineControlPlaneLabel] = "true"
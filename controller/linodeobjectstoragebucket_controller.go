@@ -24,6 +24,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/linode/linodego"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,6 +37,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -43,6 +45,7 @@ import (
 	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
 	"github.com/linode/cluster-api-provider-linode/cloud/scope"
 	"github.com/linode/cluster-api-provider-linode/cloud/services"
+	"github.com/linode/cluster-api-provider-linode/observability/tracing"
 	"github.com/linode/cluster-api-provider-linode/util"
 	"github.com/linode/cluster-api-provider-linode/util/reconciler"
 )
@@ -50,11 +53,11 @@ import (
 // LinodeObjectStorageBucketReconciler reconciles a LinodeObjectStorageBucket object
 type LinodeObjectStorageBucketReconciler struct {
 	client.Client
-	Logger           logr.Logger
-	Recorder         record.EventRecorder
-	LinodeApiKey     string
-	WatchFilterValue string
-	ReconcileTimeout time.Duration
+	Logger             logr.Logger
+	Recorder           record.EventRecorder
+	LinodeClientConfig scope.ClientConfig
+	WatchFilterValue   string
+	ReconcileTimeout   time.Duration
 }
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=linodeobjectstoragebuckets,verbs=get;list;watch;create;update;patch;delete
@@ -73,11 +76,15 @@ type LinodeObjectStorageBucketReconciler struct {
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.17.0/pkg/reconcile
-func (r *LinodeObjectStorageBucketReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *LinodeObjectStorageBucketReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
 	defer cancel()
 
+	ctx, span := tracing.Start(ctx, "LinodeObjectStorageBucketReconciler.Reconcile", attribute.String("name", req.NamespacedName.String()))
+	defer tracing.End(span, &err)
+
 	logger := r.Logger.WithValues("name", req.NamespacedName.String())
+	logger = tracing.Logger(ctx, logger)
 
 	objectStorageBucket := &infrav1alpha1.LinodeObjectStorageBucket{}
 	if err := r.Client.Get(ctx, req.NamespacedName, objectStorageBucket); err != nil {
@@ -88,9 +95,19 @@ func (r *LinodeObjectStorageBucketReconciler) Reconcile(ctx context.Context, req
 		return ctrl.Result{}, err
 	}
 
+	if paused, err := isPaused(ctx, r.Client, objectStorageBucket); err != nil {
+		logger.Error(err, "Failed to check if the LinodeObjectStorageBucket is paused")
+
+		return ctrl.Result{}, err
+	} else if paused {
+		logger.Info("LinodeObjectStorageBucket or its Cluster is paused, skipping reconcile")
+
+		return ctrl.Result{}, nil
+	}
+
 	bScope, err := scope.NewObjectStorageBucketScope(
 		ctx,
-		r.LinodeApiKey,
+		r.LinodeClientConfig,
 		scope.ObjectStorageBucketScopeParams{
 			Client: r.Client,
 			Bucket: objectStorageBucket,
@@ -102,6 +119,7 @@ func (r *LinodeObjectStorageBucketReconciler) Reconcile(ctx context.Context, req
 
 		return ctrl.Result{}, fmt.Errorf("failed to create object storage bucket scope: %w", err)
 	}
+	bScope.LinodeClient = wrapLinodeClient(bScope.LinodeClient, bScope.LinodeEndpoint, "linodeobjectstoragebucket")
 
 	return r.reconcile(ctx, bScope)
 }
@@ -131,9 +149,11 @@ func (r *LinodeObjectStorageBucketReconciler) reconcile(ctx context.Context, bSc
 	return res, nil
 }
 
-func (r *LinodeObjectStorageBucketReconciler) setFailure(bScope *scope.ObjectStorageBucketScope, err error) {
-	bScope.Bucket.Status.FailureMessage = util.Pointer(err.Error())
-	r.Recorder.Event(bScope.Bucket, corev1.EventTypeWarning, "Failed", err.Error())
+func (r *LinodeObjectStorageBucketReconciler) setFailure(ctx context.Context, bScope *scope.ObjectStorageBucketScope, err error) {
+	if util.IsTerminalError(err) {
+		bScope.Bucket.Status.FailureMessage = util.Pointer(err.Error())
+	}
+	tracing.Event(ctx, r.Recorder, bScope.Bucket, corev1.EventTypeWarning, "Failed", err.Error())
 	conditions.MarkFalse(bScope.Bucket, clusterv1.ReadyCondition, "Failed", clusterv1.ConditionSeverityError, "%s", err.Error())
 }
 
@@ -145,7 +165,7 @@ func (r *LinodeObjectStorageBucketReconciler) reconcileApply(ctx context.Context
 	bucket, err := services.EnsureObjectStorageBucket(ctx, bScope)
 	if err != nil {
 		bScope.Logger.Error(err, "Failed to ensure bucket exists")
-		r.setFailure(bScope, err)
+		r.setFailure(ctx, bScope, err)
 
 		return err
 	}
@@ -161,20 +181,20 @@ func (r *LinodeObjectStorageBucketReconciler) reconcileApply(ctx context.Context
 		newKeys, err := services.RotateObjectStorageKeys(ctx, bScope)
 		if err != nil {
 			bScope.Logger.Error(err, "Failed to provision new access keys")
-			r.setFailure(bScope, err)
+			r.setFailure(ctx, bScope, err)
 
 			return err
 		}
 		bScope.Bucket.Status.AccessKeyRefs = []int{newKeys[0].ID, newKeys[1].ID}
 		keys = newKeys
 
-		r.Recorder.Event(bScope.Bucket, corev1.EventTypeNormal, "KeysAssigned", "Object storage keys assigned")
+		tracing.Event(ctx, r.Recorder, bScope.Bucket, corev1.EventTypeNormal, "KeysAssigned", "Object storage keys assigned")
 
 	case bScope.Bucket.Status.AccessKeyRefs != nil:
 		secretDeleted, err = bScope.ShouldRestoreKeySecret(ctx)
 		if err != nil {
 			bScope.Logger.Error(err, "Failed to ensure access key secret exists")
-			r.setFailure(bScope, err)
+			r.setFailure(ctx, bScope, err)
 
 			return err
 		}
@@ -183,21 +203,21 @@ func (r *LinodeObjectStorageBucketReconciler) reconcileApply(ctx context.Context
 			sameKeys, err := services.GetObjectStorageKeys(ctx, bScope)
 			if err != nil {
 				bScope.Logger.Error(err, "Failed to restore access keys for deleted secret")
-				r.setFailure(bScope, err)
+				r.setFailure(ctx, bScope, err)
 
 				return err
 			}
 			keys = sameKeys
 		}
 
-		r.Recorder.Event(bScope.Bucket, corev1.EventTypeNormal, "KeysRetrieved", "Object storage keys retrieved")
+		tracing.Event(ctx, r.Recorder, bScope.Bucket, corev1.EventTypeNormal, "KeysRetrieved", "Object storage keys retrieved")
 	}
 
 	if keys[0] != nil && keys[1] != nil {
 		secret, err := bScope.GenerateKeySecret(ctx, keys, bucket)
 		if err != nil {
 			bScope.Logger.Error(err, "Failed to generate key secret")
-			r.setFailure(bScope, err)
+			r.setFailure(ctx, bScope, err)
 
 			return err
 		}
@@ -209,7 +229,7 @@ func (r *LinodeObjectStorageBucketReconciler) reconcileApply(ctx context.Context
 		}
 		if err != nil {
 			bScope.Logger.Error(err, "Failed to apply key secret")
-			r.setFailure(bScope, err)
+			r.setFailure(ctx, bScope, err)
 
 			return err
 		}
@@ -218,10 +238,10 @@ func (r *LinodeObjectStorageBucketReconciler) reconcileApply(ctx context.Context
 		bScope.Bucket.Status.KeySecretName = util.Pointer(secret.Name)
 		bScope.Bucket.Status.LastKeyGeneration = bScope.Bucket.Spec.KeyGeneration
 
-		r.Recorder.Event(bScope.Bucket, corev1.EventTypeNormal, "KeysStored", "Object storage keys stored in secret")
+		tracing.Event(ctx, r.Recorder, bScope.Bucket, corev1.EventTypeNormal, "KeysStored", "Object storage keys stored in secret")
 	}
 
-	r.Recorder.Event(bScope.Bucket, corev1.EventTypeNormal, "Synced", "Object storage bucket synced")
+	tracing.Event(ctx, r.Recorder, bScope.Bucket, corev1.EventTypeNormal, "Synced", "Object storage bucket synced")
 
 	bScope.Bucket.Status.Ready = true
 	conditions.MarkTrue(bScope.Bucket, clusterv1.ReadyCondition)
@@ -234,7 +254,7 @@ func (r *LinodeObjectStorageBucketReconciler) reconcileDelete(ctx context.Contex
 
 	if err := services.RevokeObjectStorageKeys(ctx, bScope); err != nil {
 		bScope.Logger.Error(err, "failed to revoke access keys; keys must be manually revoked")
-		r.setFailure(bScope, err)
+		r.setFailure(ctx, bScope, err)
 
 		return err
 	}
@@ -242,18 +262,18 @@ func (r *LinodeObjectStorageBucketReconciler) reconcileDelete(ctx context.Contex
 	if !controllerutil.RemoveFinalizer(bScope.Bucket, infrav1alpha1.GroupVersion.String()) {
 		err := errors.New("failed to remove finalizer from bucket; unable to delete")
 		bScope.Logger.Error(err, "controllerutil.RemoveFinalizer")
-		r.setFailure(bScope, err)
+		r.setFailure(ctx, bScope, err)
 
 		return err
 	}
 
-	r.Recorder.Event(bScope.Bucket, clusterv1.DeletedReason, "Revoked", "Object storage keys revoked")
+	tracing.Event(ctx, r.Recorder, bScope.Bucket, clusterv1.DeletedReason, "Revoked", "Object storage keys revoked")
 
 	return nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *LinodeObjectStorageBucketReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *LinodeObjectStorageBucketReconciler) SetupWithManager(mgr ctrl.Manager, options crcontroller.Options) error {
 	linodeObjectStorageBucketMapper, err := kutil.ClusterToTypedObjectsMapper(r.Client, &infrav1alpha1.LinodeObjectStorageBucketList{}, mgr.GetScheme())
 	if err != nil {
 		return fmt.Errorf("failed to create mapper for LinodeObjectStorageBuckets: %w", err)
@@ -261,15 +281,17 @@ func (r *LinodeObjectStorageBucketReconciler) SetupWithManager(mgr ctrl.Manager)
 
 	err = ctrl.NewControllerManagedBy(mgr).
 		For(&infrav1alpha1.LinodeObjectStorageBucket{}).
+		WithOptions(options).
 		Owns(&corev1.Secret{}).
 		WithEventFilter(predicate.And(
-			predicates.ResourceHasFilterLabel(mgr.GetLogger(), r.WatchFilterValue),
-			predicate.GenerationChangedPredicate{},
+			predicates.ResourceNotPausedAndHasFilterLabel(mgr.GetLogger(), r.WatchFilterValue),
+			// Removing the paused annotation doesn't change the generation
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}),
 		)).
 		Watches(
 			&clusterv1.Cluster{},
 			handler.EnqueueRequestsFromMapFunc(linodeObjectStorageBucketMapper),
-			builder.WithPredicates(predicates.ClusterUnpausedAndInfrastructureReady(mgr.GetLogger())),
+			builder.WithPredicates(predicates.ClusterUnpaused(mgr.GetLogger())),
 		).Complete(r)
 	if err != nil {
 		return fmt.Errorf("failed to build controller: %w", err)
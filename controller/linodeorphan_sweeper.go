@@ -0,0 +1,464 @@
+/*
+Copyright 2023 Akamai Technologies, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/linode/linodego"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/clients"
+	"github.com/linode/cluster-api-provider-linode/observability/metrics"
+	"github.com/linode/cluster-api-provider-linode/util"
+)
+
+const (
+	// DefaultOrphanSweeperInterval is the default time between two sweeps of the LinodeOrphanSweeper.
+	DefaultOrphanSweeperInterval = 10 * time.Minute
+	// DefaultOrphanSweeperGracePeriod is the default time a resource has to be orphaned before it is deleted.
+	DefaultOrphanSweeperGracePeriod = time.Hour
+
+	// orphanSweeperStatusKey is the key of the sweep status in the LinodeOrphanSweeper ConfigMap.
+	orphanSweeperStatusKey = "status.yaml"
+
+	orphanKindInstance         = "Instance"
+	orphanKindNodeBalancer     = "NodeBalancer"
+	orphanKindVPC              = "VPC"
+	orphanKindObjectStorageKey = "ObjectStorageKey"
+)
+
+// orphanKinds are the kinds of Linode resources swept by the LinodeOrphanSweeper.
+var orphanKinds = []string{orphanKindInstance, orphanKindNodeBalancer, orphanKindVPC, orphanKindObjectStorageKey}
+
+// deletableOrphanKinds are the kinds of orphans the LinodeOrphanSweeper deletes. VPCs and Object Storage keys can't be
+// tagged with the management cluster which created them, so their orphans are only reported.
+var deletableOrphanKinds = []string{orphanKindInstance, orphanKindNodeBalancer}
+
+// LinodeOrphanSweeper periodically finds the Linode resources created by CAPL which no object manages anymore, e.g.
+// because a reconcile crashed before recording the ID of a new instance or an object was force-deleted. Orphans are
+// reported in a ConfigMap and as a metric, and orphaned instances and NodeBalancers are optionally deleted once they
+// have been orphaned for GracePeriod.
+//
+// Instances and NodeBalancers are recognized by util.ManagedTag and the tag of the management cluster, VPCs by
+// util.ManagedVPCDescription and Object Storage keys by the labels and bucket access of the keys created for
+// LinodeObjectStorageBuckets. VPCs and keys may have been created by another management cluster or by hand, so they
+// are never deleted. Only the resources of the account of the default Linode API token are swept.
+type LinodeOrphanSweeper struct {
+	client.Client
+	LinodeClient clients.LinodeClient
+	// ConfigMap is the ConfigMap the orphans found by the last sweep are reported in.
+	ConfigMap client.ObjectKey
+	// Interval is the time between two sweeps.
+	Interval time.Duration
+	// GracePeriod is the time a resource has to be orphaned before it is deleted.
+	GracePeriod time.Duration
+	// Delete enables the deletion of orphaned instances and NodeBalancers.
+	Delete bool
+	// DryRun logs the orphans which would be deleted instead of deleting them.
+	DryRun bool
+	// ManagementClusterID identifies the management cluster in the tags of the Linode resources.
+	ManagementClusterID string
+	// Namespaces restricts the sweep to the resources of the LinodeClusters in these namespaces, when the manager
+	// only watches them. VPCs and Object Storage keys are not tagged with a namespace and are not swept then.
+	Namespaces []string
+
+	// apiReader reads the ConfigMap without caching all ConfigMaps of the cluster.
+	apiReader client.Reader
+	now       func() time.Time
+}
+
+// orphanSweepStatus is the content of the LinodeOrphanSweeper ConfigMap.
+type orphanSweepStatus struct {
+	// LastSweepTime is the time of the last sweep.
+	LastSweepTime metav1.Time `json:"lastSweepTime"`
+	// Orphans are the orphaned resources found by the last sweep which were not deleted.
+	Orphans []orphanedResource `json:"orphans,omitempty"`
+}
+
+// orphanedResource is a Linode resource created by CAPL which no object manages.
+type orphanedResource struct {
+	Kind  string `json:"kind"`
+	ID    int    `json:"id"`
+	Label string `json:"label"`
+	// FirstSeen is the time of the first sweep which found the resource, from which the grace period is counted.
+	FirstSeen metav1.Time `json:"firstSeen"`
+	// DeletionError is the error of the last attempt to delete the resource.
+	DeletionError string `json:"deletionError,omitempty"`
+}
+
+func (o orphanedResource) key() string {
+	return fmt.Sprintf("%s/%d", o.Kind, o.ID)
+}
+
+// SetupWithManager adds the sweeper to the Manager.
+func (s *LinodeOrphanSweeper) SetupWithManager(mgr ctrl.Manager) error {
+	s.apiReader = mgr.GetAPIReader()
+
+	if err := mgr.Add(s); err != nil {
+		return fmt.Errorf("failed to add Linode orphan sweeper: %w", err)
+	}
+
+	return nil
+}
+
+// Start sweeps every Interval until ctx is done. It implements manager.Runnable.
+func (s *LinodeOrphanSweeper) Start(ctx context.Context) error {
+	logger := ctrl.LoggerFrom(ctx).WithName("LinodeOrphanSweeper")
+
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.sweep(ctx, logger); err != nil {
+			logger.Error(err, "Failed to sweep orphaned Linode resources")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweep finds the orphaned resources, deletes the ones past the grace period if enabled and reports the others.
+func (s *LinodeOrphanSweeper) sweep(ctx context.Context, logger logr.Logger) error {
+	now := time.Now()
+	if s.now != nil {
+		now = s.now()
+	}
+
+	status, err := s.loadStatus(ctx)
+	if err != nil {
+		return err
+	}
+	firstSeen := make(map[string]metav1.Time, len(status.Orphans))
+	for _, orphan := range status.Orphans {
+		firstSeen[orphan.key()] = orphan.FirstSeen
+	}
+
+	orphans, err := s.findOrphans(ctx)
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[string]int, len(orphanKinds))
+	remaining := make([]orphanedResource, 0, len(orphans))
+	for _, orphan := range orphans {
+		orphanLogger := logger.WithValues("kind", orphan.Kind, "id", orphan.ID, "label", orphan.Label)
+
+		if seen, ok := firstSeen[orphan.key()]; ok {
+			orphan.FirstSeen = seen
+		} else {
+			orphan.FirstSeen = metav1.NewTime(now)
+			orphanLogger.Info("Found orphaned Linode resource")
+		}
+
+		if s.Delete && slices.Contains(deletableOrphanKinds, orphan.Kind) && now.Sub(orphan.FirstSeen.Time) >= s.GracePeriod {
+			if s.DryRun {
+				orphanLogger.Info("Would delete orphaned Linode resource (dry run)")
+			} else if err := s.deleteOrphan(ctx, orphan); err != nil {
+				orphanLogger.Error(err, "Failed to delete orphaned Linode resource")
+				orphan.DeletionError = err.Error()
+			} else {
+				orphanLogger.Info("Deleted orphaned Linode resource")
+				metrics.ObserveOrphanDeletion(orphan.Kind)
+
+				continue
+			}
+		}
+
+		counts[orphan.Kind]++
+		remaining = append(remaining, orphan)
+	}
+
+	for _, kind := range orphanKinds {
+		metrics.SetOrphanedResources(kind, counts[kind])
+	}
+
+	return s.saveStatus(ctx, orphanSweepStatus{LastSweepTime: metav1.NewTime(now), Orphans: remaining})
+}
+
+// findOrphans returns the orphaned resources of every kind. The objects managing a kind of resources are listed
+// before the Linode resources, so a resource created in between is reported until the next sweep finds its object.
+// The grace period is counted from the first sweep which found it and covers that window.
+func (s *LinodeOrphanSweeper) findOrphans(ctx context.Context) ([]orphanedResource, error) {
+	finders := []func(context.Context) ([]orphanedResource, error){s.orphanedInstances, s.orphanedNodeBalancers}
+	if len(s.Namespaces) == 0 {
+		finders = append(finders, s.orphanedVPCs, s.orphanedObjectStorageKeys)
+	}
+
+	var orphans []orphanedResource
+	for _, find := range finders {
+		found, err := find(ctx)
+		if err != nil {
+			return nil, err
+		}
+		orphans = append(orphans, found...)
+	}
+
+	return orphans, nil
+}
+
+// isManaged returns true if tags mark a resource created by CAPL in this management cluster, and in one of the
+// swept namespaces if they are restricted.
+func (s *LinodeOrphanSweeper) isManaged(tags []string) bool {
+	if !slices.Contains(tags, util.ManagedTag) {
+		return false
+	}
+	if len(s.Namespaces) > 0 && !slices.ContainsFunc(s.Namespaces, func(namespace string) bool {
+		return slices.Contains(tags, util.NamespaceTag(namespace))
+	}) {
+		return false
+	}
+	if s.ManagementClusterID == "" {
+		_, ok := util.TagValue(tags, util.ManagementClusterTagPrefix)
+		return !ok
+	}
+
+	return slices.Contains(tags, util.ManagementClusterTag(s.ManagementClusterID))
+}
+
+func (s *LinodeOrphanSweeper) orphanedInstances(ctx context.Context) ([]orphanedResource, error) {
+	filter, err := util.FilterEq("tags", util.ManagedTag).String()
+	if err != nil {
+		return nil, err
+	}
+	var linodeMachines infrav1alpha1.LinodeMachineList
+	if err := s.Client.List(ctx, &linodeMachines); err != nil {
+		return nil, fmt.Errorf("list LinodeMachines: %w", err)
+	}
+	ids := make(map[int]bool, len(linodeMachines.Items))
+	names := make(map[string]bool, len(linodeMachines.Items))
+	for _, linodeMachine := range linodeMachines.Items {
+		if linodeMachine.Spec.InstanceID != nil {
+			ids[*linodeMachine.Spec.InstanceID] = true
+		}
+		names[linodeMachine.Name] = true
+	}
+
+	var orphans []orphanedResource
+	err = clients.ForEach(ctx, s.LinodeClient.ListInstances, filter, func(instance linodego.Instance) error {
+		// A LinodeMachine adopts the instance labeled with its name when the instance ID was not recorded
+		if s.isManaged(instance.Tags) && !ids[instance.ID] && !names[instance.Label] {
+			orphans = append(orphans, orphanedResource{Kind: orphanKindInstance, ID: instance.ID, Label: instance.Label})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list instances: %w", err)
+	}
+
+	return orphans, nil
+}
+
+func (s *LinodeOrphanSweeper) orphanedNodeBalancers(ctx context.Context) ([]orphanedResource, error) {
+	filter, err := util.FilterEq("tags", util.ManagedTag).String()
+	if err != nil {
+		return nil, err
+	}
+	var linodeClusters infrav1alpha1.LinodeClusterList
+	if err := s.Client.List(ctx, &linodeClusters); err != nil {
+		return nil, fmt.Errorf("list LinodeClusters: %w", err)
+	}
+	ids := make(map[int]bool, len(linodeClusters.Items))
+	uids := make(map[string]bool, len(linodeClusters.Items))
+	for _, linodeCluster := range linodeClusters.Items {
+		if linodeCluster.Spec.Network.NodeBalancerID != nil {
+			ids[*linodeCluster.Spec.Network.NodeBalancerID] = true
+		}
+		uids[string(linodeCluster.UID)] = true
+	}
+
+	var orphans []orphanedResource
+	err = clients.ForEach(ctx, s.LinodeClient.ListNodeBalancers, filter, func(nodeBalancer linodego.NodeBalancer) error {
+		// A LinodeCluster adopts the NodeBalancer tagged with its UID when the NodeBalancer ID was not recorded
+		uid, _ := util.TagValue(nodeBalancer.Tags, util.ClusterUIDTagPrefix)
+		if !s.isManaged(nodeBalancer.Tags) || ids[nodeBalancer.ID] || uids[uid] {
+			return nil
+		}
+		var label string
+		if nodeBalancer.Label != nil {
+			label = *nodeBalancer.Label
+		}
+		orphans = append(orphans, orphanedResource{Kind: orphanKindNodeBalancer, ID: nodeBalancer.ID, Label: label})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list NodeBalancers: %w", err)
+	}
+
+	return orphans, nil
+}
+
+func (s *LinodeOrphanSweeper) orphanedVPCs(ctx context.Context) ([]orphanedResource, error) {
+	var linodeVPCs infrav1alpha1.LinodeVPCList
+	if err := s.Client.List(ctx, &linodeVPCs); err != nil {
+		return nil, fmt.Errorf("list LinodeVPCs: %w", err)
+	}
+	ids := make(map[int]bool, len(linodeVPCs.Items))
+	names := make(map[string]bool, len(linodeVPCs.Items))
+	for _, linodeVPC := range linodeVPCs.Items {
+		if linodeVPC.Spec.VPCID != nil {
+			ids[*linodeVPC.Spec.VPCID] = true
+		}
+		names[linodeVPC.Name] = true
+	}
+
+	var orphans []orphanedResource
+	err := clients.ForEach(ctx, s.LinodeClient.ListVPCs, "", func(vpc linodego.VPC) error {
+		// A LinodeVPC adopts the VPC labeled with its name when the VPC ID was not recorded
+		if vpc.Description == util.ManagedVPCDescription && !ids[vpc.ID] && !names[vpc.Label] {
+			orphans = append(orphans, orphanedResource{Kind: orphanKindVPC, ID: vpc.ID, Label: vpc.Label})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list VPCs: %w", err)
+	}
+
+	return orphans, nil
+}
+
+func (s *LinodeOrphanSweeper) orphanedObjectStorageKeys(ctx context.Context) ([]orphanedResource, error) {
+	var buckets infrav1alpha1.LinodeObjectStorageBucketList
+	if err := s.Client.List(ctx, &buckets); err != nil {
+		return nil, fmt.Errorf("list LinodeObjectStorageBuckets: %w", err)
+	}
+	ids := make(map[int]bool)
+	for _, bucket := range buckets.Items {
+		for _, keyID := range bucket.Status.AccessKeyRefs {
+			ids[keyID] = true
+		}
+	}
+
+	var orphans []orphanedResource
+	err := clients.ForEach(ctx, s.LinodeClient.ListObjectStorageKeys, "", func(key linodego.ObjectStorageKey) error {
+		// Keys are not adopted, a key whose ID was not recorded is replaced by a new one
+		if isManagedObjectStorageKey(key) && !ids[key.ID] {
+			orphans = append(orphans, orphanedResource{Kind: orphanKindObjectStorageKey, ID: key.ID, Label: key.Label})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list Object Storage keys: %w", err)
+	}
+
+	return orphans, nil
+}
+
+// isManagedObjectStorageKey returns true if key looks like one of the read-write and read-only keys created by
+// services.RotateObjectStorageKeys, which are limited to a single bucket and labeled after it.
+func isManagedObjectStorageKey(key linodego.ObjectStorageKey) bool {
+	if key.BucketAccess == nil || len(*key.BucketAccess) != 1 {
+		return false
+	}
+
+	access := (*key.BucketAccess)[0]
+	switch key.Label {
+	case access.BucketName + "-rw":
+		return access.Permissions == "read_write"
+	case access.BucketName + "-ro":
+		return access.Permissions == "read_only"
+	default:
+		return false
+	}
+}
+
+func (s *LinodeOrphanSweeper) deleteOrphan(ctx context.Context, orphan orphanedResource) error {
+	var err error
+	switch orphan.Kind {
+	case orphanKindInstance:
+		err = s.LinodeClient.DeleteInstance(ctx, orphan.ID)
+	case orphanKindNodeBalancer:
+		err = s.LinodeClient.DeleteNodeBalancer(ctx, orphan.ID)
+	default:
+		return fmt.Errorf("unknown kind %s", orphan.Kind)
+	}
+
+	return util.IgnoreLinodeAPIError(err, http.StatusNotFound)
+}
+
+func (s *LinodeOrphanSweeper) loadStatus(ctx context.Context) (orphanSweepStatus, error) {
+	var status orphanSweepStatus
+
+	var configMap corev1.ConfigMap
+	if err := s.apiReader.Get(ctx, s.ConfigMap, &configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return status, nil
+		}
+
+		return status, fmt.Errorf("get ConfigMap %s: %w", s.ConfigMap, err)
+	}
+
+	if err := yaml.Unmarshal([]byte(configMap.Data[orphanSweeperStatusKey]), &status); err != nil {
+		return status, fmt.Errorf("invalid %s in ConfigMap %s: %w", orphanSweeperStatusKey, s.ConfigMap, err)
+	}
+
+	return status, nil
+}
+
+func (s *LinodeOrphanSweeper) saveStatus(ctx context.Context, status orphanSweepStatus) error {
+	raw, err := yaml.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("marshal orphan sweep status: %w", err)
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.ConfigMap.Name,
+			Namespace: s.ConfigMap.Namespace,
+		},
+	}
+	data := map[string]string{orphanSweeperStatusKey: string(raw)}
+
+	if err := s.apiReader.Get(ctx, s.ConfigMap, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("get ConfigMap %s: %w", s.ConfigMap, err)
+		}
+
+		configMap.Data = data
+		if err := s.Client.Create(ctx, configMap); err != nil {
+			return fmt.Errorf("create ConfigMap %s: %w", s.ConfigMap, err)
+		}
+
+		return nil
+	}
+
+	configMap.Data = data
+	if err := s.Client.Update(ctx, configMap); err != nil {
+		return fmt.Errorf("update ConfigMap %s: %w", s.ConfigMap, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/mock"
+	"github.com/linode/cluster-api-provider-linode/util"
+)
+
+func newLinodeOrphanSweeper(t *testing.T, linodeClient *mock.MockLinodeClient) *LinodeOrphanSweeper {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, infrav1alpha1.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&infrav1alpha1.LinodeMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default"},
+			Spec:       infrav1alpha1.LinodeMachineSpec{InstanceID: util.Pointer(1)},
+		},
+		&infrav1alpha1.LinodeMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "pending-machine", Namespace: "default"},
+		},
+		&infrav1alpha1.LinodeCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+			Spec: infrav1alpha1.LinodeClusterSpec{
+				Network: infrav1alpha1.NetworkSpec{NodeBalancerID: util.Pointer(10)},
+			},
+		},
+		&infrav1alpha1.LinodeCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "pending-cluster", Namespace: "default", UID: "pending-uid"},
+		},
+		&infrav1alpha1.LinodeVPC{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-vpc", Namespace: "default"},
+			Spec:       infrav1alpha1.LinodeVPCSpec{VPCID: util.Pointer(20)},
+		},
+		&infrav1alpha1.LinodeObjectStorageBucket{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-bucket", Namespace: "default"},
+			Status:     infrav1alpha1.LinodeObjectStorageBucketStatus{AccessKeyRefs: []int{30, 31}},
+		},
+	).Build()
+
+	return &LinodeOrphanSweeper{
+		Client:       k8sClient,
+		LinodeClient: linodeClient,
+		ConfigMap:    client.ObjectKey{Namespace: "capl-system", Name: "capl-orphan-sweeper"},
+		GracePeriod:  time.Hour,
+		apiReader:    k8sClient,
+
+		ManagementClusterID: "test-mc",
+	}
+}
+
+func bucketAccess(bucketName, permissions string) *[]linodego.ObjectStorageKeyBucketAccess {
+	return &[]linodego.ObjectStorageKeyBucketAccess{{BucketName: bucketName, Permissions: permissions}}
+}
+
+func expectLinodeResources(linodeClient *mock.MockLinodeClient) {
+	linodeClient.EXPECT().ListInstances(gomock.Any(), gomock.Any()).Return([]linodego.Instance{
+		{ID: 1, Label: "test-machine", Tags: []string{util.ManagedTag, "capl-mc=test-mc"}},
+		{ID: 2, Label: "pending-machine", Tags: []string{util.ManagedTag, "capl-mc=test-mc"}},
+		{ID: 3, Label: "orphan-machine", Tags: []string{util.ManagedTag, "capl-mc=test-mc"}},
+		{ID: 4, Label: "unmanaged-machine", Tags: []string{"test-cluster"}},
+		{ID: 5, Label: "other-management-cluster-machine", Tags: []string{util.ManagedTag, "capl-mc=other-mc"}},
+		{ID: 6, Label: "untagged-management-cluster-machine", Tags: []string{util.ManagedTag}},
+	}, nil).AnyTimes()
+	linodeClient.EXPECT().ListNodeBalancers(gomock.Any(), gomock.Any()).Return([]linodego.NodeBalancer{
+		{ID: 10, Label: util.Pointer("test-cluster"), Tags: []string{util.ManagedTag, "capl-mc=test-mc", "capl-uid=test-uid"}},
+		{ID: 11, Label: util.Pointer("pending-cluster"), Tags: []string{util.ManagedTag, "capl-mc=test-mc", "capl-uid=pending-uid"}},
+		{ID: 12, Label: util.Pointer("orphan-cluster"), Tags: []string{util.ManagedTag, "capl-mc=test-mc", "capl-uid=orphan-uid"}},
+	}, nil).AnyTimes()
+	linodeClient.EXPECT().ListVPCs(gomock.Any(), gomock.Any()).Return([]linodego.VPC{
+		{ID: 20, Label: "test-vpc", Description: util.ManagedVPCDescription},
+		{ID: 21, Label: "orphan-vpc", Description: util.ManagedVPCDescription},
+		{ID: 22, Label: "unmanaged-vpc"},
+	}, nil).AnyTimes()
+	linodeClient.EXPECT().ListObjectStorageKeys(gomock.Any(), gomock.Any()).Return([]linodego.ObjectStorageKey{
+		{ID: 30, Label: "test-bucket-rw", BucketAccess: bucketAccess("test-bucket", "read_write")},
+		{ID: 31, Label: "test-bucket-ro", BucketAccess: bucketAccess("test-bucket", "read_only")},
+		{ID: 32, Label: "test-bucket-rw", BucketAccess: bucketAccess("test-bucket", "read_write")},
+		{ID: 33, Label: "unmanaged-key"},
+		{ID: 34, Label: "test-bucket-ro", BucketAccess: bucketAccess("test-bucket", "read_write")},
+	}, nil).AnyTimes()
+}
+
+func TestLinodeOrphanSweeperFindOrphans(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	linodeClient := mock.NewMockLinodeClient(mockCtrl)
+	expectLinodeResources(linodeClient)
+	sweeper := newLinodeOrphanSweeper(t, linodeClient)
+
+	orphans, err := sweeper.findOrphans(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []orphanedResource{
+		{Kind: orphanKindInstance, ID: 3, Label: "orphan-machine"},
+		{Kind: orphanKindNodeBalancer, ID: 12, Label: "orphan-cluster"},
+		{Kind: orphanKindVPC, ID: 21, Label: "orphan-vpc"},
+		{Kind: orphanKindObjectStorageKey, ID: 32, Label: "test-bucket-rw"},
+	}, orphans)
+}
+
+func TestLinodeOrphanSweeperFindOrphansInNamespaces(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	linodeClient := mock.NewMockLinodeClient(mockCtrl)
+	linodeClient.EXPECT().ListInstances(gomock.Any(), gomock.Any()).Return([]linodego.Instance{
+		{ID: 1, Label: "test-machine", Tags: []string{util.ManagedTag, "capl-mc=test-mc", "capl-ns=default"}},
+		{ID: 3, Label: "orphan-machine", Tags: []string{util.ManagedTag, "capl-mc=test-mc", "capl-ns=default"}},
+		{ID: 7, Label: "other-namespace-machine", Tags: []string{util.ManagedTag, "capl-mc=test-mc", "capl-ns=other"}},
+		{ID: 8, Label: "legacy-machine", Tags: []string{util.ManagedTag, "capl-mc=test-mc"}},
+	}, nil)
+	linodeClient.EXPECT().ListNodeBalancers(gomock.Any(), gomock.Any()).Return([]linodego.NodeBalancer{
+		{ID: 12, Label: util.Pointer("orphan-cluster"), Tags: []string{util.ManagedTag, "capl-mc=test-mc", "capl-ns=other"}},
+	}, nil)
+	sweeper := newLinodeOrphanSweeper(t, linodeClient)
+	sweeper.Namespaces = []string{"default"}
+
+	orphans, err := sweeper.findOrphans(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []orphanedResource{{Kind: orphanKindInstance, ID: 3, Label: "orphan-machine"}}, orphans)
+}
+
+func TestLinodeOrphanSweeperFindOrphansError(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	linodeClient := mock.NewMockLinodeClient(mockCtrl)
+	linodeClient.EXPECT().ListInstances(gomock.Any(), gomock.Any()).Return(nil, errors.New("server error"))
+	sweeper := newLinodeOrphanSweeper(t, linodeClient)
+
+	_, err := sweeper.findOrphans(context.Background())
+	require.ErrorContains(t, err, "server error")
+}
+
+func TestLinodeOrphanSweeperSweep(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		delete            bool
+		dryRun            bool
+		expects           func(linodeClient *mock.MockLinodeClient)
+		expectedRemaining []int
+	}{
+		{
+			name:              "report only",
+			expectedRemaining: []int{3, 12, 21, 32},
+		},
+		{
+			name:              "dry run",
+			delete:            true,
+			dryRun:            true,
+			expectedRemaining: []int{3, 12, 21, 32},
+		},
+		{
+			name:   "delete",
+			delete: true,
+			expects: func(linodeClient *mock.MockLinodeClient) {
+				linodeClient.EXPECT().DeleteInstance(gomock.Any(), 3).Return(nil)
+				linodeClient.EXPECT().DeleteNodeBalancer(gomock.Any(), 12).Return(&linodego.Error{Code: 404})
+			},
+			// VPCs and Object Storage keys are only reported
+			expectedRemaining: []int{21, 32},
+		},
+		{
+			name:   "delete error",
+			delete: true,
+			expects: func(linodeClient *mock.MockLinodeClient) {
+				linodeClient.EXPECT().DeleteInstance(gomock.Any(), 3).Return(&linodego.Error{Code: 400, Message: "Linode busy"})
+				linodeClient.EXPECT().DeleteNodeBalancer(gomock.Any(), 12).Return(nil)
+			},
+			expectedRemaining: []int{3, 21, 32},
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			linodeClient := mock.NewMockLinodeClient(mockCtrl)
+			expectLinodeResources(linodeClient)
+			sweeper := newLinodeOrphanSweeper(t, linodeClient)
+			sweeper.Delete = testcase.delete
+			sweeper.DryRun = testcase.dryRun
+
+			ctx := context.Background()
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			sweeper.now = func() time.Time { return now }
+
+			// Orphans are not deleted before the grace period has passed
+			require.NoError(t, sweeper.sweep(ctx, logr.Discard()))
+			status, err := sweeper.loadStatus(ctx)
+			require.NoError(t, err)
+			assert.Len(t, status.Orphans, 4)
+
+			now = now.Add(time.Hour)
+			if testcase.expects != nil {
+				testcase.expects(linodeClient)
+			}
+			require.NoError(t, sweeper.sweep(ctx, logr.Discard()))
+
+			status, err = sweeper.loadStatus(ctx)
+			require.NoError(t, err)
+			assert.True(t, status.LastSweepTime.Time.Equal(now))
+
+			var remaining []int
+			for _, orphan := range status.Orphans {
+				remaining = append(remaining, orphan.ID)
+				assert.True(t, orphan.FirstSeen.Time.Equal(now.Add(-time.Hour)))
+			}
+			assert.Equal(t, testcase.expectedRemaining, remaining)
+		})
+	}
+}
+
+func TestIsManagedObjectStorageKey(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isManagedObjectStorageKey(linodego.ObjectStorageKey{Label: "bucket-rw", BucketAccess: bucketAccess("bucket", "read_write")}))
+	assert.True(t, isManagedObjectStorageKey(linodego.ObjectStorageKey{Label: "bucket-ro", BucketAccess: bucketAccess("bucket", "read_only")}))
+	assert.False(t, isManagedObjectStorageKey(linodego.ObjectStorageKey{Label: "bucket-rw", BucketAccess: bucketAccess("other", "read_write")}))
+	assert.False(t, isManagedObjectStorageKey(linodego.ObjectStorageKey{Label: "bucket-rw"}))
+	assert.False(t, isManagedObjectStorageKey(linodego.ObjectStorageKey{
+		Label:        "bucket-rw",
+		BucketAccess: &[]linodego.ObjectStorageKeyBucketAccess{{BucketName: "bucket", Permissions: "read_write"}, {BucketName: "other", Permissions: "read_write"}},
+	}))
+}
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -36,6 +37,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -43,6 +45,8 @@ import (
 
 	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
 	"github.com/linode/cluster-api-provider-linode/cloud/scope"
+	"github.com/linode/cluster-api-provider-linode/feature"
+	"github.com/linode/cluster-api-provider-linode/observability/tracing"
 	"github.com/linode/cluster-api-provider-linode/util"
 	"github.com/linode/cluster-api-provider-linode/util/reconciler"
 )
@@ -50,11 +54,13 @@ import (
 // LinodeVPCReconciler reconciles a LinodeVPC object
 type LinodeVPCReconciler struct {
 	client.Client
-	Recorder         record.EventRecorder
-	LinodeApiKey     string
-	WatchFilterValue string
-	Scheme           *runtime.Scheme
-	ReconcileTimeout time.Duration
+	Recorder           record.EventRecorder
+	LinodeClientConfig scope.ClientConfig
+	WatchFilterValue   string
+	Scheme             *runtime.Scheme
+	ReconcileTimeout   time.Duration
+	// RequeueDelay is how long to wait before checking on a pending or failed operation again.
+	RequeueDelay time.Duration
 }
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=linodevpcs,verbs=get;list;watch;create;update;patch;delete
@@ -72,11 +78,15 @@ type LinodeVPCReconciler struct {
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.16.0/pkg/reconcile
-func (r *LinodeVPCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *LinodeVPCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
 	defer cancel()
 
+	ctx, span := tracing.Start(ctx, "LinodeVPCReconciler.Reconcile", attribute.String("name", req.NamespacedName.String()))
+	defer tracing.End(span, &err)
+
 	log := ctrl.LoggerFrom(ctx).WithName("LinodeVPCReconciler").WithValues("name", req.NamespacedName.String())
+	log = tracing.Logger(ctx, log)
 
 	linodeVPC := &infrav1alpha1.LinodeVPC{}
 	if err := r.Client.Get(ctx, req.NamespacedName, linodeVPC); err != nil {
@@ -87,9 +97,27 @@ func (r *LinodeVPCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
+	if paused, err := isPaused(ctx, r.Client, linodeVPC); err != nil {
+		log.Error(err, "Failed to check if the LinodeVPC is paused")
+
+		return ctrl.Result{}, err
+	} else if paused {
+		log.Info("LinodeVPC or its Cluster is paused, skipping reconcile")
+
+		return ctrl.Result{}, nil
+	}
+
+	// Existing LinodeVPCs are left as they are while the LinodeVPC feature is disabled, but are still deleted so that
+	// their finalizer does not block the deletion of their cluster
+	if !feature.Gates.Enabled(feature.LinodeVPC) && linodeVPC.DeletionTimestamp.IsZero() {
+		log.Info("LinodeVPC feature is disabled, skipping reconcile")
+
+		return ctrl.Result{}, nil
+	}
+
 	vpcScope, err := scope.NewVPCScope(
 		ctx,
-		r.LinodeApiKey,
+		r.LinodeClientConfig,
 		scope.VPCScopeParams{
 			Client:    r.Client,
 			LinodeVPC: linodeVPC,
@@ -100,6 +128,7 @@ func (r *LinodeVPCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 		return ctrl.Result{}, fmt.Errorf("failed to create VPC scope: %w", err)
 	}
+	vpcScope.LinodeClient = wrapLinodeClient(vpcScope.LinodeClient, vpcScope.LinodeEndpoint, "linodevpc")
 
 	return r.reconcile(ctx, log, vpcScope)
 }
@@ -115,18 +144,7 @@ func (r *LinodeVPCReconciler) reconcile(
 	vpcScope.LinodeVPC.Status.FailureReason = nil
 	vpcScope.LinodeVPC.Status.FailureMessage = util.Pointer("")
 
-	failureReason := infrav1alpha1.VPCStatusError("UnknownError")
-	//nolint:dupl // Code duplication is simplicity in this case.
 	defer func() {
-		if err != nil {
-			vpcScope.LinodeVPC.Status.FailureReason = util.Pointer(failureReason)
-			vpcScope.LinodeVPC.Status.FailureMessage = util.Pointer(err.Error())
-
-			conditions.MarkFalse(vpcScope.LinodeVPC, clusterv1.ReadyCondition, string(failureReason), clusterv1.ConditionSeverityError, err.Error())
-
-			r.Recorder.Event(vpcScope.LinodeVPC, corev1.EventTypeWarning, string(failureReason), err.Error())
-		}
-
 		// Always close the scope when exiting this function so we can persist any LinodeVPC changes.
 		// This ignores any resource not found errors when reconciling deletions.
 		if patchErr := vpcScope.Close(ctx); patchErr != nil && utilerrors.FilterOut(util.UnwrapError(patchErr), apierrors.IsNotFound) != nil {
@@ -138,9 +156,10 @@ func (r *LinodeVPCReconciler) reconcile(
 
 	// Delete
 	if !vpcScope.LinodeVPC.ObjectMeta.DeletionTimestamp.IsZero() {
-		failureReason = infrav1alpha1.DeleteVPCError
-
 		res, err = r.reconcileDelete(ctx, logger, vpcScope)
+		if err != nil {
+			r.setFailureReason(ctx, vpcScope, infrav1alpha1.DeleteVPCError, err)
+		}
 
 		return
 	}
@@ -149,50 +168,66 @@ func (r *LinodeVPCReconciler) reconcile(
 	err = vpcScope.AddFinalizer(ctx)
 	if err != nil {
 		logger.Error(err, "Failed to add finalizer")
+		r.setFailureReason(ctx, vpcScope, infrav1alpha1.CreateVPCError, err)
 
 		return
 	}
 
 	// Update
 	if vpcScope.LinodeVPC.Spec.VPCID != nil {
-		failureReason = infrav1alpha1.UpdateVPCError
-
 		logger = logger.WithValues("vpcID", *vpcScope.LinodeVPC.Spec.VPCID)
 
 		err = r.reconcileUpdate(ctx, logger, vpcScope)
 		if err != nil && !reconciler.HasConditionSeverity(vpcScope.LinodeVPC, clusterv1.ReadyCondition, clusterv1.ConditionSeverityError) {
 			logger.Info("re-queuing VPC update")
 
-			res = ctrl.Result{RequeueAfter: reconciler.DefaultVPCControllerReconcileDelay}
+			res = ctrl.Result{RequeueAfter: r.requeueDelay()}
 			err = nil
+		} else if err == nil {
+			// Refresh the subnets in the status
+			res = ctrl.Result{RequeueAfter: reconciler.DefaultVPCControllerRefreshInterval}
 		}
 
 		return
 	}
 
 	// Create
-	failureReason = infrav1alpha1.CreateVPCError
-
 	err = r.reconcileCreate(ctx, logger, vpcScope)
 	if err != nil && !reconciler.HasConditionSeverity(vpcScope.LinodeVPC, clusterv1.ReadyCondition, clusterv1.ConditionSeverityError) {
 		logger.Info("re-queuing VPC creation")
 
-		res = ctrl.Result{RequeueAfter: reconciler.DefaultVPCControllerReconcileDelay}
+		res = ctrl.Result{RequeueAfter: r.requeueDelay()}
 		err = nil
+	} else if err == nil {
+		// Refresh the subnets in the status
+		res = ctrl.Result{RequeueAfter: reconciler.DefaultVPCControllerRefreshInterval}
 	}
 
 	return
 }
 
+// setFailureReason records err on the Ready condition. Transient errors decay into a failure once the reconcile
+// timeout passes, while terminal errors fail the LinodeVPC immediately.
+func (r *LinodeVPCReconciler) setFailureReason(ctx context.Context, vpcScope *scope.VPCScope, failureReason infrav1alpha1.VPCStatusError, err error) {
+	if util.IsTerminalError(err) {
+		vpcScope.LinodeVPC.Status.FailureReason = util.Pointer(failureReason)
+		vpcScope.LinodeVPC.Status.FailureMessage = util.Pointer(err.Error())
+
+		conditions.MarkFalse(vpcScope.LinodeVPC, clusterv1.ReadyCondition, string(failureReason), clusterv1.ConditionSeverityError, "%s", err.Error())
+	} else {
+		reconciler.RecordDecayingCondition(vpcScope.LinodeVPC, clusterv1.ReadyCondition, string(failureReason), err.Error(), reconciler.DefaultTimeout(r.ReconcileTimeout, reconciler.DefaultVPCControllerReconcileTimeout))
+	}
+
+	tracing.Event(ctx, r.Recorder, vpcScope.LinodeVPC, corev1.EventTypeWarning, string(failureReason), err.Error())
+}
+
 func (r *LinodeVPCReconciler) reconcileCreate(ctx context.Context, logger logr.Logger, vpcScope *scope.VPCScope) error {
 	logger.Info("creating vpc")
 
 	if err := vpcScope.AddCredentialsRefFinalizer(ctx); err != nil {
 		logger.Error(err, "Failed to update credentials secret")
 
-		reconciler.RecordDecayingCondition(vpcScope.LinodeVPC, clusterv1.ReadyCondition, string(infrav1alpha1.CreateVPCError), err.Error(), reconciler.DefaultTimeout(r.ReconcileTimeout, reconciler.DefaultVPCControllerReconcileTimeout))
-
-		r.Recorder.Event(vpcScope.LinodeVPC, corev1.EventTypeWarning, string(infrav1alpha1.CreateVPCError), err.Error())
+		r.setFailureReason(ctx, vpcScope, infrav1alpha1.CreateVPCError, err)
 
 		return err
 	}
@@ -200,16 +235,14 @@ func (r *LinodeVPCReconciler) reconcileCreate(ctx context.Context, logger logr.L
 	if err := r.reconcileVPC(ctx, vpcScope, logger); err != nil {
 		logger.Error(err, "Failed to create VPC")
 
-		reconciler.RecordDecayingCondition(vpcScope.LinodeVPC, clusterv1.ReadyCondition, string(infrav1alpha1.CreateVPCError), err.Error(), reconciler.DefaultTimeout(r.ReconcileTimeout, reconciler.DefaultVPCControllerReconcileTimeout))
-
-		r.Recorder.Event(vpcScope.LinodeVPC, corev1.EventTypeWarning, string(infrav1alpha1.CreateVPCError), err.Error())
+		r.setFailureReason(ctx, vpcScope, infrav1alpha1.CreateVPCError, err)
 
 		return err
 	}
 	vpcScope.LinodeVPC.Status.Ready = true
 
 	if vpcScope.LinodeVPC.Spec.VPCID != nil {
-		r.Recorder.Event(vpcScope.LinodeVPC, corev1.EventTypeNormal, "Created", fmt.Sprintf("Created VPC %d", *vpcScope.LinodeVPC.Spec.VPCID))
+		tracing.Event(ctx, r.Recorder, vpcScope.LinodeVPC, corev1.EventTypeNormal, "Created", fmt.Sprintf("Created VPC %d", *vpcScope.LinodeVPC.Spec.VPCID))
 	}
 
 	return nil
@@ -221,9 +254,7 @@ func (r *LinodeVPCReconciler) reconcileUpdate(ctx context.Context, logger logr.L
 	if err := r.reconcileVPC(ctx, vpcScope, logger); err != nil {
 		logger.Error(err, "Failed to update VPC")
 
-		reconciler.RecordDecayingCondition(vpcScope.LinodeVPC, clusterv1.ReadyCondition, string(infrav1alpha1.UpdateVPCError), err.Error(), reconciler.DefaultTimeout(r.ReconcileTimeout, reconciler.DefaultVPCControllerReconcileTimeout))
-
-		r.Recorder.Event(vpcScope.LinodeVPC, corev1.EventTypeWarning, string(infrav1alpha1.UpdateVPCError), err.Error())
+		r.setFailureReason(ctx, vpcScope, infrav1alpha1.UpdateVPCError, err)
 
 		return err
 	}
@@ -236,7 +267,23 @@ func (r *LinodeVPCReconciler) reconcileUpdate(ctx context.Context, logger logr.L
 func (r *LinodeVPCReconciler) reconcileDelete(ctx context.Context, logger logr.Logger, vpcScope *scope.VPCScope) (ctrl.Result, error) {
 	logger.Info("deleting VPC")
 
-	if vpcScope.LinodeVPC.Spec.VPCID != nil {
+	if vpcScope.LinodeVPC.Spec.VPCID != nil && retainsVPC(vpcScope.LinodeVPC) {
+		logger.Info("VPC is retained, leaving it in place")
+
+		if err := r.releaseVPC(ctx, vpcScope); err != nil {
+			logger.Error(err, "Failed to release VPC")
+
+			if vpcScope.LinodeVPC.ObjectMeta.DeletionTimestamp.Add(reconciler.DefaultTimeout(r.ReconcileTimeout, reconciler.DefaultVPCControllerReconcileTimeout)).After(time.Now()) {
+				logger.Info("re-queuing VPC deletion")
+
+				return ctrl.Result{RequeueAfter: r.requeueDelay()}, nil
+			}
+
+			return ctrl.Result{}, err
+		}
+
+		tracing.Event(ctx, r.Recorder, vpcScope.LinodeVPC, corev1.EventTypeNormal, "Retained", fmt.Sprintf("Retained VPC %d", *vpcScope.LinodeVPC.Spec.VPCID))
+	} else if vpcScope.LinodeVPC.Spec.VPCID != nil {
 		vpc, err := vpcScope.LinodeClient.GetVPC(ctx, *vpcScope.LinodeVPC.Spec.VPCID)
 		if util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
 			logger.Error(err, "Failed to fetch VPC")
@@ -244,29 +291,33 @@ func (r *LinodeVPCReconciler) reconcileDelete(ctx context.Context, logger logr.L
 			if vpcScope.LinodeVPC.ObjectMeta.DeletionTimestamp.Add(reconciler.DefaultTimeout(r.ReconcileTimeout, reconciler.DefaultVPCControllerReconcileTimeout)).After(time.Now()) {
 				logger.Info("re-queuing VPC deletion")
 
-				return ctrl.Result{RequeueAfter: reconciler.DefaultVPCControllerReconcileDelay}, nil
+				return ctrl.Result{RequeueAfter: r.requeueDelay()}, nil
 			}
 
 			return ctrl.Result{}, err
 		}
 
 		if vpc != nil {
-			for i := range vpc.Subnets {
-				if len(vpc.Subnets[i].Linodes) == 0 {
-					continue
-				}
+			vpcScope.LinodeVPC.Status.Subnets = vpcSubnetStatuses(vpc.Subnets)
 
-				logger.Info("VPC subnets still has node(s) attached")
+			var linodeIDs []int
+			for i := range vpc.Subnets {
+				linodeIDs = append(linodeIDs, attachedLinodeIDs(vpc.Subnets[i])...)
+			}
+			if len(linodeIDs) != 0 {
+				logger.Info("VPC subnets still has node(s) attached", "linodeIDs", linodeIDs)
 
 				if vpc.Updated.Add(reconciler.DefaultTimeout(r.ReconcileTimeout, reconciler.DefaultVPCControllerWaitForHasNodesTimeout)).After(time.Now()) {
 					logger.Info("VPC has node(s) attached, re-queuing VPC deletion")
 
-					return ctrl.Result{RequeueAfter: reconciler.DefaultVPCControllerReconcileDelay}, nil
+					conditions.MarkFalse(vpcScope.LinodeVPC, clusterv1.ReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityWarning, "waiting for node(s) to be detached: %s", formatIDs(linodeIDs))
+
+					return ctrl.Result{RequeueAfter: r.requeueDelay()}, nil
 				}
 
-				conditions.MarkFalse(vpcScope.LinodeVPC, clusterv1.ReadyCondition, clusterv1.DeletionFailedReason, clusterv1.ConditionSeverityError, "skipped due to node(s) attached")
+				conditions.MarkFalse(vpcScope.LinodeVPC, clusterv1.ReadyCondition, clusterv1.DeletionFailedReason, clusterv1.ConditionSeverityError, "skipped due to node(s) attached: %s", formatIDs(linodeIDs))
 
-				return ctrl.Result{}, errors.New("will not delete VPC with node(s) attached")
+				return ctrl.Result{}, fmt.Errorf("will not delete VPC with node(s) attached: %s", formatIDs(linodeIDs))
 			}
 
 			err = vpcScope.LinodeClient.DeleteVPC(ctx, *vpcScope.LinodeVPC.Spec.VPCID)
@@ -276,7 +327,7 @@ func (r *LinodeVPCReconciler) reconcileDelete(ctx context.Context, logger logr.L
 				if vpcScope.LinodeVPC.ObjectMeta.DeletionTimestamp.Add(reconciler.DefaultTimeout(r.ReconcileTimeout, reconciler.DefaultVPCControllerReconcileTimeout)).After(time.Now()) {
 					logger.Info("re-queuing VPC deletion")
 
-					return ctrl.Result{RequeueAfter: reconciler.DefaultVPCControllerReconcileDelay}, nil
+					return ctrl.Result{RequeueAfter: r.requeueDelay()}, nil
 				}
 
 				return ctrl.Result{}, err
@@ -288,7 +339,7 @@ func (r *LinodeVPCReconciler) reconcileDelete(ctx context.Context, logger logr.L
 
 	conditions.MarkFalse(vpcScope.LinodeVPC, clusterv1.ReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "VPC deleted")
 
-	r.Recorder.Event(vpcScope.LinodeVPC, corev1.EventTypeNormal, clusterv1.DeletedReason, "VPC has cleaned up")
+	tracing.Event(ctx, r.Recorder, vpcScope.LinodeVPC, corev1.EventTypeNormal, clusterv1.DeletedReason, "VPC has cleaned up")
 
 	vpcScope.LinodeVPC.Spec.VPCID = nil
 
@@ -298,7 +349,7 @@ func (r *LinodeVPCReconciler) reconcileDelete(ctx context.Context, logger logr.L
 		if vpcScope.LinodeVPC.ObjectMeta.DeletionTimestamp.Add(reconciler.DefaultTimeout(r.ReconcileTimeout, reconciler.DefaultVPCControllerReconcileTimeout)).After(time.Now()) {
 			logger.Info("re-queuing VPC deletion")
 
-			return ctrl.Result{RequeueAfter: reconciler.DefaultVPCControllerReconcileDelay}, nil
+			return ctrl.Result{RequeueAfter: r.requeueDelay()}, nil
 		}
 
 		return ctrl.Result{}, err
@@ -310,7 +361,7 @@ func (r *LinodeVPCReconciler) reconcileDelete(ctx context.Context, logger logr.L
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *LinodeVPCReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *LinodeVPCReconciler) SetupWithManager(mgr ctrl.Manager, options crcontroller.Options) error {
 	linodeVPCMapper, err := kutil.ClusterToTypedObjectsMapper(r.Client, &infrav1alpha1.LinodeVPCList{}, mgr.GetScheme())
 	if err != nil {
 		return fmt.Errorf("failed to create mapper for LinodeVPCs: %w", err)
@@ -318,6 +369,7 @@ func (r *LinodeVPCReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	err = ctrl.NewControllerManagedBy(mgr).
 		For(&infrav1alpha1.LinodeVPC{}).
+		WithOptions(options).
 		WithEventFilter(
 			predicate.And(
 				// Filter for objects with a specific WatchLabel.
@@ -330,7 +382,8 @@ func (r *LinodeVPCReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			)).Watches(
 		&clusterv1.Cluster{},
 		handler.EnqueueRequestsFromMapFunc(linodeVPCMapper),
-		builder.WithPredicates(predicates.ClusterUnpausedAndInfrastructureReady(mgr.GetLogger())),
+		// VPCs are needed before the infrastructure is ready, so they are only requeued when the Cluster is unpaused
+		builder.WithPredicates(predicates.ClusterUnpaused(mgr.GetLogger())),
 	).Complete(r)
 	if err != nil {
 		return fmt.Errorf("failed to build controller: %w", err)
@@ -338,3 +391,7 @@ func (r *LinodeVPCReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	return nil
 }
+
+func (r *LinodeVPCReconciler) requeueDelay() time.Duration {
+	return reconciler.DefaultTimeout(r.RequeueDelay, reconciler.DefaultVPCControllerReconcileDelay)
+}
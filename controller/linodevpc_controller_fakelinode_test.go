@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/cloud/scope"
+	"github.com/linode/cluster-api-provider-linode/mock/fakelinode"
+)
+
+// TestLinodeVPCReconcilerFakeLinode runs the LinodeVPC controller end to end against the fake Linode API, which it
+// reaches through the configurable API URL.
+func TestLinodeVPCReconcilerFakeLinode(t *testing.T) {
+	t.Parallel()
+
+	fakeAPI := fakelinode.New()
+	srv := httptest.NewServer(fakeAPI)
+	t.Cleanup(srv.Close)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	require.NoError(t, infrav1alpha1.AddToScheme(scheme))
+
+	linodeVPC := &infrav1alpha1.LinodeVPC{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-vpc", Namespace: "default"},
+		Spec: infrav1alpha1.LinodeVPCSpec{
+			Region:  "us-east",
+			Subnets: []infrav1alpha1.VPCSubnetCreateOptions{{Label: "default", IPv4: "10.0.0.0/8"}},
+		},
+	}
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(linodeVPC).
+		WithStatusSubresource(&infrav1alpha1.LinodeVPC{}).
+		Build()
+
+	reconciler := LinodeVPCReconciler{
+		Client:             k8sClient,
+		Recorder:           record.NewFakeRecorder(10),
+		LinodeClientConfig: scope.ClientConfig{Token: "fake", BaseURL: srv.URL},
+		Scheme:             scheme,
+	}
+	ctx := context.Background()
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(linodeVPC)}
+
+	// Create the VPC
+	_, err := reconciler.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, k8sClient.Get(ctx, request.NamespacedName, linodeVPC))
+	require.NotNil(t, linodeVPC.Spec.VPCID)
+	assert.True(t, linodeVPC.Status.Ready)
+
+	vpcs := fakeAPI.VPCs()
+	require.Len(t, vpcs, 1)
+	assert.Equal(t, *linodeVPC.Spec.VPCID, vpcs[0].ID)
+	assert.Equal(t, "us-east", vpcs[0].Region)
+	require.Len(t, vpcs[0].Subnets, 1)
+	assert.Equal(t, "10.0.0.0/8", vpcs[0].Subnets[0].IPv4)
+
+	// Refresh the subnets of the existing VPC
+	_, err = reconciler.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, k8sClient.Get(ctx, request.NamespacedName, linodeVPC))
+	require.Len(t, linodeVPC.Status.Subnets, 1)
+	assert.Equal(t, vpcs[0].Subnets[0].ID, linodeVPC.Status.Subnets[0].ID)
+
+	// Delete the VPC
+	require.NoError(t, k8sClient.Delete(ctx, linodeVPC))
+	_, err = reconciler.Reconcile(ctx, request)
+	require.NoError(t, err)
+	assert.Empty(t, fakeAPI.VPCs())
+	assert.True(t, apierrors.IsNotFound(k8sClient.Get(ctx, request.NamespacedName, linodeVPC)))
+}
@@ -21,15 +21,28 @@ import (
 	"context"
 	"encoding/gob"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/linode/linodego"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/clients"
 	"github.com/linode/cluster-api-provider-linode/cloud/scope"
+	"github.com/linode/cluster-api-provider-linode/observability/tracing"
 	"github.com/linode/cluster-api-provider-linode/util"
 )
 
+// reservedSubnetAddresses is the number of addresses of a VPC subnet which cannot be assigned to Linodes.
+const reservedSubnetAddresses = 3
+
 func (r *LinodeVPCReconciler) reconcileVPC(ctx context.Context, vpcScope *scope.VPCScope, logger logr.Logger) error {
 	createConfig := linodeVPCSpecToVPCCreateConfig(vpcScope.LinodeVPC.Spec)
 	if createConfig == nil {
@@ -40,7 +53,9 @@ func (r *LinodeVPCReconciler) reconcileVPC(ctx context.Context, vpcScope *scope.
 		return err
 	}
 
-	createConfig.Label = vpcScope.LinodeVPC.Name
+	if createConfig.Label == "" {
+		createConfig.Label = vpcScope.LinodeVPC.Name
+	}
 	listFilter := util.Filter{
 		ID:    vpcScope.LinodeVPC.Spec.VPCID,
 		Label: createConfig.Label,
@@ -50,15 +65,42 @@ func (r *LinodeVPCReconciler) reconcileVPC(ctx context.Context, vpcScope *scope.
 	if err != nil {
 		return err
 	}
-	if vpcs, err := vpcScope.LinodeClient.ListVPCs(ctx, linodego.NewListOptions(1, filter)); err != nil {
+	// Labels are unique
+	if vpc, err := clients.FindUnique(ctx, vpcScope.LinodeClient.ListVPCs, filter, func(vpc linodego.VPC) int { return vpc.ID }); err != nil {
 		logger.Error(err, "Failed to list VPCs")
 
 		return err
-	} else if len(vpcs) != 0 {
-		// Labels are unique
-		vpcScope.LinodeVPC.Spec.VPCID = &vpcs[0].ID
+	} else if vpc == nil && vpcScope.LinodeVPC.Spec.VPCID != nil {
+		// The VPC was deleted out of band or never existed, creating a new one would silently replace it
+		err := fmt.Errorf("VPC %d not found", *vpcScope.LinodeVPC.Spec.VPCID)
+		logger.Error(err, "Failed to get VPC")
 
-		return nil
+		return err
+	} else if vpc != nil {
+		// The inline VPC of a LinodeCluster is always created, adopting a VPC by label would delete it with the cluster
+		if vpcScope.LinodeVPC.Spec.VPCID == nil && isClusterVPC(vpcScope.LinodeVPC) {
+			err := fmt.Errorf("VPC %d already has the label %s of the inline VPC of a LinodeCluster, refusing to adopt it", vpc.ID, vpc.Label)
+			logger.Error(err, "Failed to adopt VPC")
+
+			return err
+		}
+		// An adopted VPC must be in the region of the LinodeVPC, since its subnets are used by Linodes in that region
+		if vpc.Region != vpcScope.LinodeVPC.Spec.Region {
+			err := fmt.Errorf("VPC %d is in region %s, not %s", vpc.ID, vpc.Region, vpcScope.LinodeVPC.Spec.Region)
+			logger.Error(err, "Failed to adopt VPC")
+
+			return err
+		}
+		vpcScope.LinodeVPC.Spec.VPCID = &vpc.ID
+
+		err := r.reconcileVPCSubnets(ctx, vpcScope, vpc, logger)
+		vpcScope.LinodeVPC.Status.Subnets = vpcSubnetStatuses(vpc.Subnets)
+
+		return err
+	}
+
+	if createConfig.Description == "" {
+		createConfig.Description = util.ManagedVPCDescription
 	}
 
 	vpc, err := vpcScope.LinodeClient.CreateVPC(ctx, *createConfig)
@@ -75,10 +117,184 @@ func (r *LinodeVPCReconciler) reconcileVPC(ctx context.Context, vpcScope *scope.
 	}
 
 	vpcScope.LinodeVPC.Spec.VPCID = &vpc.ID
+	vpcScope.LinodeVPC.Status.Subnets = vpcSubnetStatuses(vpc.Subnets)
+	vpcScope.LinodeVPC.Status.Created = true
+	vpcScope.LinodeVPC.Status.CreatedSubnetIDs = make([]int, 0, len(vpc.Subnets))
+	for _, subnet := range vpc.Subnets {
+		vpcScope.LinodeVPC.Status.CreatedSubnetIDs = append(vpcScope.LinodeVPC.Status.CreatedSubnetIDs, subnet.ID)
+	}
 
 	return nil
 }
 
+// isClusterVPC returns true if linodeVPC is the LinodeVPC of the inline VPC of a LinodeCluster.
+func isClusterVPC(linodeVPC *infrav1alpha1.LinodeVPC) bool {
+	owner := metav1.GetControllerOf(linodeVPC)
+
+	return owner != nil && owner.Kind == "LinodeCluster" && strings.HasPrefix(owner.APIVersion, infrav1alpha1.GroupVersion.Group+"/")
+}
+
+// retainsVPC returns true if the VPC of linodeVPC is left in place when the LinodeVPC is deleted. Without a deletion
+// policy, only the VPCs created by CAPL are deleted.
+func retainsVPC(linodeVPC *infrav1alpha1.LinodeVPC) bool {
+	switch linodeVPC.Spec.DeletionPolicy {
+	case infrav1alpha1.VPCDeletionPolicyRetain:
+		return true
+	case infrav1alpha1.VPCDeletionPolicyDelete:
+		return false
+	default:
+		return !linodeVPC.Status.Created
+	}
+}
+
+// releaseVPC replaces util.ManagedVPCDescription on a VPC retained after its LinodeVPC is deleted, so that the VPC is
+// no longer reported as an orphan. VPCs with another description are left untouched.
+func (r *LinodeVPCReconciler) releaseVPC(ctx context.Context, vpcScope *scope.VPCScope) error {
+	vpc, err := vpcScope.LinodeClient.GetVPC(ctx, *vpcScope.LinodeVPC.Spec.VPCID)
+	if err != nil {
+		return util.IgnoreLinodeAPIError(err, http.StatusNotFound)
+	}
+	if vpc.Description != util.ManagedVPCDescription {
+		return nil
+	}
+
+	_, err = vpcScope.LinodeClient.UpdateVPC(ctx, vpc.ID, linodego.VPCUpdateOptions{Description: util.RetainedVPCDescription})
+
+	return util.IgnoreLinodeAPIError(err, http.StatusNotFound)
+}
+
+// reconcileVPCSubnets deletes the subnets of vpc removed from the LinodeVPC, then creates the subnets of the LinodeVPC
+// missing from vpc, and updates the subnets of vpc accordingly. Subnets are matched by label. Only the subnets created
+// by CAPL are deleted, and never the ones of a retained VPC or with Linodes attached, so the subnets of an adopted VPC
+// are left in place. The subnets are left alone when the LinodeVPC has none.
+func (r *LinodeVPCReconciler) reconcileVPCSubnets(ctx context.Context, vpcScope *scope.VPCScope, vpc *linodego.VPC, logger logr.Logger) error {
+	if len(vpcScope.LinodeVPC.Spec.Subnets) == 0 {
+		return nil
+	}
+
+	desired := make(map[string]bool, len(vpcScope.LinodeVPC.Spec.Subnets))
+	for _, subnet := range vpcScope.LinodeVPC.Spec.Subnets {
+		desired[subnet.Label] = true
+	}
+
+	status := &vpcScope.LinodeVPC.Status
+	retained := retainsVPC(vpcScope.LinodeVPC)
+
+	var errs []error
+	existing := make(map[string]bool, len(vpc.Subnets))
+	subnets := make([]linodego.VPCSubnet, 0, len(vpc.Subnets))
+	for _, subnet := range vpc.Subnets {
+		if desired[subnet.Label] {
+			existing[subnet.Label] = true
+			subnets = append(subnets, subnet)
+
+			continue
+		}
+		if retained || !slices.Contains(status.CreatedSubnetIDs, subnet.ID) {
+			subnets = append(subnets, subnet)
+
+			continue
+		}
+		if len(subnet.Linodes) != 0 {
+			err := fmt.Errorf("will not delete subnet %s with node(s) attached: %s", subnet.Label, formatIDs(attachedLinodeIDs(subnet)))
+			logger.Error(err, "Failed to delete VPC subnet", "subnetID", subnet.ID)
+			errs = append(errs, err)
+			subnets = append(subnets, subnet)
+
+			continue
+		}
+		if err := vpcScope.LinodeClient.DeleteVPCSubnet(ctx, vpc.ID, subnet.ID); util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
+			logger.Error(err, "Failed to delete VPC subnet", "subnetID", subnet.ID)
+			errs = append(errs, fmt.Errorf("delete subnet %s: %w", subnet.Label, err))
+			subnets = append(subnets, subnet)
+
+			continue
+		}
+		logger.Info("deleted VPC subnet", "subnet", subnet.Label, "subnetID", subnet.ID)
+		tracing.Event(ctx, r.Recorder, vpcScope.LinodeVPC, corev1.EventTypeNormal, "SubnetDeleted", fmt.Sprintf("Deleted subnet %s", subnet.Label))
+	}
+
+	for _, subnet := range vpcScope.LinodeVPC.Spec.Subnets {
+		if existing[subnet.Label] {
+			continue
+		}
+		created, err := vpcScope.LinodeClient.CreateVPCSubnet(ctx, linodego.VPCSubnetCreateOptions{Label: subnet.Label, IPv4: subnet.IPv4}, vpc.ID)
+		if err != nil {
+			logger.Error(err, "Failed to create VPC subnet", "subnet", subnet.Label)
+			errs = append(errs, fmt.Errorf("create subnet %s: %w", subnet.Label, err))
+
+			continue
+		}
+		logger.Info("created VPC subnet", "subnet", subnet.Label, "subnetID", created.ID)
+		tracing.Event(ctx, r.Recorder, vpcScope.LinodeVPC, corev1.EventTypeNormal, "SubnetCreated", fmt.Sprintf("Created subnet %s", subnet.Label))
+		subnets = append(subnets, *created)
+		status.CreatedSubnetIDs = append(status.CreatedSubnetIDs, created.ID)
+	}
+	vpc.Subnets = subnets
+
+	// Forget the subnets which are gone from the VPC
+	status.CreatedSubnetIDs = slices.DeleteFunc(status.CreatedSubnetIDs, func(id int) bool {
+		return !slices.ContainsFunc(subnets, func(subnet linodego.VPCSubnet) bool { return subnet.ID == id })
+	})
+
+	return errors.Join(errs...)
+}
+
+// vpcSubnetStatuses returns the status of the given subnets.
+func vpcSubnetStatuses(subnets []linodego.VPCSubnet) []infrav1alpha1.VPCSubnetStatus {
+	statuses := make([]infrav1alpha1.VPCSubnetStatus, 0, len(subnets))
+	for _, subnet := range subnets {
+		statuses = append(statuses, infrav1alpha1.VPCSubnetStatus{
+			Label:                  subnet.Label,
+			ID:                     subnet.ID,
+			IPv4:                   subnet.IPv4,
+			LinodeIDs:              attachedLinodeIDs(subnet),
+			AvailableIPv4Addresses: availableIPv4Addresses(subnet),
+		})
+	}
+
+	return statuses
+}
+
+// attachedLinodeIDs returns the IDs of the Linodes attached to subnet.
+func attachedLinodeIDs(subnet linodego.VPCSubnet) []int {
+	if len(subnet.Linodes) == 0 {
+		return nil
+	}
+	ids := make([]int, 0, len(subnet.Linodes))
+	for _, linode := range subnet.Linodes {
+		ids = append(ids, linode.ID)
+	}
+
+	return ids
+}
+
+// availableIPv4Addresses returns the number of addresses of subnet which can still be assigned to Linode interfaces.
+// The network, gateway and broadcast addresses of a subnet are reserved, and every interface attached to the subnet
+// uses one address.
+func availableIPv4Addresses(subnet linodego.VPCSubnet) int {
+	prefix, err := netip.ParsePrefix(subnet.IPv4)
+	if err != nil || !prefix.Addr().Is4() {
+		return 0
+	}
+	available := 1<<(32-prefix.Bits()) - reservedSubnetAddresses
+	for _, linode := range subnet.Linodes {
+		available -= len(linode.Interfaces)
+	}
+
+	return max(available, 0)
+}
+
+// formatIDs returns the given IDs as a comma-separated list.
+func formatIDs(ids []int) string {
+	formatted := make([]string, len(ids))
+	for i, id := range ids {
+		formatted[i] = strconv.Itoa(id)
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
 func linodeVPCSpecToVPCCreateConfig(vpcSpec infrav1alpha1.LinodeVPCSpec) *linodego.VPCCreateOptions {
 	var buf bytes.Buffer
 	enc := gob.NewEncoder(&buf)
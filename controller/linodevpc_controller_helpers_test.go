@@ -2,13 +2,26 @@ package controller
 
 import (
 	"bytes"
+	"context"
 	"encoding/gob"
+	"errors"
 	"testing"
 
+	"github.com/go-logr/logr"
+	"github.com/linode/linodego"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 
 	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+	"github.com/linode/cluster-api-provider-linode/cloud/scope"
+	"github.com/linode/cluster-api-provider-linode/mock"
+	"github.com/linode/cluster-api-provider-linode/util"
 )
 
 func TestLinodeVPCSpecToCreateVPCConfig(t *testing.T) {
@@ -40,3 +53,400 @@ func TestLinodeVPCSpecToCreateVPCConfig(t *testing.T) {
 
 	assert.Equal(t, vpcSpec, actualVPCSpec)
 }
+
+func TestReconcileVPCSubnets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                     string
+		subnets                  []infrav1alpha1.VPCSubnetCreateOptions
+		deletionPolicy           infrav1alpha1.VPCDeletionPolicy
+		adopted                  bool
+		createdSubnetIDs         []int
+		vpc                      linodego.VPC
+		expects                  func(client *mock.MockLinodeClient)
+		expectedSubnets          []int
+		expectedCreatedSubnetIDs []int
+		expectedErr              string
+	}{
+		{
+			name:            "Success - up to date",
+			subnets:         []infrav1alpha1.VPCSubnetCreateOptions{{Label: "a", IPv4: "10.0.0.0/24"}},
+			vpc:             linodego.VPC{ID: 1, Subnets: []linodego.VPCSubnet{{ID: 10, Label: "a", IPv4: "10.0.0.0/24"}}},
+			expects:         func(client *mock.MockLinodeClient) {},
+			expectedSubnets: []int{10},
+		},
+		{
+			name:             "Success - subnet added and removed",
+			subnets:          []infrav1alpha1.VPCSubnetCreateOptions{{Label: "a", IPv4: "10.0.0.0/24"}, {Label: "c", IPv4: "10.0.2.0/24"}},
+			createdSubnetIDs: []int{11},
+			vpc: linodego.VPC{ID: 1, Subnets: []linodego.VPCSubnet{
+				{ID: 10, Label: "a", IPv4: "10.0.0.0/24", Linodes: []linodego.VPCSubnetLinode{{ID: 100}}},
+				{ID: 11, Label: "b", IPv4: "10.0.1.0/24"},
+			}},
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().DeleteVPCSubnet(gomock.Any(), 1, 11).Return(nil)
+				client.EXPECT().CreateVPCSubnet(gomock.Any(), linodego.VPCSubnetCreateOptions{Label: "c", IPv4: "10.0.2.0/24"}, 1).
+					Return(&linodego.VPCSubnet{ID: 12, Label: "c", IPv4: "10.0.2.0/24"}, nil)
+			},
+			expectedSubnets:          []int{10, 12},
+			expectedCreatedSubnetIDs: []int{12},
+		},
+		{
+			name:    "Success - subnet not created by CAPL is not deleted",
+			subnets: []infrav1alpha1.VPCSubnetCreateOptions{{Label: "a", IPv4: "10.0.0.0/24"}},
+			vpc: linodego.VPC{ID: 1, Subnets: []linodego.VPCSubnet{
+				{ID: 10, Label: "a", IPv4: "10.0.0.0/24"},
+				{ID: 11, Label: "b", IPv4: "10.0.1.0/24"},
+			}},
+			expects:         func(client *mock.MockLinodeClient) {},
+			expectedSubnets: []int{10, 11},
+		},
+		{
+			name:             "Success - subnet of a retained VPC is not deleted",
+			subnets:          []infrav1alpha1.VPCSubnetCreateOptions{{Label: "a", IPv4: "10.0.0.0/24"}},
+			deletionPolicy:   infrav1alpha1.VPCDeletionPolicyRetain,
+			createdSubnetIDs: []int{11},
+			vpc: linodego.VPC{ID: 1, Subnets: []linodego.VPCSubnet{
+				{ID: 10, Label: "a", IPv4: "10.0.0.0/24"},
+				{ID: 11, Label: "b", IPv4: "10.0.1.0/24"},
+			}},
+			expects:                  func(client *mock.MockLinodeClient) {},
+			expectedSubnets:          []int{10, 11},
+			expectedCreatedSubnetIDs: []int{11},
+		},
+		{
+			name:                     "Success - subnet of an adopted VPC is not deleted",
+			subnets:                  []infrav1alpha1.VPCSubnetCreateOptions{{Label: "a", IPv4: "10.0.0.0/24"}},
+			adopted:                  true,
+			createdSubnetIDs:         []int{11},
+			vpc:                      linodego.VPC{ID: 1, Subnets: []linodego.VPCSubnet{{ID: 10, Label: "a", IPv4: "10.0.0.0/24"}, {ID: 11, Label: "b", IPv4: "10.0.1.0/24"}}},
+			expects:                  func(client *mock.MockLinodeClient) {},
+			expectedSubnets:          []int{10, 11},
+			expectedCreatedSubnetIDs: []int{11},
+		},
+		{
+			name:                     "Success - subnet deleted out of band is forgotten",
+			subnets:                  []infrav1alpha1.VPCSubnetCreateOptions{{Label: "a", IPv4: "10.0.0.0/24"}},
+			createdSubnetIDs:         []int{10, 11},
+			vpc:                      linodego.VPC{ID: 1, Subnets: []linodego.VPCSubnet{{ID: 10, Label: "a", IPv4: "10.0.0.0/24"}}},
+			expects:                  func(client *mock.MockLinodeClient) {},
+			expectedSubnets:          []int{10},
+			expectedCreatedSubnetIDs: []int{10},
+		},
+		{
+			name:            "Success - subnets are not managed",
+			vpc:             linodego.VPC{ID: 1, Subnets: []linodego.VPCSubnet{{ID: 10, Label: "a", IPv4: "10.0.0.0/24"}}},
+			expects:         func(client *mock.MockLinodeClient) {},
+			expectedSubnets: []int{10},
+		},
+		{
+			name:             "Error - subnet with Linodes attached is not deleted",
+			subnets:          []infrav1alpha1.VPCSubnetCreateOptions{{Label: "c", IPv4: "10.0.2.0/24"}},
+			createdSubnetIDs: []int{10},
+			vpc: linodego.VPC{ID: 1, Subnets: []linodego.VPCSubnet{
+				{ID: 10, Label: "a", IPv4: "10.0.0.0/24", Linodes: []linodego.VPCSubnetLinode{{ID: 100}}},
+			}},
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().CreateVPCSubnet(gomock.Any(), gomock.Any(), 1).Return(&linodego.VPCSubnet{ID: 12}, nil)
+			},
+			expectedSubnets:          []int{10, 12},
+			expectedCreatedSubnetIDs: []int{10, 12},
+			expectedErr:              "will not delete subnet a with node(s) attached: 100",
+		},
+		{
+			name:    "Error - create fails",
+			subnets: []infrav1alpha1.VPCSubnetCreateOptions{{Label: "c", IPv4: "10.0.2.0/24"}},
+			vpc:     linodego.VPC{ID: 1},
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().CreateVPCSubnet(gomock.Any(), gomock.Any(), 1).Return(nil, errors.New("overlap"))
+			},
+			expectedSubnets: []int{},
+			expectedErr:     "create subnet c: overlap",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			client := mock.NewMockLinodeClient(mockCtrl)
+			testcase.expects(client)
+
+			r := &LinodeVPCReconciler{Recorder: record.NewFakeRecorder(10)}
+			vpcScope := &scope.VPCScope{
+				LinodeClient: client,
+				LinodeVPC: &infrav1alpha1.LinodeVPC{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-vpc", Namespace: "default"},
+					Spec:       infrav1alpha1.LinodeVPCSpec{Subnets: testcase.subnets, DeletionPolicy: testcase.deletionPolicy},
+					Status:     infrav1alpha1.LinodeVPCStatus{Created: !testcase.adopted, CreatedSubnetIDs: testcase.createdSubnetIDs},
+				},
+			}
+
+			err := r.reconcileVPCSubnets(context.Background(), vpcScope, &testcase.vpc, logr.Discard())
+			if testcase.expectedErr != "" {
+				require.ErrorContains(t, err, testcase.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			if testcase.expectedSubnets != nil {
+				subnetIDs := []int{}
+				for _, subnet := range testcase.vpc.Subnets {
+					subnetIDs = append(subnetIDs, subnet.ID)
+				}
+				assert.Equal(t, testcase.expectedSubnets, subnetIDs)
+			}
+			assert.ElementsMatch(t, testcase.expectedCreatedSubnetIDs, vpcScope.LinodeVPC.Status.CreatedSubnetIDs)
+		})
+	}
+}
+
+func TestVPCSubnetStatuses(t *testing.T) {
+	t.Parallel()
+
+	statuses := vpcSubnetStatuses([]linodego.VPCSubnet{
+		{
+			ID:    10,
+			Label: "default",
+			IPv4:  "10.0.0.0/24",
+			Linodes: []linodego.VPCSubnetLinode{
+				{ID: 100, Interfaces: []linodego.VPCSubnetLinodeInterface{{ID: 1}}},
+				{ID: 101, Interfaces: []linodego.VPCSubnetLinodeInterface{{ID: 2}, {ID: 3}}},
+			},
+		},
+		{ID: 11, Label: "empty", IPv4: "10.0.1.0/30"},
+		{ID: 12, Label: "invalid", IPv4: "invalid"},
+	})
+	assert.Equal(t, []infrav1alpha1.VPCSubnetStatus{
+		{Label: "default", ID: 10, IPv4: "10.0.0.0/24", LinodeIDs: []int{100, 101}, AvailableIPv4Addresses: 250},
+		{Label: "empty", ID: 11, IPv4: "10.0.1.0/30", AvailableIPv4Addresses: 1},
+		{Label: "invalid", ID: 12, IPv4: "invalid"},
+	}, statuses)
+}
+
+func TestReconcileVPCAdoption(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		spec           infrav1alpha1.LinodeVPCSpec
+		clusterVPC     bool
+		expectedFilter string
+		vpc            linodego.VPC
+		expectedVPCID  int
+		expectedErr    string
+	}{
+		{
+			name:           "Success - adopted by ID",
+			spec:           infrav1alpha1.LinodeVPCSpec{VPCID: ptr.To(5), Region: "us-east"},
+			expectedFilter: `{"id":"5"}`,
+			vpc:            linodego.VPC{ID: 5, Label: "shared", Region: "us-east"},
+			expectedVPCID:  5,
+		},
+		{
+			name:           "Success - adopted by label",
+			spec:           infrav1alpha1.LinodeVPCSpec{Label: "shared", Region: "us-east"},
+			expectedFilter: `{"label":"shared"}`,
+			vpc:            linodego.VPC{ID: 5, Label: "shared", Region: "us-east"},
+			expectedVPCID:  5,
+		},
+		{
+			name:           "Error - adopted VPC is in another region",
+			spec:           infrav1alpha1.LinodeVPCSpec{Label: "shared", Region: "us-east"},
+			expectedFilter: `{"label":"shared"}`,
+			vpc:            linodego.VPC{ID: 5, Label: "shared", Region: "us-west"},
+			expectedErr:    "VPC 5 is in region us-west, not us-east",
+		},
+		{
+			name:           "Error - inline VPC of a LinodeCluster is never adopted by label",
+			spec:           infrav1alpha1.LinodeVPCSpec{Label: "shared", Region: "us-east"},
+			clusterVPC:     true,
+			expectedFilter: `{"label":"shared"}`,
+			vpc:            linodego.VPC{ID: 5, Label: "shared", Region: "us-east"},
+			expectedErr:    "VPC 5 already has the label shared of the inline VPC of a LinodeCluster, refusing to adopt it",
+		},
+		{
+			name:           "Success - inline VPC of a LinodeCluster is found by ID",
+			spec:           infrav1alpha1.LinodeVPCSpec{VPCID: ptr.To(5), Label: "shared", Region: "us-east"},
+			clusterVPC:     true,
+			expectedFilter: `{"id":"5"}`,
+			vpc:            linodego.VPC{ID: 5, Label: "shared", Region: "us-east"},
+			expectedVPCID:  5,
+		},
+		{
+			name:           "Error - VPC ID not found",
+			spec:           infrav1alpha1.LinodeVPCSpec{VPCID: ptr.To(5), Region: "us-east"},
+			expectedFilter: `{"id":"5"}`,
+			expectedErr:    "VPC 5 not found",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			client := mock.NewMockLinodeClient(mockCtrl)
+			client.EXPECT().ListVPCs(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts *linodego.ListOptions) ([]linodego.VPC, error) {
+				assert.Equal(t, testcase.expectedFilter, opts.Filter)
+				if testcase.vpc.ID == 0 {
+					return nil, nil
+				}
+
+				return []linodego.VPC{testcase.vpc}, nil
+			})
+
+			r := &LinodeVPCReconciler{Recorder: record.NewFakeRecorder(10)}
+			vpcScope := &scope.VPCScope{
+				LinodeClient: client,
+				LinodeVPC: &infrav1alpha1.LinodeVPC{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-vpc", Namespace: "default"},
+					Spec:       testcase.spec,
+				},
+			}
+			if testcase.clusterVPC {
+				vpcScope.LinodeVPC.OwnerReferences = []metav1.OwnerReference{{
+					APIVersion: infrav1alpha1.GroupVersion.String(),
+					Kind:       "LinodeCluster",
+					Name:       "test-cluster",
+					Controller: ptr.To(true),
+				}}
+			}
+
+			err := r.reconcileVPC(context.Background(), vpcScope, logr.Discard())
+			if testcase.expectedErr != "" {
+				require.ErrorContains(t, err, testcase.expectedErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expectedVPCID, *vpcScope.LinodeVPC.Spec.VPCID)
+		})
+	}
+}
+
+func TestReconcileVPCDeletionPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		spec          infrav1alpha1.LinodeVPCSpec
+		created       bool
+		expects       func(client *mock.MockLinodeClient)
+		expectedEvent string
+	}{
+		{
+			name:    "Success - retained VPC is released",
+			spec:    infrav1alpha1.LinodeVPCSpec{VPCID: ptr.To(5), Region: "us-east", DeletionPolicy: infrav1alpha1.VPCDeletionPolicyRetain},
+			created: true,
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().GetVPC(gomock.Any(), 5).Return(&linodego.VPC{ID: 5, Description: util.ManagedVPCDescription}, nil)
+				client.EXPECT().UpdateVPC(gomock.Any(), 5, linodego.VPCUpdateOptions{Description: util.RetainedVPCDescription}).Return(&linodego.VPC{ID: 5}, nil)
+			},
+			expectedEvent: "Retained VPC 5",
+		},
+		{
+			name: "Success - VPC adopted by label is retained by default",
+			spec: infrav1alpha1.LinodeVPCSpec{VPCID: ptr.To(5), Label: "shared", Region: "us-east"},
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().GetVPC(gomock.Any(), 5).Return(&linodego.VPC{ID: 5, Label: "shared", Description: "shared VPC"}, nil)
+			},
+			expectedEvent: "Retained VPC 5",
+		},
+		{
+			name:    "Success - created VPC is deleted by default",
+			spec:    infrav1alpha1.LinodeVPCSpec{VPCID: ptr.To(5), Region: "us-east"},
+			created: true,
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().GetVPC(gomock.Any(), 5).Return(&linodego.VPC{ID: 5}, nil)
+				client.EXPECT().DeleteVPC(gomock.Any(), 5).Return(nil)
+			},
+			expectedEvent: "VPC has cleaned up",
+		},
+		{
+			name: "Success - adopted VPC is deleted when requested",
+			spec: infrav1alpha1.LinodeVPCSpec{VPCID: ptr.To(5), Region: "us-east", DeletionPolicy: infrav1alpha1.VPCDeletionPolicyDelete},
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().GetVPC(gomock.Any(), 5).Return(&linodego.VPC{ID: 5}, nil)
+				client.EXPECT().DeleteVPC(gomock.Any(), 5).Return(nil)
+			},
+			expectedEvent: "VPC has cleaned up",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// DeleteVPC fails the test unless expected
+			client := mock.NewMockLinodeClient(mockCtrl)
+			testcase.expects(client)
+
+			recorder := record.NewFakeRecorder(10)
+			r := &LinodeVPCReconciler{Recorder: recorder}
+			vpcScope := &scope.VPCScope{
+				LinodeClient: client,
+				LinodeVPC: &infrav1alpha1.LinodeVPC{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "test-vpc",
+						Namespace:  "default",
+						Finalizers: []string{infrav1alpha1.GroupVersion.String()},
+					},
+					Spec:   testcase.spec,
+					Status: infrav1alpha1.LinodeVPCStatus{Created: testcase.created},
+				},
+			}
+
+			res, err := r.reconcileDelete(context.Background(), logr.Discard(), vpcScope)
+			require.NoError(t, err)
+			assert.Zero(t, res)
+			assert.Empty(t, vpcScope.LinodeVPC.Finalizers)
+			assert.Contains(t, <-recorder.Events, testcase.expectedEvent)
+		})
+	}
+}
+
+func TestVPCSetFailureReason(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		err              error
+		expectedReason   *infrav1alpha1.VPCStatusError
+		expectedSeverity clusterv1.ConditionSeverity
+	}{
+		{
+			name:             "transient error decays",
+			err:              linodego.Error{Code: 503, Message: "unavailable"},
+			expectedSeverity: clusterv1.ConditionSeverityWarning,
+		},
+		{
+			name:             "terminal error fails immediately",
+			err:              linodego.Error{Code: 400, Message: "[region] Region is not valid"},
+			expectedReason:   ptr.To(infrav1alpha1.CreateVPCError),
+			expectedSeverity: clusterv1.ConditionSeverityError,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &LinodeVPCReconciler{Recorder: record.NewFakeRecorder(10)}
+			vpcScope := &scope.VPCScope{LinodeVPC: &infrav1alpha1.LinodeVPC{}}
+
+			r.setFailureReason(context.Background(), vpcScope, infrav1alpha1.CreateVPCError, testcase.err)
+			assert.Equal(t, testcase.expectedReason, vpcScope.LinodeVPC.Status.FailureReason)
+			assert.True(t, conditions.IsFalse(vpcScope.LinodeVPC, clusterv1.ReadyCondition))
+			assert.Equal(t, ptr.To(testcase.expectedSeverity), conditions.GetSeverity(vpcScope.LinodeVPC, clusterv1.ReadyCondition))
+		})
+	}
+}
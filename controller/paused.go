@@ -0,0 +1,46 @@
+/*
+Copyright 2023 Akamai Technologies, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// isPaused returns true if obj has the paused annotation, or if the Cluster named by its cluster name label is
+// paused, e.g. while clusterctl move runs. Objects which don't belong to a Cluster, or whose Cluster doesn't exist,
+// are only paused by their annotation.
+func isPaused(ctx context.Context, k8sClient client.Client, obj client.Object) (bool, error) {
+	if annotations.HasPaused(obj) {
+		return true, nil
+	}
+
+	clusterName, ok := obj.GetLabels()[clusterv1.ClusterNameLabel]
+	if !ok || clusterName == "" {
+		return false, nil
+	}
+
+	var cluster clusterv1.Cluster
+	if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: clusterName}, &cluster); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	return cluster.Spec.Paused, nil
+}
@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
+)
+
+func TestIsPaused(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	require.NoError(t, infrav1alpha1.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "paused-cluster", Namespace: "default"},
+			Spec:       clusterv1.ClusterSpec{Paused: true},
+		},
+		&clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		},
+	).Build()
+
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		annotations map[string]string
+		expected    bool
+	}{
+		{
+			name: "No cluster",
+		},
+		{
+			name:        "Paused annotation",
+			annotations: map[string]string{clusterv1.PausedAnnotation: "true"},
+			expected:    true,
+		},
+		{
+			name:     "Paused cluster",
+			labels:   map[string]string{clusterv1.ClusterNameLabel: "paused-cluster"},
+			expected: true,
+		},
+		{
+			name:   "Unpaused cluster",
+			labels: map[string]string{clusterv1.ClusterNameLabel: "test-cluster"},
+		},
+		{
+			name:   "Missing cluster",
+			labels: map[string]string{clusterv1.ClusterNameLabel: "missing-cluster"},
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			linodeVPC := &infrav1alpha1.LinodeVPC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-vpc",
+					Namespace:   "default",
+					Labels:      testcase.labels,
+					Annotations: testcase.annotations,
+				},
+			}
+
+			paused, err := isPaused(context.Background(), k8sClient, linodeVPC)
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expected, paused)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Package feature defines the feature gates of CAPL, which let new capabilities be enabled or disabled with the
+// --feature-gates flag of the controller manager.
+package feature
+
+import (
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/component-base/featuregate"
+)
+
+const (
+	// LinodeVPC enables the LinodeVPC controller and the LinodeVPC references of LinodeClusters.
+	LinodeVPC featuregate.Feature = "LinodeVPC"
+
+	// NodeBalancerVPCBackends enables registering the control plane machines as backends of the api server
+	// NodeBalancer with their VPC address. CAPL cannot attach the NodeBalancer to the VPC yet, so it must be attached
+	// outside of CAPL.
+	NodeBalancerVPCBackends featuregate.Feature = "NodeBalancerVPCBackends"
+
+	// StackScriptBootstrap enables bootstrapping instances with a StackScript in the regions and images which do not
+	// support cloud-init metadata. Instances fail to be created there when disabled.
+	StackScriptBootstrap featuregate.Feature = "StackScriptBootstrap"
+)
+
+var (
+	// MutableGates is the mutable version of Gates, set by the --feature-gates flag.
+	MutableGates featuregate.MutableFeatureGate = featuregate.NewFeatureGate()
+
+	// Gates is the feature gates consulted by the controllers and webhooks.
+	Gates featuregate.FeatureGate = MutableGates
+)
+
+// defaultFeatureGates are the feature gates known to CAPL. Features which shipped before the gates existed are beta
+// and enabled by default, so that they can be disabled without affecting existing clusters.
+var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
+	LinodeVPC:               {Default: true, PreRelease: featuregate.Beta},
+	NodeBalancerVPCBackends: {Default: false, PreRelease: featuregate.Alpha},
+	StackScriptBootstrap:    {Default: true, PreRelease: featuregate.Beta},
+}
+
+func init() {
+	runtime.Must(MutableGates.Add(defaultFeatureGates))
+}
+
+// NewGates returns feature gates with the defaults of CAPL, independent of Gates, e.g. for tests.
+func NewGates() featuregate.MutableFeatureGate {
+	gates := featuregate.NewFeatureGate()
+	runtime.Must(gates.Add(defaultFeatureGates))
+
+	return gates
+}
+
+// Known returns the feature gates known to CAPL and their specs.
+func Known() map[featuregate.Feature]featuregate.FeatureSpec {
+	return maps.Clone(defaultFeatureGates)
+}
+
+// Enabled returns the sorted names of the CAPL features enabled in gates.
+func Enabled(gates featuregate.FeatureGate) []string {
+	var enabled []string
+	for name := range defaultFeatureGates {
+		if gates.Enabled(name) {
+			enabled = append(enabled, string(name))
+		}
+	}
+	slices.Sort(enabled)
+
+	return enabled
+}
@@ -0,0 +1,23 @@
+package feature
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnabled(t *testing.T) {
+	t.Parallel()
+
+	gates := NewGates()
+	assert.Equal(t, []string{string(LinodeVPC), string(StackScriptBootstrap)}, Enabled(gates))
+
+	require.NoError(t, gates.Set("LinodeVPC=false,AllBeta=true"))
+	assert.Equal(t, []string{string(StackScriptBootstrap)}, Enabled(gates))
+
+	require.NoError(t, gates.Set("NodeBalancerVPCBackends=true"))
+	assert.Equal(t, []string{string(NodeBalancerVPCBackends), string(StackScriptBootstrap)}, Enabled(gates))
+
+	require.Error(t, gates.Set("Unknown=true"))
+}
@@ -193,6 +193,21 @@ func (mr *MockLinodeClientMockRecorder) CreateVPC(ctx, opts any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVPC", reflect.TypeOf((*MockLinodeClient)(nil).CreateVPC), ctx, opts)
 }
 
+// CreateVPCSubnet mocks base method.
+func (m *MockLinodeClient) CreateVPCSubnet(ctx context.Context, opts linodego.VPCSubnetCreateOptions, vpcID int) (*linodego.VPCSubnet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVPCSubnet", ctx, opts, vpcID)
+	ret0, _ := ret[0].(*linodego.VPCSubnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVPCSubnet indicates an expected call of CreateVPCSubnet.
+func (mr *MockLinodeClientMockRecorder) CreateVPCSubnet(ctx, opts, vpcID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVPCSubnet", reflect.TypeOf((*MockLinodeClient)(nil).CreateVPCSubnet), ctx, opts, vpcID)
+}
+
 // DeleteInstance mocks base method.
 func (m *MockLinodeClient) DeleteInstance(ctx context.Context, linodeID int) error {
 	m.ctrl.T.Helper()
@@ -263,6 +278,20 @@ func (mr *MockLinodeClientMockRecorder) DeleteVPC(ctx, vpcID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVPC", reflect.TypeOf((*MockLinodeClient)(nil).DeleteVPC), ctx, vpcID)
 }
 
+// DeleteVPCSubnet mocks base method.
+func (m *MockLinodeClient) DeleteVPCSubnet(ctx context.Context, vpcID, subnetID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVPCSubnet", ctx, vpcID, subnetID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVPCSubnet indicates an expected call of DeleteVPCSubnet.
+func (mr *MockLinodeClientMockRecorder) DeleteVPCSubnet(ctx, vpcID, subnetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVPCSubnet", reflect.TypeOf((*MockLinodeClient)(nil).DeleteVPCSubnet), ctx, vpcID, subnetID)
+}
+
 // GetImage mocks base method.
 func (m *MockLinodeClient) GetImage(ctx context.Context, imageID string) (*linodego.Image, error) {
 	m.ctrl.T.Helper()
@@ -323,6 +352,21 @@ func (mr *MockLinodeClientMockRecorder) GetInstanceIPAddresses(ctx, linodeID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceIPAddresses", reflect.TypeOf((*MockLinodeClient)(nil).GetInstanceIPAddresses), ctx, linodeID)
 }
 
+// GetNodeBalancer mocks base method.
+func (m *MockLinodeClient) GetNodeBalancer(ctx context.Context, nodebalancerID int) (*linodego.NodeBalancer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodeBalancer", ctx, nodebalancerID)
+	ret0, _ := ret[0].(*linodego.NodeBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodeBalancer indicates an expected call of GetNodeBalancer.
+func (mr *MockLinodeClientMockRecorder) GetNodeBalancer(ctx, nodebalancerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeBalancer", reflect.TypeOf((*MockLinodeClient)(nil).GetNodeBalancer), ctx, nodebalancerID)
+}
+
 // GetObjectStorageBucket mocks base method.
 func (m *MockLinodeClient) GetObjectStorageBucket(ctx context.Context, cluster, label string) (*linodego.ObjectStorageBucket, error) {
 	m.ctrl.T.Helper()
@@ -398,6 +442,21 @@ func (mr *MockLinodeClientMockRecorder) GetVPC(ctx, vpcID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVPC", reflect.TypeOf((*MockLinodeClient)(nil).GetVPC), ctx, vpcID)
 }
 
+// ListEvents mocks base method.
+func (m *MockLinodeClient) ListEvents(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", ctx, opts)
+	ret0, _ := ret[0].([]linodego.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockLinodeClientMockRecorder) ListEvents(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockLinodeClient)(nil).ListEvents), ctx, opts)
+}
+
 // ListInstanceConfigs mocks base method.
 func (m *MockLinodeClient) ListInstanceConfigs(ctx context.Context, linodeID int, opts *linodego.ListOptions) ([]linodego.InstanceConfig, error) {
 	m.ctrl.T.Helper()
@@ -443,6 +502,21 @@ func (mr *MockLinodeClientMockRecorder) ListNodeBalancers(ctx, opts any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodeBalancers", reflect.TypeOf((*MockLinodeClient)(nil).ListNodeBalancers), ctx, opts)
 }
 
+// ListObjectStorageKeys mocks base method.
+func (m *MockLinodeClient) ListObjectStorageKeys(ctx context.Context, opts *linodego.ListOptions) ([]linodego.ObjectStorageKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListObjectStorageKeys", ctx, opts)
+	ret0, _ := ret[0].([]linodego.ObjectStorageKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListObjectStorageKeys indicates an expected call of ListObjectStorageKeys.
+func (mr *MockLinodeClientMockRecorder) ListObjectStorageKeys(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjectStorageKeys", reflect.TypeOf((*MockLinodeClient)(nil).ListObjectStorageKeys), ctx, opts)
+}
+
 // ListStackscripts mocks base method.
 func (m *MockLinodeClient) ListStackscripts(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Stackscript, error) {
 	m.ctrl.T.Helper()
@@ -487,6 +561,21 @@ func (mr *MockLinodeClientMockRecorder) ResizeInstanceDisk(ctx, linodeID, diskID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResizeInstanceDisk", reflect.TypeOf((*MockLinodeClient)(nil).ResizeInstanceDisk), ctx, linodeID, diskID, size)
 }
 
+// UpdateInstance mocks base method.
+func (m *MockLinodeClient) UpdateInstance(ctx context.Context, linodeID int, opts linodego.InstanceUpdateOptions) (*linodego.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInstance", ctx, linodeID, opts)
+	ret0, _ := ret[0].(*linodego.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInstance indicates an expected call of UpdateInstance.
+func (mr *MockLinodeClientMockRecorder) UpdateInstance(ctx, linodeID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstance", reflect.TypeOf((*MockLinodeClient)(nil).UpdateInstance), ctx, linodeID, opts)
+}
+
 // UpdateInstanceConfig mocks base method.
 func (m *MockLinodeClient) UpdateInstanceConfig(ctx context.Context, linodeID, configID int, opts linodego.InstanceConfigUpdateOptions) (*linodego.InstanceConfig, error) {
 	m.ctrl.T.Helper()
@@ -502,6 +591,36 @@ func (mr *MockLinodeClientMockRecorder) UpdateInstanceConfig(ctx, linodeID, conf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstanceConfig", reflect.TypeOf((*MockLinodeClient)(nil).UpdateInstanceConfig), ctx, linodeID, configID, opts)
 }
 
+// UpdateNodeBalancer mocks base method.
+func (m *MockLinodeClient) UpdateNodeBalancer(ctx context.Context, nodebalancerID int, opts linodego.NodeBalancerUpdateOptions) (*linodego.NodeBalancer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNodeBalancer", ctx, nodebalancerID, opts)
+	ret0, _ := ret[0].(*linodego.NodeBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNodeBalancer indicates an expected call of UpdateNodeBalancer.
+func (mr *MockLinodeClientMockRecorder) UpdateNodeBalancer(ctx, nodebalancerID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodeBalancer", reflect.TypeOf((*MockLinodeClient)(nil).UpdateNodeBalancer), ctx, nodebalancerID, opts)
+}
+
+// UpdateVPC mocks base method.
+func (m *MockLinodeClient) UpdateVPC(ctx context.Context, vpcID int, opts linodego.VPCUpdateOptions) (*linodego.VPC, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVPC", ctx, vpcID, opts)
+	ret0, _ := ret[0].(*linodego.VPC)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVPC indicates an expected call of UpdateVPC.
+func (mr *MockLinodeClientMockRecorder) UpdateVPC(ctx, vpcID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVPC", reflect.TypeOf((*MockLinodeClient)(nil).UpdateVPC), ctx, vpcID, opts)
+}
+
 // MockLinodeInstanceClient is a mock of LinodeInstanceClient interface.
 type MockLinodeInstanceClient struct {
 	ctrl     *gomock.Controller
@@ -747,6 +866,21 @@ func (mr *MockLinodeInstanceClientMockRecorder) ResizeInstanceDisk(ctx, linodeID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResizeInstanceDisk", reflect.TypeOf((*MockLinodeInstanceClient)(nil).ResizeInstanceDisk), ctx, linodeID, diskID, size)
 }
 
+// UpdateInstance mocks base method.
+func (m *MockLinodeInstanceClient) UpdateInstance(ctx context.Context, linodeID int, opts linodego.InstanceUpdateOptions) (*linodego.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInstance", ctx, linodeID, opts)
+	ret0, _ := ret[0].(*linodego.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInstance indicates an expected call of UpdateInstance.
+func (mr *MockLinodeInstanceClientMockRecorder) UpdateInstance(ctx, linodeID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstance", reflect.TypeOf((*MockLinodeInstanceClient)(nil).UpdateInstance), ctx, linodeID, opts)
+}
+
 // UpdateInstanceConfig mocks base method.
 func (m *MockLinodeInstanceClient) UpdateInstanceConfig(ctx context.Context, linodeID, configID int, opts linodego.InstanceConfigUpdateOptions) (*linodego.InstanceConfig, error) {
 	m.ctrl.T.Helper()
@@ -800,6 +934,21 @@ func (mr *MockLinodeVPCClientMockRecorder) CreateVPC(ctx, opts any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVPC", reflect.TypeOf((*MockLinodeVPCClient)(nil).CreateVPC), ctx, opts)
 }
 
+// CreateVPCSubnet mocks base method.
+func (m *MockLinodeVPCClient) CreateVPCSubnet(ctx context.Context, opts linodego.VPCSubnetCreateOptions, vpcID int) (*linodego.VPCSubnet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVPCSubnet", ctx, opts, vpcID)
+	ret0, _ := ret[0].(*linodego.VPCSubnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVPCSubnet indicates an expected call of CreateVPCSubnet.
+func (mr *MockLinodeVPCClientMockRecorder) CreateVPCSubnet(ctx, opts, vpcID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVPCSubnet", reflect.TypeOf((*MockLinodeVPCClient)(nil).CreateVPCSubnet), ctx, opts, vpcID)
+}
+
 // DeleteVPC mocks base method.
 func (m *MockLinodeVPCClient) DeleteVPC(ctx context.Context, vpcID int) error {
 	m.ctrl.T.Helper()
@@ -814,6 +963,20 @@ func (mr *MockLinodeVPCClientMockRecorder) DeleteVPC(ctx, vpcID any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVPC", reflect.TypeOf((*MockLinodeVPCClient)(nil).DeleteVPC), ctx, vpcID)
 }
 
+// DeleteVPCSubnet mocks base method.
+func (m *MockLinodeVPCClient) DeleteVPCSubnet(ctx context.Context, vpcID, subnetID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVPCSubnet", ctx, vpcID, subnetID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVPCSubnet indicates an expected call of DeleteVPCSubnet.
+func (mr *MockLinodeVPCClientMockRecorder) DeleteVPCSubnet(ctx, vpcID, subnetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVPCSubnet", reflect.TypeOf((*MockLinodeVPCClient)(nil).DeleteVPCSubnet), ctx, vpcID, subnetID)
+}
+
 // GetVPC mocks base method.
 func (m *MockLinodeVPCClient) GetVPC(ctx context.Context, vpcID int) (*linodego.VPC, error) {
 	m.ctrl.T.Helper()
@@ -844,6 +1007,21 @@ func (mr *MockLinodeVPCClientMockRecorder) ListVPCs(ctx, opts any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVPCs", reflect.TypeOf((*MockLinodeVPCClient)(nil).ListVPCs), ctx, opts)
 }
 
+// UpdateVPC mocks base method.
+func (m *MockLinodeVPCClient) UpdateVPC(ctx context.Context, vpcID int, opts linodego.VPCUpdateOptions) (*linodego.VPC, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVPC", ctx, vpcID, opts)
+	ret0, _ := ret[0].(*linodego.VPC)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVPC indicates an expected call of UpdateVPC.
+func (mr *MockLinodeVPCClientMockRecorder) UpdateVPC(ctx, vpcID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVPC", reflect.TypeOf((*MockLinodeVPCClient)(nil).UpdateVPC), ctx, vpcID, opts)
+}
+
 // MockLinodeNodeBalancerClient is a mock of LinodeNodeBalancerClient interface.
 type MockLinodeNodeBalancerClient struct {
 	ctrl     *gomock.Controller
@@ -940,6 +1118,21 @@ func (mr *MockLinodeNodeBalancerClientMockRecorder) DeleteNodeBalancerNode(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNodeBalancerNode", reflect.TypeOf((*MockLinodeNodeBalancerClient)(nil).DeleteNodeBalancerNode), ctx, nodebalancerID, configID, nodeID)
 }
 
+// GetNodeBalancer mocks base method.
+func (m *MockLinodeNodeBalancerClient) GetNodeBalancer(ctx context.Context, nodebalancerID int) (*linodego.NodeBalancer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodeBalancer", ctx, nodebalancerID)
+	ret0, _ := ret[0].(*linodego.NodeBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodeBalancer indicates an expected call of GetNodeBalancer.
+func (mr *MockLinodeNodeBalancerClientMockRecorder) GetNodeBalancer(ctx, nodebalancerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeBalancer", reflect.TypeOf((*MockLinodeNodeBalancerClient)(nil).GetNodeBalancer), ctx, nodebalancerID)
+}
+
 // ListNodeBalancers mocks base method.
 func (m *MockLinodeNodeBalancerClient) ListNodeBalancers(ctx context.Context, opts *linodego.ListOptions) ([]linodego.NodeBalancer, error) {
 	m.ctrl.T.Helper()
@@ -955,6 +1148,21 @@ func (mr *MockLinodeNodeBalancerClientMockRecorder) ListNodeBalancers(ctx, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodeBalancers", reflect.TypeOf((*MockLinodeNodeBalancerClient)(nil).ListNodeBalancers), ctx, opts)
 }
 
+// UpdateNodeBalancer mocks base method.
+func (m *MockLinodeNodeBalancerClient) UpdateNodeBalancer(ctx context.Context, nodebalancerID int, opts linodego.NodeBalancerUpdateOptions) (*linodego.NodeBalancer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNodeBalancer", ctx, nodebalancerID, opts)
+	ret0, _ := ret[0].(*linodego.NodeBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNodeBalancer indicates an expected call of UpdateNodeBalancer.
+func (mr *MockLinodeNodeBalancerClientMockRecorder) UpdateNodeBalancer(ctx, nodebalancerID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodeBalancer", reflect.TypeOf((*MockLinodeNodeBalancerClient)(nil).UpdateNodeBalancer), ctx, nodebalancerID, opts)
+}
+
 // MockLinodeObjectStorageClient is a mock of LinodeObjectStorageClient interface.
 type MockLinodeObjectStorageClient struct {
 	ctrl     *gomock.Controller
@@ -1052,6 +1260,59 @@ func (mr *MockLinodeObjectStorageClientMockRecorder) GetObjectStorageKey(ctx, ke
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectStorageKey", reflect.TypeOf((*MockLinodeObjectStorageClient)(nil).GetObjectStorageKey), ctx, keyID)
 }
 
+// ListObjectStorageKeys mocks base method.
+func (m *MockLinodeObjectStorageClient) ListObjectStorageKeys(ctx context.Context, opts *linodego.ListOptions) ([]linodego.ObjectStorageKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListObjectStorageKeys", ctx, opts)
+	ret0, _ := ret[0].([]linodego.ObjectStorageKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListObjectStorageKeys indicates an expected call of ListObjectStorageKeys.
+func (mr *MockLinodeObjectStorageClientMockRecorder) ListObjectStorageKeys(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjectStorageKeys", reflect.TypeOf((*MockLinodeObjectStorageClient)(nil).ListObjectStorageKeys), ctx, opts)
+}
+
+// MockLinodeEventClient is a mock of LinodeEventClient interface.
+type MockLinodeEventClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinodeEventClientMockRecorder
+}
+
+// MockLinodeEventClientMockRecorder is the mock recorder for MockLinodeEventClient.
+type MockLinodeEventClientMockRecorder struct {
+	mock *MockLinodeEventClient
+}
+
+// NewMockLinodeEventClient creates a new mock instance.
+func NewMockLinodeEventClient(ctrl *gomock.Controller) *MockLinodeEventClient {
+	mock := &MockLinodeEventClient{ctrl: ctrl}
+	mock.recorder = &MockLinodeEventClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinodeEventClient) EXPECT() *MockLinodeEventClientMockRecorder {
+	return m.recorder
+}
+
+// ListEvents mocks base method.
+func (m *MockLinodeEventClient) ListEvents(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", ctx, opts)
+	ret0, _ := ret[0].([]linodego.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockLinodeEventClientMockRecorder) ListEvents(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockLinodeEventClient)(nil).ListEvents), ctx, opts)
+}
+
 // MockK8sClient is a mock of K8sClient interface.
 type MockK8sClient struct {
 	ctrl     *gomock.Controller
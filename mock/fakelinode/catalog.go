@@ -0,0 +1,102 @@
+package fakelinode
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/linode/linodego"
+)
+
+// seedCatalog populates the regions, images and types available in a new server.
+func (s *Server) seedCatalog() {
+	capabilities := []string{"Linodes", "NodeBalancers", "Block Storage", "Object Storage", "VPCs", "Metadata"}
+	for _, id := range []string{"us-east", "us-ord", "us-sea", "fr-par"} {
+		s.regions[id] = &linodego.Region{
+			ID:           id,
+			Country:      strings.Split(id, "-")[0],
+			Capabilities: slices.Clone(capabilities),
+			Status:       "ok",
+			Label:        id,
+			SiteType:     "core",
+		}
+	}
+
+	for _, id := range []string{"linode/ubuntu22.04", "linode/ubuntu24.04", "linode/debian12"} {
+		s.images[id] = &linodego.Image{
+			ID:           id,
+			CreatedBy:    "linode",
+			Capabilities: []string{"cloud-init"},
+			Label:        strings.TrimPrefix(id, "linode/"),
+			Type:         "manual",
+			Status:       linodego.ImageStatusAvailable,
+			Size:         2500,
+			IsPublic:     true,
+		}
+	}
+
+	for _, t := range []linodego.LinodeType{
+		{ID: "g6-nanode-1", Class: linodego.ClassNanode, Label: "Nanode 1GB", Disk: 25600, Memory: 1024, VCPUs: 1, Transfer: 1000},
+		{ID: "g6-standard-1", Class: linodego.ClassStandard, Label: "Linode 2GB", Disk: 51200, Memory: 2048, VCPUs: 1, Transfer: 2000},
+		{ID: "g6-standard-2", Class: linodego.ClassStandard, Label: "Linode 4GB", Disk: 81920, Memory: 4096, VCPUs: 2, Transfer: 4000},
+		{ID: "g6-standard-4", Class: linodego.ClassStandard, Label: "Linode 8GB", Disk: 163840, Memory: 8192, VCPUs: 4, Transfer: 5000},
+	} {
+		s.types[t.ID] = &t
+	}
+}
+
+// AddRegion adds or replaces a region.
+func (s *Server) AddRegion(region linodego.Region) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.regions[region.ID] = &region
+}
+
+// AddImage adds or replaces an image.
+func (s *Server) AddImage(image linodego.Image) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.images[image.ID] = &image
+}
+
+// AddType adds or replaces a Linode type.
+func (s *Server) AddType(linodeType linodego.LinodeType) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.types[linodeType.ID] = &linodeType
+}
+
+func (s *Server) registerCatalog() {
+	s.handle("GET /regions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		region, ok := s.regions[r.PathValue("id")]
+		if !ok {
+			writeNotFound(w)
+
+			return
+		}
+		writeJSON(w, http.StatusOK, region)
+	})
+
+	s.handle("GET /images/{id...}", func(w http.ResponseWriter, r *http.Request) {
+		image, ok := s.images[r.PathValue("id")]
+		if !ok {
+			writeNotFound(w)
+
+			return
+		}
+		writeJSON(w, http.StatusOK, image)
+	})
+
+	s.handle("GET /linode/types/{id}", func(w http.ResponseWriter, r *http.Request) {
+		linodeType, ok := s.types[r.PathValue("id")]
+		if !ok {
+			writeNotFound(w)
+
+			return
+		}
+		writeJSON(w, http.StatusOK, linodeType)
+	})
+}
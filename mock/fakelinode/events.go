@@ -0,0 +1,71 @@
+package fakelinode
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/linode/linodego"
+)
+
+// eventTimeFormat is the timestamp format of the Linode API.
+const eventTimeFormat = "2006-01-02T15:04:05"
+
+// event is the wire representation of an account event, since linodego.Event does not marshal its created time.
+type event struct {
+	linodego.Event
+
+	Created string `json:"created"`
+}
+
+// AddEvent appends an account event, e.g. to simulate a failed operation. Its ID and created time are set when
+// they are zero-valued. It returns the ID of the event.
+func (s *Server) AddEvent(linodeEvent linodego.Event) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addEvent(linodeEvent)
+}
+
+// addEvent appends an account event. Callers must hold s.mu.
+func (s *Server) addEvent(linodeEvent linodego.Event) int {
+	if linodeEvent.ID == 0 {
+		linodeEvent.ID = s.newID()
+	}
+	created := time.Now().UTC()
+	if linodeEvent.Created != nil {
+		created = linodeEvent.Created.UTC()
+	}
+	linodeEvent.Created = nil
+
+	s.events = append(s.events, &event{Event: linodeEvent, Created: created.Format(eventTimeFormat)})
+
+	return linodeEvent.ID
+}
+
+// recordInstanceEvent appends a finished event for an operation on a Linode. Callers must hold s.mu.
+func (s *Server) recordInstanceEvent(action linodego.EventAction, inst *instance, disk *linodego.InstanceDisk) {
+	linodeEvent := linodego.Event{
+		Action:   action,
+		Status:   linodego.EventFinished,
+		Username: "fake",
+		Entity: &linodego.EventEntity{
+			ID:    inst.ID,
+			Label: inst.Label,
+			Type:  linodego.EntityLinode,
+		},
+	}
+	if disk != nil {
+		linodeEvent.SecondaryEntity = &linodego.EventEntity{
+			ID:    disk.ID,
+			Label: disk.Label,
+			Type:  linodego.EntityDisk,
+		}
+	}
+	s.addEvent(linodeEvent)
+}
+
+func (s *Server) registerEvents() {
+	s.handle("GET /account/events", func(w http.ResponseWriter, r *http.Request) {
+		writeList(w, r, s.events)
+	})
+}
@@ -0,0 +1,98 @@
+package fakelinode
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fault is a delay and/or error injected into requests that match it.
+type Fault struct {
+	// Method is the HTTP method to match. All methods match when empty.
+	Method string
+	// Path is the path prefix to match, without the API version, e.g. "/linode/instances". All paths match when
+	// empty.
+	Path string
+	// Times is the number of matching requests to affect, after which the fault is removed. The fault applies to
+	// all matching requests when zero.
+	Times int
+
+	// Latency delays matching requests.
+	Latency time.Duration
+	// StatusCode is the HTTP status returned for matching requests instead of serving them. Requests are served
+	// normally (after any latency) when zero.
+	StatusCode int
+	// RetryAfter is the value of the Retry-After header sent with a 429 response.
+	RetryAfter time.Duration
+}
+
+// InjectFault adds a fault to the server. When more than one fault matches a request, the earliest one injected
+// is applied.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// matchFault returns the fault to apply to r, if any, consuming one of its remaining uses.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := r.URL.Path
+	if _, rest, found := strings.Cut(strings.TrimPrefix(path, "/"), "/"); found {
+		path = "/" + rest
+	}
+
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(path, fault.Path) {
+			continue
+		}
+
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+
+		return fault
+	}
+
+	return nil
+}
+
+// apply delays and/or fails the request. It returns whether the request should still be served.
+func (f *Fault) apply(w http.ResponseWriter, r *http.Request) bool {
+	if f.Latency > 0 {
+		select {
+		case <-time.After(f.Latency):
+		case <-r.Context().Done():
+			return false
+		}
+	}
+
+	if f.StatusCode == 0 {
+		return true
+	}
+
+	if f.StatusCode == http.StatusTooManyRequests {
+		w.Header().Set("Retry-After", strconv.Itoa(int(f.RetryAfter.Seconds())))
+	}
+	writeError(w, f.StatusCode, "", http.StatusText(f.StatusCode))
+
+	return false
+}
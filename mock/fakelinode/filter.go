@@ -0,0 +1,195 @@
+package fakelinode
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// filterItems returns the items that match the Linode API filter expression, sorted as requested by it.
+//
+// Fields are compared by their JSON representation. A plain value matches a field equal to it, or an array field
+// containing it. The +and, +or, +contains, +neq, +gt, +gte, +lt and +lte operators and +order_by/+order are
+// supported.
+func filterItems[T any](items []T, filter string) ([]T, error) {
+	if filter == "" {
+		return items, nil
+	}
+
+	var expr map[string]any
+	if err := json.Unmarshal([]byte(filter), &expr); err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+
+	orderBy, _ := expr["+order_by"].(string)
+	descending := expr["+order"] == "desc"
+	delete(expr, "+order_by")
+	delete(expr, "+order")
+
+	type object = map[string]any
+	objects := make([]object, 0, len(items))
+	matched := make([]T, 0, len(items))
+	for _, item := range items {
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		var obj object
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return nil, err
+		}
+
+		ok, err := matchExpr(obj, expr)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			objects = append(objects, obj)
+			matched = append(matched, item)
+		}
+	}
+
+	if orderBy != "" {
+		indices := make([]int, len(matched))
+		for i := range indices {
+			indices[i] = i
+		}
+		slices.SortStableFunc(indices, func(a, b int) int {
+			cmp := compare(objects[a][orderBy], objects[b][orderBy])
+			if descending {
+				return -cmp
+			}
+
+			return cmp
+		})
+
+		sorted := make([]T, len(matched))
+		for i, idx := range indices {
+			sorted[i] = matched[idx]
+		}
+		matched = sorted
+	}
+
+	return matched, nil
+}
+
+// matchExpr reports whether obj matches every clause of expr.
+func matchExpr(obj map[string]any, expr map[string]any) (bool, error) {
+	for key, value := range expr {
+		var (
+			ok  bool
+			err error
+		)
+		switch key {
+		case "+and", "+or":
+			ok, err = matchLogical(obj, key, value)
+		default:
+			ok, err = matchField(obj[key], value)
+		}
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func matchLogical(obj map[string]any, op string, value any) (bool, error) {
+	clauses, ok := value.([]any)
+	if !ok {
+		return false, fmt.Errorf("%s requires a list", op)
+	}
+
+	for _, clause := range clauses {
+		expr, ok := clause.(map[string]any)
+		if !ok {
+			return false, fmt.Errorf("%s requires a list of objects", op)
+		}
+		matched, err := matchExpr(obj, expr)
+		if err != nil {
+			return false, err
+		}
+		if op == "+or" && matched {
+			return true, nil
+		}
+		if op == "+and" && !matched {
+			return false, nil
+		}
+	}
+
+	return op == "+and", nil
+}
+
+// matchField reports whether a field's value satisfies a filter value, which is either a plain value or an
+// object of operators.
+func matchField(field, value any) (bool, error) {
+	ops, isOps := value.(map[string]any)
+	if !isOps {
+		if list, ok := field.([]any); ok {
+			return slices.ContainsFunc(list, func(elem any) bool { return compare(elem, value) == 0 }), nil
+		}
+
+		return compare(field, value) == 0, nil
+	}
+
+	for op, operand := range ops {
+		var ok bool
+		switch op {
+		case "+contains":
+			ok = strings.Contains(toString(field), toString(operand))
+		case "+neq":
+			ok = compare(field, operand) != 0
+		case "+gt":
+			ok = compare(field, operand) > 0
+		case "+gte":
+			ok = compare(field, operand) >= 0
+		case "+lt":
+			ok = compare(field, operand) < 0
+		case "+lte":
+			ok = compare(field, operand) <= 0
+		default:
+			return false, fmt.Errorf("unsupported operator %q", op)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// compare orders two JSON values, numerically when both are numbers (or numeric strings) and lexically
+// otherwise.
+func compare(a, b any) int {
+	as, bs := toString(a), toString(b)
+
+	af, aErr := strconv.ParseFloat(as, 64)
+	bf, bErr := strconv.ParseFloat(bs, 64)
+	if aErr == nil && bErr == nil {
+		switch {
+		case af < bf:
+			return -1
+		case af > bf:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return strings.Compare(as, bs)
+}
+
+func toString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
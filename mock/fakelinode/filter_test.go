@@ -0,0 +1,63 @@
+package fakelinode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	ID    int      `json:"id"`
+	Label string   `json:"label"`
+	Tags  []string `json:"tags"`
+}
+
+func TestFilterItems(t *testing.T) {
+	t.Parallel()
+
+	items := []item{
+		{ID: 1, Label: "control-plane-1", Tags: []string{"cluster-a"}},
+		{ID: 2, Label: "worker-1", Tags: []string{"cluster-a", "workers"}},
+		{ID: 3, Label: "worker-2", Tags: []string{"cluster-b", "workers"}},
+	}
+
+	tests := []struct {
+		name    string
+		filter  string
+		wantIDs []int
+		wantErr bool
+	}{
+		{name: "No filter", filter: "", wantIDs: []int{1, 2, 3}},
+		{name: "Equal", filter: `{"label":"worker-1"}`, wantIDs: []int{2}},
+		{name: "Numeric string", filter: `{"id":"3"}`, wantIDs: []int{3}},
+		{name: "Tag", filter: `{"tags":"workers"}`, wantIDs: []int{2, 3}},
+		{name: "And", filter: `{"+and":[{"tags":"workers"},{"tags":"cluster-a"}]}`, wantIDs: []int{2}},
+		{name: "Or", filter: `{"+or":[{"id":1},{"id":3}]}`, wantIDs: []int{1, 3}},
+		{name: "Contains", filter: `{"label":{"+contains":"worker"}}`, wantIDs: []int{2, 3}},
+		{name: "Range", filter: `{"id":{"+gt":1,"+lte":2}}`, wantIDs: []int{2}},
+		{name: "Order", filter: `{"tags":"workers","+order_by":"label","+order":"desc"}`, wantIDs: []int{3, 2}},
+		{name: "Unknown operator", filter: `{"id":{"+between":1}}`, wantErr: true},
+		{name: "Invalid JSON", filter: `{`, wantErr: true},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := filterItems(items, testcase.filter)
+			if testcase.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+
+			ids := make([]int, 0, len(got))
+			for _, i := range got {
+				ids = append(ids, i.ID)
+			}
+			assert.Equal(t, testcase.wantIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,532 @@
+package fakelinode
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+
+	"github.com/linode/linodego"
+)
+
+const (
+	defaultSwapSize = 512
+
+	// publicIPv4Pool and privateIPv4Pool are the ranges addresses are assigned from, by ID.
+	publicIPv4Pool  = "100.64.0.0/10"
+	privateIPv4Pool = "192.168.128.0/17"
+)
+
+// instance is a Linode and the resources that only exist as part of it.
+type instance struct {
+	linodego.Instance
+
+	configs     []*linodego.InstanceConfig
+	disks       []*linodego.InstanceDisk
+	privateIPv4 string
+}
+
+// Instances returns a snapshot of all Linodes.
+func (s *Server) Instances() []linodego.Instance {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.listInstances()
+}
+
+// SetInstanceStatus sets the status of a Linode, e.g. to simulate it being shut down. It returns false if the
+// Linode does not exist.
+func (s *Server) SetInstanceStatus(linodeID int, status linodego.InstanceStatus) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inst, ok := s.instances[linodeID]
+	if ok {
+		inst.Status = status
+	}
+
+	return ok
+}
+
+func (s *Server) listInstances() []linodego.Instance {
+	instances := make([]linodego.Instance, 0, len(s.instances))
+	for _, id := range sortedKeys(s.instances) {
+		instances = append(instances, s.instances[id].Instance)
+	}
+
+	return instances
+}
+
+//nolint:cyclop,gocognit // Route registration for the whole resource lives in one place.
+func (s *Server) registerInstances() {
+	s.handle("GET /linode/instances", func(w http.ResponseWriter, r *http.Request) {
+		writeList(w, r, s.listInstances())
+	})
+
+	s.handle("POST /linode/instances", func(w http.ResponseWriter, r *http.Request) {
+		var opts linodego.InstanceCreateOptions
+		if !decode(w, r, &opts) {
+			return
+		}
+		inst, field, err := s.createInstance(opts)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, field, err.Error())
+
+			return
+		}
+		s.recordInstanceEvent(linodego.ActionLinodeCreate, inst, nil)
+		writeJSON(w, http.StatusOK, inst.Instance)
+	})
+
+	s.handle("GET /linode/instances/{id}", func(w http.ResponseWriter, r *http.Request) {
+		inst := s.pathInstance(w, r)
+		if inst == nil {
+			return
+		}
+		writeJSON(w, http.StatusOK, inst.Instance)
+	})
+
+	s.handle("DELETE /linode/instances/{id}", func(w http.ResponseWriter, r *http.Request) {
+		inst := s.pathInstance(w, r)
+		if inst == nil {
+			return
+		}
+		s.detachFromSubnets(inst.ID)
+		delete(s.instances, inst.ID)
+		s.recordInstanceEvent(linodego.ActionLinodeDelete, inst, nil)
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+
+	s.handle("POST /linode/instances/{id}/boot", func(w http.ResponseWriter, r *http.Request) {
+		inst := s.pathInstance(w, r)
+		if inst == nil {
+			return
+		}
+		if inst.Status == linodego.InstanceRunning {
+			writeError(w, http.StatusBadRequest, "", "Linode is already booted.")
+
+			return
+		}
+		inst.Status = linodego.InstanceRunning
+		s.recordInstanceEvent(linodego.ActionLinodeBoot, inst, nil)
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+
+	s.handle("GET /linode/instances/{id}/ips", func(w http.ResponseWriter, r *http.Request) {
+		inst := s.pathInstance(w, r)
+		if inst == nil {
+			return
+		}
+		writeJSON(w, http.StatusOK, s.instanceIPs(inst))
+	})
+
+	s.handle("GET /linode/instances/{id}/configs", func(w http.ResponseWriter, r *http.Request) {
+		inst := s.pathInstance(w, r)
+		if inst == nil {
+			return
+		}
+		writeList(w, r, inst.configs)
+	})
+
+	s.handle("PUT /linode/instances/{id}/configs/{configID}", func(w http.ResponseWriter, r *http.Request) {
+		inst := s.pathInstance(w, r)
+		if inst == nil {
+			return
+		}
+		configID, _ := pathID(r, "configID")
+		idx := slices.IndexFunc(inst.configs, func(c *linodego.InstanceConfig) bool { return c.ID == configID })
+		if idx < 0 {
+			writeNotFound(w)
+
+			return
+		}
+
+		var opts linodego.InstanceConfigUpdateOptions
+		if !decode(w, r, &opts) {
+			return
+		}
+		config := inst.configs[idx]
+		if opts.Label != "" {
+			config.Label = opts.Label
+		}
+		if opts.Devices != nil {
+			config.Devices = opts.Devices
+		}
+		if opts.Interfaces != nil {
+			s.detachFromSubnets(inst.ID)
+			interfaces, field, err := s.buildInterfaces(inst, opts.Interfaces)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, field, err.Error())
+
+				return
+			}
+			config.Interfaces = interfaces
+		}
+		if opts.Kernel != "" {
+			config.Kernel = opts.Kernel
+		}
+		if opts.RootDevice != "" {
+			config.RootDevice = opts.RootDevice
+		}
+		config.Comments = opts.Comments
+		config.MemoryLimit = opts.MemoryLimit
+		writeJSON(w, http.StatusOK, config)
+	})
+
+	s.handle("GET /linode/instances/{id}/disks", func(w http.ResponseWriter, r *http.Request) {
+		inst := s.pathInstance(w, r)
+		if inst == nil {
+			return
+		}
+		writeList(w, r, inst.disks)
+	})
+
+	s.handle("POST /linode/instances/{id}/disks", func(w http.ResponseWriter, r *http.Request) {
+		inst := s.pathInstance(w, r)
+		if inst == nil {
+			return
+		}
+		var opts linodego.InstanceDiskCreateOptions
+		if !decode(w, r, &opts) {
+			return
+		}
+		if opts.Size > inst.freeDisk() {
+			writeError(w, http.StatusBadRequest, "size", "Insufficient space for disk")
+
+			return
+		}
+		filesystem := linodego.DiskFilesystem(opts.Filesystem)
+		if filesystem == "" {
+			filesystem = linodego.FilesystemExt4
+		}
+		disk := &linodego.InstanceDisk{
+			ID:         s.newID(),
+			Label:      opts.Label,
+			Status:     linodego.DiskReady,
+			Size:       opts.Size,
+			Filesystem: filesystem,
+		}
+		inst.disks = append(inst.disks, disk)
+		s.recordInstanceEvent(linodego.ActionDiskCreate, inst, disk)
+		writeJSON(w, http.StatusOK, disk)
+	})
+
+	s.handle("GET /linode/instances/{id}/disks/{diskID}", func(w http.ResponseWriter, r *http.Request) {
+		disk := s.pathDisk(w, r)
+		if disk == nil {
+			return
+		}
+		writeJSON(w, http.StatusOK, disk)
+	})
+
+	s.handle("POST /linode/instances/{id}/disks/{diskID}/resize", func(w http.ResponseWriter, r *http.Request) {
+		disk := s.pathDisk(w, r)
+		if disk == nil {
+			return
+		}
+		var opts struct {
+			Size int `json:"size"`
+		}
+		if !decode(w, r, &opts) {
+			return
+		}
+		inst := s.instances[mustPathID(r, "id")]
+		if opts.Size-disk.Size > inst.freeDisk() {
+			writeError(w, http.StatusBadRequest, "size", "Insufficient space for resize")
+
+			return
+		}
+		disk.Size = opts.Size
+		s.recordInstanceEvent(linodego.ActionDiskResize, inst, disk)
+		writeJSON(w, http.StatusOK, disk)
+	})
+}
+
+// createInstance creates a Linode. On failure, it returns the name of the invalid field along with the error.
+func (s *Server) createInstance(opts linodego.InstanceCreateOptions) (*instance, string, error) {
+	region, ok := s.regions[opts.Region]
+	if !ok {
+		return nil, "region", fmt.Errorf("region %q not found", opts.Region)
+	}
+	linodeType, ok := s.types[opts.Type]
+	if !ok {
+		return nil, "type", fmt.Errorf("type %q not found", opts.Type)
+	}
+	if opts.Image != "" {
+		if _, ok := s.images[opts.Image]; !ok {
+			return nil, "image", fmt.Errorf("image %q not found", opts.Image)
+		}
+	}
+
+	id := s.newID()
+	label := opts.Label
+	if label == "" {
+		label = fmt.Sprintf("linode%d", id)
+	}
+	for _, other := range s.instances {
+		if other.Label == label {
+			return nil, "label", fmt.Errorf("label %q must be unique among your Linodes", label)
+		}
+	}
+
+	publicIPv4 := net.ParseIP(addrAt(publicIPv4Pool, id).String())
+	inst := &instance{
+		Instance: linodego.Instance{
+			ID:     id,
+			Region: region.ID,
+			Image:  opts.Image,
+			Group:  opts.Group,
+			IPv4:   []*net.IP{&publicIPv4},
+			IPv6:   fmt.Sprintf("2600:3c00::f03c:91ff:fe%02x:%04x/128", id>>16&0xff, id&0xffff),
+			Label:  label,
+			Type:   linodeType.ID,
+			Status: linodego.InstanceOffline,
+			Specs: &linodego.InstanceSpec{
+				Disk:     linodeType.Disk,
+				Memory:   linodeType.Memory,
+				VCPUs:    linodeType.VCPUs,
+				Transfer: linodeType.Transfer,
+				GPUs:     linodeType.GPUs,
+			},
+			HasUserData: opts.Metadata != nil && opts.Metadata.UserData != "",
+			Tags:        slices.Clone(opts.Tags),
+		},
+	}
+	if inst.Tags == nil {
+		inst.Tags = []string{}
+	}
+	if opts.PrivateIP {
+		inst.privateIPv4 = addrAt(privateIPv4Pool, id).String()
+	}
+
+	if opts.Image != "" {
+		swapSize := defaultSwapSize
+		if opts.SwapSize != nil {
+			swapSize = *opts.SwapSize
+		}
+		root := &linodego.InstanceDisk{
+			ID:         s.newID(),
+			Label:      s.images[opts.Image].Label + " Disk",
+			Status:     linodego.DiskReady,
+			Size:       linodeType.Disk - swapSize,
+			Filesystem: linodego.FilesystemExt4,
+		}
+		swap := &linodego.InstanceDisk{
+			ID:         s.newID(),
+			Label:      fmt.Sprintf("%d MB Swap Image", swapSize),
+			Status:     linodego.DiskReady,
+			Size:       swapSize,
+			Filesystem: linodego.FilesystemSwap,
+		}
+		inst.disks = []*linodego.InstanceDisk{root, swap}
+
+		interfaces, field, err := s.buildInterfaces(inst, opts.Interfaces)
+		if err != nil {
+			return nil, field, err
+		}
+		inst.configs = []*linodego.InstanceConfig{{
+			ID:    s.newID(),
+			Label: fmt.Sprintf("My %s Disk Profile", s.images[opts.Image].Label),
+			Devices: &linodego.InstanceConfigDeviceMap{
+				SDA: &linodego.InstanceConfigDevice{DiskID: root.ID},
+				SDB: &linodego.InstanceConfigDevice{DiskID: swap.ID},
+			},
+			Helpers:    &linodego.InstanceConfigHelpers{},
+			Interfaces: interfaces,
+			Kernel:     "linode/grub2",
+			RootDevice: "/dev/sda",
+			RunLevel:   "default",
+			VirtMode:   "paravirt",
+		}}
+
+		if opts.Booted == nil || *opts.Booted {
+			inst.Status = linodego.InstanceRunning
+		}
+	}
+
+	s.instances[id] = inst
+
+	return inst, "", nil
+}
+
+// buildInterfaces creates the interfaces of a config, attaching the Linode to any VPC subnets. On failure, it
+// returns the name of the invalid field along with the error.
+func (s *Server) buildInterfaces(inst *instance, opts []linodego.InstanceConfigInterfaceCreateOptions) ([]linodego.InstanceConfigInterface, string, error) {
+	interfaces := make([]linodego.InstanceConfigInterface, 0, len(opts))
+	for i, opt := range opts {
+		iface := linodego.InstanceConfigInterface{
+			ID:          s.newID(),
+			IPAMAddress: opt.IPAMAddress,
+			Label:       opt.Label,
+			Purpose:     opt.Purpose,
+			Primary:     opt.Primary,
+			Active:      true,
+			IPRanges:    opt.IPRanges,
+		}
+
+		if opt.Purpose == linodego.InterfacePurposeVPC {
+			field := fmt.Sprintf("interfaces[%d].subnet_id", i)
+			if opt.SubnetID == nil {
+				return nil, field, fmt.Errorf("subnet_id is required")
+			}
+			vpc, subnet := s.findSubnet(*opt.SubnetID)
+			if subnet == nil {
+				return nil, field, fmt.Errorf("subnet %d not found", *opt.SubnetID)
+			}
+			if vpc.Region != inst.Region {
+				return nil, field, fmt.Errorf("subnet %d is not in region %s", *opt.SubnetID, inst.Region)
+			}
+
+			address := ""
+			if opt.IPv4 != nil && opt.IPv4.VPC != "" {
+				address = opt.IPv4.VPC
+			} else {
+				next, err := s.nextSubnetAddress(subnet)
+				if err != nil {
+					return nil, field, err
+				}
+				address = next
+			}
+
+			iface.VPCID = &vpc.ID
+			iface.SubnetID = &subnet.ID
+			iface.IPv4 = &linodego.VPCIPv4{VPC: address}
+			if opt.IPv4 != nil {
+				iface.IPv4.NAT1To1 = opt.IPv4.NAT1To1
+			}
+
+			subnet.Linodes = append(subnet.Linodes, linodego.VPCSubnetLinode{
+				ID:         inst.ID,
+				Interfaces: []linodego.VPCSubnetLinodeInterface{{ID: iface.ID, Active: true}},
+			})
+		}
+
+		interfaces = append(interfaces, iface)
+	}
+
+	return interfaces, "", nil
+}
+
+func (s *Server) instanceIPs(inst *instance) *linodego.InstanceIPAddressResponse {
+	ipv4 := &linodego.InstanceIPv4Response{
+		Public: []*linodego.InstanceIP{{
+			Address:    inst.IPv4[0].String(),
+			Gateway:    addrAt(publicIPv4Pool, 1).String(),
+			SubnetMask: "255.255.255.0",
+			Prefix:     24,
+			Type:       linodego.IPTypeIPv4,
+			Public:     true,
+			LinodeID:   inst.ID,
+			Region:     inst.Region,
+		}},
+		Private:  []*linodego.InstanceIP{},
+		Shared:   []*linodego.InstanceIP{},
+		Reserved: []*linodego.InstanceIP{},
+		VPC:      []*linodego.VPCIP{},
+	}
+	if inst.privateIPv4 != "" {
+		ipv4.Private = append(ipv4.Private, &linodego.InstanceIP{
+			Address:    inst.privateIPv4,
+			SubnetMask: "255.255.128.0",
+			Prefix:     17,
+			Type:       linodego.IPTypeIPv4,
+			LinodeID:   inst.ID,
+			Region:     inst.Region,
+		})
+	}
+	for _, config := range inst.configs {
+		for _, iface := range config.Interfaces {
+			if iface.VPCID == nil || iface.IPv4 == nil {
+				continue
+			}
+			address := iface.IPv4.VPC
+			ipv4.VPC = append(ipv4.VPC, &linodego.VPCIP{
+				Address:     &address,
+				LinodeID:    inst.ID,
+				Region:      inst.Region,
+				Active:      iface.Active,
+				NAT1To1:     iface.IPv4.NAT1To1,
+				VPCID:       *iface.VPCID,
+				SubnetID:    *iface.SubnetID,
+				ConfigID:    config.ID,
+				InterfaceID: iface.ID,
+			})
+		}
+	}
+
+	slaac, _, _ := net.ParseCIDR(inst.IPv6)
+
+	return &linodego.InstanceIPAddressResponse{
+		IPv4: ipv4,
+		IPv6: &linodego.InstanceIPv6Response{
+			SLAAC: &linodego.InstanceIP{
+				Address:  slaac.String(),
+				Prefix:   64,
+				Type:     linodego.IPTypeIPv6,
+				Public:   true,
+				LinodeID: inst.ID,
+				Region:   inst.Region,
+			},
+			Global: []linodego.IPv6Range{},
+		},
+	}
+}
+
+// freeDisk returns the disk space not allocated to any of the Linode's disks.
+func (i *instance) freeDisk() int {
+	free := i.Specs.Disk
+	for _, disk := range i.disks {
+		free -= disk.Size
+	}
+
+	return free
+}
+
+// pathInstance returns the Linode identified by the request path, writing a 404 response if there is none.
+func (s *Server) pathInstance(w http.ResponseWriter, r *http.Request) *instance {
+	id, _ := pathID(r, "id")
+	inst, ok := s.instances[id]
+	if !ok {
+		writeNotFound(w)
+
+		return nil
+	}
+
+	return inst
+}
+
+// pathDisk returns the disk identified by the request path, writing a 404 response if there is none.
+func (s *Server) pathDisk(w http.ResponseWriter, r *http.Request) *linodego.InstanceDisk {
+	inst := s.pathInstance(w, r)
+	if inst == nil {
+		return nil
+	}
+	diskID, _ := pathID(r, "diskID")
+	idx := slices.IndexFunc(inst.disks, func(d *linodego.InstanceDisk) bool { return d.ID == diskID })
+	if idx < 0 {
+		writeNotFound(w)
+
+		return nil
+	}
+
+	return inst.disks[idx]
+}
+
+func mustPathID(r *http.Request, name string) int {
+	id, _ := pathID(r, name)
+
+	return id
+}
+
+// addrAt returns the nth address of an IPv4 prefix, wrapping around if n exceeds its size.
+func addrAt(prefix string, n int) netip.Addr {
+	p := netip.MustParsePrefix(prefix)
+	base := p.Addr().As4()
+	size := uint32(1) << (32 - p.Bits())
+	offset := uint32(n)%(size-2) + 1
+
+	v := (uint32(base[0])<<24 | uint32(base[1])<<16 | uint32(base[2])<<8 | uint32(base[3])) + offset
+
+	return netip.AddrFrom4([4]byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)})
+}
@@ -0,0 +1,309 @@
+package fakelinode
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/linode/linodego"
+)
+
+// nodeBalancer is a NodeBalancer along with its configs and their nodes.
+type nodeBalancer struct {
+	linodego.NodeBalancer
+
+	configs []*nodeBalancerConfig
+}
+
+type nodeBalancerConfig struct {
+	linodego.NodeBalancerConfig
+
+	nodes []*linodego.NodeBalancerNode
+}
+
+// NodeBalancers returns a snapshot of all NodeBalancers.
+func (s *Server) NodeBalancers() []linodego.NodeBalancer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.listNodeBalancers()
+}
+
+// NodeBalancerNodes returns a snapshot of the nodes of a NodeBalancer config.
+func (s *Server) NodeBalancerNodes(nodeBalancerID, configID int) []linodego.NodeBalancerNode {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nb, ok := s.nodeBalancers[nodeBalancerID]
+	if !ok {
+		return nil
+	}
+	config := nb.config(configID)
+	if config == nil {
+		return nil
+	}
+
+	nodes := make([]linodego.NodeBalancerNode, 0, len(config.nodes))
+	for _, node := range config.nodes {
+		nodes = append(nodes, *node)
+	}
+
+	return nodes
+}
+
+func (s *Server) listNodeBalancers() []linodego.NodeBalancer {
+	nodeBalancers := make([]linodego.NodeBalancer, 0, len(s.nodeBalancers))
+	for _, id := range sortedKeys(s.nodeBalancers) {
+		nodeBalancers = append(nodeBalancers, s.nodeBalancers[id].NodeBalancer)
+	}
+
+	return nodeBalancers
+}
+
+func (nb *nodeBalancer) config(configID int) *nodeBalancerConfig {
+	idx := slices.IndexFunc(nb.configs, func(c *nodeBalancerConfig) bool { return c.ID == configID })
+	if idx < 0 {
+		return nil
+	}
+
+	return nb.configs[idx]
+}
+
+//nolint:cyclop // Route registration for the whole resource lives in one place.
+func (s *Server) registerNodeBalancers() {
+	s.handle("GET /nodebalancers", func(w http.ResponseWriter, r *http.Request) {
+		writeList(w, r, s.listNodeBalancers())
+	})
+
+	s.handle("POST /nodebalancers", func(w http.ResponseWriter, r *http.Request) {
+		var opts linodego.NodeBalancerCreateOptions
+		if !decode(w, r, &opts) {
+			return
+		}
+		if _, ok := s.regions[opts.Region]; !ok {
+			writeError(w, http.StatusBadRequest, "region", fmt.Sprintf("region %q not found", opts.Region))
+
+			return
+		}
+
+		id := s.newID()
+		label := fmt.Sprintf("nodebalancer%d", id)
+		if opts.Label != nil && *opts.Label != "" {
+			label = *opts.Label
+		}
+		for _, other := range s.nodeBalancers {
+			if *other.Label == label {
+				writeError(w, http.StatusBadRequest, "label", "Label must be unique among your NodeBalancers")
+
+				return
+			}
+		}
+
+		ipv4 := addrAt(publicIPv4Pool, id).String()
+		hostname := fmt.Sprintf("nb-%s.%s.nodebalancer.linode.com", strings.ReplaceAll(ipv4, ".", "-"), opts.Region)
+		ipv6 := fmt.Sprintf("2600:3c00:1::%x", id)
+		throttle := 0
+		if opts.ClientConnThrottle != nil {
+			throttle = *opts.ClientConnThrottle
+		}
+		tags := slices.Clone(opts.Tags)
+		if tags == nil {
+			tags = []string{}
+		}
+
+		nb := &nodeBalancer{NodeBalancer: linodego.NodeBalancer{
+			ID:                 id,
+			Label:              &label,
+			Region:             opts.Region,
+			Hostname:           &hostname,
+			IPv4:               &ipv4,
+			IPv6:               &ipv6,
+			ClientConnThrottle: throttle,
+			Tags:               tags,
+		}}
+		for _, configOpts := range opts.Configs {
+			s.addNodeBalancerConfig(nb, *configOpts)
+		}
+		s.nodeBalancers[id] = nb
+		writeJSON(w, http.StatusOK, nb.NodeBalancer)
+	})
+
+	s.handle("GET /nodebalancers/{id}", func(w http.ResponseWriter, r *http.Request) {
+		nb := s.pathNodeBalancer(w, r)
+		if nb == nil {
+			return
+		}
+		writeJSON(w, http.StatusOK, nb.NodeBalancer)
+	})
+
+	s.handle("DELETE /nodebalancers/{id}", func(w http.ResponseWriter, r *http.Request) {
+		nb := s.pathNodeBalancer(w, r)
+		if nb == nil {
+			return
+		}
+		delete(s.nodeBalancers, nb.ID)
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+
+	s.handle("GET /nodebalancers/{id}/configs", func(w http.ResponseWriter, r *http.Request) {
+		nb := s.pathNodeBalancer(w, r)
+		if nb == nil {
+			return
+		}
+		configs := make([]linodego.NodeBalancerConfig, 0, len(nb.configs))
+		for _, config := range nb.configs {
+			configs = append(configs, config.NodeBalancerConfig)
+		}
+		writeList(w, r, configs)
+	})
+
+	s.handle("POST /nodebalancers/{id}/configs", func(w http.ResponseWriter, r *http.Request) {
+		nb := s.pathNodeBalancer(w, r)
+		if nb == nil {
+			return
+		}
+		var opts linodego.NodeBalancerConfigCreateOptions
+		if !decode(w, r, &opts) {
+			return
+		}
+		for _, config := range nb.configs {
+			if config.Port == opts.Port {
+				writeError(w, http.StatusBadRequest, "port", "Port is already in use by another config")
+
+				return
+			}
+		}
+		config := s.addNodeBalancerConfig(nb, opts)
+		writeJSON(w, http.StatusOK, config.NodeBalancerConfig)
+	})
+
+	s.handle("GET /nodebalancers/{id}/configs/{configID}/nodes", func(w http.ResponseWriter, r *http.Request) {
+		config := s.pathNodeBalancerConfig(w, r)
+		if config == nil {
+			return
+		}
+		writeList(w, r, config.nodes)
+	})
+
+	s.handle("POST /nodebalancers/{id}/configs/{configID}/nodes", func(w http.ResponseWriter, r *http.Request) {
+		config := s.pathNodeBalancerConfig(w, r)
+		if config == nil {
+			return
+		}
+		var opts linodego.NodeBalancerNodeCreateOptions
+		if !decode(w, r, &opts) {
+			return
+		}
+		for _, node := range config.nodes {
+			if node.Address == opts.Address {
+				writeError(w, http.StatusBadRequest, "address", "This address is already in use by another node on this config")
+
+				return
+			}
+		}
+		writeJSON(w, http.StatusOK, addNodeBalancerNode(s.newID(), config, opts))
+	})
+
+	s.handle("DELETE /nodebalancers/{id}/configs/{configID}/nodes/{nodeID}", func(w http.ResponseWriter, r *http.Request) {
+		config := s.pathNodeBalancerConfig(w, r)
+		if config == nil {
+			return
+		}
+		nodeID, _ := pathID(r, "nodeID")
+		idx := slices.IndexFunc(config.nodes, func(n *linodego.NodeBalancerNode) bool { return n.ID == nodeID })
+		if idx < 0 {
+			writeNotFound(w)
+
+			return
+		}
+		config.nodes = slices.Delete(config.nodes, idx, idx+1)
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+}
+
+func (s *Server) addNodeBalancerConfig(nb *nodeBalancer, opts linodego.NodeBalancerConfigCreateOptions) *nodeBalancerConfig {
+	config := &nodeBalancerConfig{NodeBalancerConfig: linodego.NodeBalancerConfig{
+		ID:             s.newID(),
+		Port:           opts.Port,
+		Protocol:       defaulted(opts.Protocol, linodego.ProtocolHTTP),
+		ProxyProtocol:  defaulted(opts.ProxyProtocol, linodego.ProxyProtocolNone),
+		Algorithm:      defaulted(opts.Algorithm, linodego.AlgorithmRoundRobin),
+		Stickiness:     defaulted(opts.Stickiness, linodego.StickinessNone),
+		Check:          defaulted(opts.Check, linodego.CheckNone),
+		CheckInterval:  opts.CheckInterval,
+		CheckAttempts:  opts.CheckAttempts,
+		CheckPath:      opts.CheckPath,
+		CheckBody:      opts.CheckBody,
+		CheckPassive:   opts.CheckPassive == nil || *opts.CheckPassive,
+		CheckTimeout:   opts.CheckTimeout,
+		CipherSuite:    defaulted(opts.CipherSuite, linodego.CipherRecommended),
+		NodeBalancerID: nb.ID,
+		NodesStatus:    &linodego.NodeBalancerNodeStatus{},
+	}}
+	for _, nodeOpts := range opts.Nodes {
+		addNodeBalancerNode(s.newID(), config, nodeOpts)
+	}
+	nb.configs = append(nb.configs, config)
+
+	return config
+}
+
+func addNodeBalancerNode(id int, config *nodeBalancerConfig, opts linodego.NodeBalancerNodeCreateOptions) *linodego.NodeBalancerNode {
+	node := &linodego.NodeBalancerNode{
+		ID:             id,
+		Address:        opts.Address,
+		Label:          opts.Label,
+		Status:         "UP",
+		Weight:         defaulted(opts.Weight, 100),
+		Mode:           defaulted(opts.Mode, linodego.ModeAccept),
+		ConfigID:       config.ID,
+		NodeBalancerID: config.NodeBalancerID,
+	}
+	config.nodes = append(config.nodes, node)
+	config.NodesStatus.Up = len(config.nodes)
+
+	return node
+}
+
+// pathNodeBalancer returns the NodeBalancer identified by the request path, writing a 404 response if there is
+// none.
+func (s *Server) pathNodeBalancer(w http.ResponseWriter, r *http.Request) *nodeBalancer {
+	id, _ := pathID(r, "id")
+	nb, ok := s.nodeBalancers[id]
+	if !ok {
+		writeNotFound(w)
+
+		return nil
+	}
+
+	return nb
+}
+
+// pathNodeBalancerConfig returns the NodeBalancer config identified by the request path, writing a 404 response
+// if there is none.
+func (s *Server) pathNodeBalancerConfig(w http.ResponseWriter, r *http.Request) *nodeBalancerConfig {
+	nb := s.pathNodeBalancer(w, r)
+	if nb == nil {
+		return nil
+	}
+	configID, _ := pathID(r, "configID")
+	config := nb.config(configID)
+	if config == nil {
+		writeNotFound(w)
+
+		return nil
+	}
+
+	return config
+}
+
+// defaulted returns value, or def if value is the zero value.
+func defaulted[T comparable](value, def T) T {
+	var zero T
+	if value == zero {
+		return def
+	}
+
+	return value
+}
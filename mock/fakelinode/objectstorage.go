@@ -0,0 +1,160 @@
+package fakelinode
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/linode/linodego"
+)
+
+// clusterPattern matches Object Storage cluster IDs, capturing the region.
+var clusterPattern = regexp.MustCompile(`^([a-z]+(?:-[a-z]+)*)-\d+$`)
+
+// ObjectStorageKeys returns a snapshot of all Object Storage keys.
+func (s *Server) ObjectStorageKeys() []linodego.ObjectStorageKey {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.listObjectStorageKeys()
+}
+
+func (s *Server) listObjectStorageKeys() []linodego.ObjectStorageKey {
+	keys := make([]linodego.ObjectStorageKey, 0, len(s.keys))
+	for _, id := range sortedKeys(s.keys) {
+		keys = append(keys, *s.keys[id])
+	}
+
+	return keys
+}
+
+//nolint:cyclop // Route registration for the whole resource lives in one place.
+func (s *Server) registerObjectStorage() {
+	s.handle("GET /object-storage/buckets", func(w http.ResponseWriter, r *http.Request) {
+		buckets := make([]linodego.ObjectStorageBucket, 0, len(s.buckets))
+		for _, key := range sortedKeys(s.buckets) {
+			buckets = append(buckets, *s.buckets[key])
+		}
+		writeList(w, r, buckets)
+	})
+
+	s.handle("POST /object-storage/buckets", func(w http.ResponseWriter, r *http.Request) {
+		var opts linodego.ObjectStorageBucketCreateOptions
+		if !decode(w, r, &opts) {
+			return
+		}
+		if !s.validCluster(opts.Cluster) {
+			writeError(w, http.StatusBadRequest, "cluster", fmt.Sprintf("cluster %q not found", opts.Cluster))
+
+			return
+		}
+		if _, ok := s.buckets[opts.Cluster+"/"+opts.Label]; ok {
+			writeError(w, http.StatusBadRequest, "label", "A bucket with this label already exists")
+
+			return
+		}
+
+		bucket := &linodego.ObjectStorageBucket{
+			Label:    opts.Label,
+			Cluster:  opts.Cluster,
+			Hostname: fmt.Sprintf("%s.%s.linodeobjects.com", opts.Label, opts.Cluster),
+		}
+		s.buckets[opts.Cluster+"/"+opts.Label] = bucket
+		writeJSON(w, http.StatusOK, bucket)
+	})
+
+	s.handle("GET /object-storage/buckets/{cluster}/{label}", func(w http.ResponseWriter, r *http.Request) {
+		bucket, ok := s.buckets[r.PathValue("cluster")+"/"+r.PathValue("label")]
+		if !ok {
+			writeNotFound(w)
+
+			return
+		}
+		writeJSON(w, http.StatusOK, bucket)
+	})
+
+	s.handle("DELETE /object-storage/buckets/{cluster}/{label}", func(w http.ResponseWriter, r *http.Request) {
+		key := r.PathValue("cluster") + "/" + r.PathValue("label")
+		if _, ok := s.buckets[key]; !ok {
+			writeNotFound(w)
+
+			return
+		}
+		delete(s.buckets, key)
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+
+	s.handle("GET /object-storage/keys", func(w http.ResponseWriter, r *http.Request) {
+		writeList(w, r, s.listObjectStorageKeys())
+	})
+
+	s.handle("POST /object-storage/keys", func(w http.ResponseWriter, r *http.Request) {
+		var opts linodego.ObjectStorageKeyCreateOptions
+		if !decode(w, r, &opts) {
+			return
+		}
+		if opts.BucketAccess != nil {
+			for i, access := range *opts.BucketAccess {
+				if _, ok := s.buckets[access.Cluster+"/"+access.BucketName]; !ok {
+					writeError(w, http.StatusBadRequest, fmt.Sprintf("bucket_access[%d]", i), "bucket not found")
+
+					return
+				}
+			}
+		}
+
+		id := s.newID()
+		secret := sha256.Sum256([]byte(fmt.Sprintf("fake-secret-%d", id)))
+		key := &linodego.ObjectStorageKey{
+			ID:           id,
+			Label:        opts.Label,
+			AccessKey:    fmt.Sprintf("FAKEACCESSKEY%07d", id),
+			SecretKey:    fmt.Sprintf("%x", secret)[:40],
+			Limited:      opts.BucketAccess != nil,
+			BucketAccess: opts.BucketAccess,
+		}
+		s.keys[id] = key
+		writeJSON(w, http.StatusOK, key)
+	})
+
+	s.handle("GET /object-storage/keys/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := pathID(r, "id")
+		key, ok := s.keys[id]
+		if !ok {
+			writeNotFound(w)
+
+			return
+		}
+		// the secret is only ever returned on creation
+		redacted := *key
+		redacted.SecretKey = "[REDACTED]"
+		writeJSON(w, http.StatusOK, redacted)
+	})
+
+	s.handle("DELETE /object-storage/keys/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := pathID(r, "id")
+		if _, ok := s.keys[id]; !ok {
+			writeNotFound(w)
+
+			return
+		}
+		delete(s.keys, id)
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+}
+
+// validCluster reports whether cluster is the ID of an Object Storage cluster in a region that supports it.
+func (s *Server) validCluster(cluster string) bool {
+	match := clusterPattern.FindStringSubmatch(cluster)
+	if match == nil {
+		return false
+	}
+	region, ok := s.regions[match[1]]
+	if !ok {
+		return false
+	}
+
+	return slices.Contains(region.Capabilities, "Object Storage")
+}
@@ -0,0 +1,205 @@
+// Package fakelinode provides a stateful, in-memory fake of the Linode API endpoints used by CAPL.
+//
+// A [Server] is an [http.Handler] meant to be served by an [net/http/httptest.Server]. Clients are pointed at it
+// by setting their base URL, e.g. with [NewClient]:
+//
+//	fake := fakelinode.New()
+//	srv := httptest.NewServer(fake)
+//	defer srv.Close()
+//
+//	client := fakelinode.NewClient(srv.URL)
+//
+// Faults such as latency, rate limiting and server errors can be injected with [Server.InjectFault].
+package fakelinode
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/linode/linodego"
+)
+
+const (
+	// apiVersion is the Linode API version used by clients created with [NewClient].
+	apiVersion = "v4"
+
+	defaultPageSize = 100
+	maxPageSize     = 500
+)
+
+// Server is a fake Linode API. It is safe for concurrent use.
+type Server struct {
+	mu  sync.Mutex
+	mux *http.ServeMux
+
+	nextID int
+	faults []*Fault
+
+	regions map[string]*linodego.Region
+	images  map[string]*linodego.Image
+	types   map[string]*linodego.LinodeType
+
+	instances     map[int]*instance
+	nodeBalancers map[int]*nodeBalancer
+	vpcs          map[int]*linodego.VPC
+	stackscripts  map[int]*linodego.Stackscript
+	buckets       map[string]*linodego.ObjectStorageBucket
+	keys          map[int]*linodego.ObjectStorageKey
+	events        []*event
+}
+
+// New returns an empty fake Linode API, seeded with a small catalog of regions, images and types.
+func New() *Server {
+	s := &Server{
+		mux:           http.NewServeMux(),
+		nextID:        1000,
+		regions:       map[string]*linodego.Region{},
+		images:        map[string]*linodego.Image{},
+		types:         map[string]*linodego.LinodeType{},
+		instances:     map[int]*instance{},
+		nodeBalancers: map[int]*nodeBalancer{},
+		vpcs:          map[int]*linodego.VPC{},
+		stackscripts:  map[int]*linodego.Stackscript{},
+		buckets:       map[string]*linodego.ObjectStorageBucket{},
+		keys:          map[int]*linodego.ObjectStorageKey{},
+	}
+	s.seedCatalog()
+
+	s.registerCatalog()
+	s.registerInstances()
+	s.registerNodeBalancers()
+	s.registerVPCs()
+	s.registerStackscripts()
+	s.registerObjectStorage()
+	s.registerEvents()
+
+	return s
+}
+
+// NewClient returns a Linode client for the fake API served at baseURL, e.g. the URL of an
+// [net/http/httptest.Server]. Automatic retries are disabled so injected faults surface immediately.
+func NewClient(baseURL string) *linodego.Client {
+	client := linodego.NewClient(http.DefaultClient)
+	client.SetBaseURL(baseURL)
+	client.SetAPIVersion(apiVersion)
+	client.SetToken("fake")
+	client.SetRetryCount(0)
+
+	return &client
+}
+
+// ServeHTTP serves a request to the fake API. The leading API version segment of the path (e.g. /v4 or
+// /v4beta) is ignored.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if fault := s.matchFault(r); fault != nil {
+		if !fault.apply(w, r) {
+			return
+		}
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	if _, rest, found := strings.Cut(path, "/"); found && strings.HasPrefix(path, "v4") {
+		path = rest
+	}
+	r.URL.Path = "/" + path
+	r.URL.RawPath = ""
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// handle registers handler for pattern, serializing it with all other requests to the fake.
+func (s *Server) handle(pattern string, handler func(w http.ResponseWriter, r *http.Request)) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		handler(w, r)
+	})
+}
+
+// newID returns a new unique ID. Callers must hold s.mu.
+func (s *Server) newID() int {
+	s.nextID++
+
+	return s.nextID
+}
+
+// pathID parses the named integer path value of r.
+func pathID(r *http.Request, name string) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue(name))
+
+	return id, err == nil
+}
+
+// decode reads the JSON request body into v, writing a 400 response if it is malformed.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "", "invalid request body: "+err.Error())
+
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a Linode API error response.
+func writeError(w http.ResponseWriter, status int, field, reason string) {
+	writeJSON(w, status, linodego.APIError{Errors: []linodego.APIErrorReason{{Field: field, Reason: reason}}})
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "", "Not found")
+}
+
+// writeList writes a page of items that match the X-Filter header of r.
+func writeList[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	items, err := filterItems(items, r.Header.Get("X-Filter"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "X-Filter", err.Error())
+
+		return
+	}
+
+	page, pageSize := 1, defaultPageSize
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && p > 0 {
+		page = p
+	}
+	if p, err := strconv.Atoi(r.URL.Query().Get("page_size")); err == nil && p > 0 {
+		pageSize = min(p, maxPageSize)
+	}
+
+	pages := max((len(items)+pageSize-1)/pageSize, 1)
+	start := min((page-1)*pageSize, len(items))
+	end := min(start+pageSize, len(items))
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data":    items[start:end],
+		"page":    page,
+		"pages":   pages,
+		"results": len(items),
+	})
+}
+
+// sortedKeys returns the keys of m in ascending order, so that listings are stable.
+func sortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,311 @@
+package fakelinode
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linode/cluster-api-provider-linode/cloud/events"
+)
+
+func newTestClient(t *testing.T) (*Server, *linodego.Client) {
+	t.Helper()
+
+	fake := New()
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	return fake, NewClient(srv.URL)
+}
+
+func TestInstances(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fake, client := newTestClient(t)
+
+	inst, err := client.CreateInstance(ctx, linodego.InstanceCreateOptions{
+		Region:    "us-ord",
+		Type:      "g6-standard-1",
+		Label:     "test-instance",
+		Image:     "linode/ubuntu22.04",
+		Tags:      []string{"test-cluster"},
+		PrivateIP: true,
+		Booted:    &[]bool{false}[0],
+	})
+	require.NoError(t, err)
+	assert.Equal(t, linodego.InstanceOffline, inst.Status)
+
+	_, err = client.CreateInstance(ctx, linodego.InstanceCreateOptions{Region: "us-ord", Type: "g6-standard-1", Label: "test-instance"})
+	require.ErrorContains(t, err, "unique")
+
+	found, err := client.ListInstances(ctx, linodego.NewListOptions(1, `{"tags":"test-cluster"}`))
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, inst.ID, found[0].ID)
+
+	configs, err := client.ListInstanceConfigs(ctx, inst.ID, nil)
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	require.NotNil(t, configs[0].Devices.SDA)
+
+	root, err := client.GetInstanceDisk(ctx, inst.ID, configs[0].Devices.SDA.DiskID)
+	require.NoError(t, err)
+	assert.Equal(t, 51200-defaultSwapSize, root.Size)
+
+	_, err = client.CreateInstanceDisk(ctx, inst.ID, linodego.InstanceDiskCreateOptions{Label: "etcd", Size: 10240})
+	require.ErrorContains(t, err, "Insufficient space")
+	require.NoError(t, client.ResizeInstanceDisk(ctx, inst.ID, root.ID, root.Size-10240))
+	_, err = client.CreateInstanceDisk(ctx, inst.ID, linodego.InstanceDiskCreateOptions{Label: "etcd", Size: 10240})
+	require.NoError(t, err)
+
+	require.NoError(t, client.BootInstance(ctx, inst.ID, 0))
+	require.ErrorContains(t, client.BootInstance(ctx, inst.ID, 0), "already booted.")
+
+	ips, err := client.GetInstanceIPAddresses(ctx, inst.ID)
+	require.NoError(t, err)
+	assert.Len(t, ips.IPv4.Public, 1)
+	assert.Len(t, ips.IPv4.Private, 1)
+
+	require.NoError(t, client.DeleteInstance(ctx, inst.ID))
+	assert.Empty(t, fake.Instances())
+
+	_, err = client.GetInstance(ctx, inst.ID)
+	assert.True(t, linodego.ErrHasStatus(err, http.StatusNotFound))
+}
+
+func TestVPCs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, client := newTestClient(t)
+
+	vpc, err := client.CreateVPC(ctx, linodego.VPCCreateOptions{
+		Label:   "test-vpc",
+		Region:  "us-ord",
+		Subnets: []linodego.VPCSubnetCreateOptions{{Label: "default", IPv4: "10.0.0.0/24"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, vpc.Subnets, 1)
+
+	inst, err := client.CreateInstance(ctx, linodego.InstanceCreateOptions{
+		Region: "us-ord",
+		Type:   "g6-standard-1",
+		Image:  "linode/ubuntu22.04",
+		Interfaces: []linodego.InstanceConfigInterfaceCreateOptions{
+			{Purpose: linodego.InterfacePurposePublic},
+			{Purpose: linodego.InterfacePurposeVPC, SubnetID: &vpc.Subnets[0].ID},
+		},
+	})
+	require.NoError(t, err)
+
+	configs, err := client.ListInstanceConfigs(ctx, inst.ID, nil)
+	require.NoError(t, err)
+	require.Len(t, configs[0].Interfaces, 2)
+	assert.Equal(t, "10.0.0.2", configs[0].Interfaces[1].IPv4.VPC)
+
+	vpc, err = client.GetVPC(ctx, vpc.ID)
+	require.NoError(t, err)
+	require.Len(t, vpc.Subnets[0].Linodes, 1)
+	assert.Equal(t, inst.ID, vpc.Subnets[0].Linodes[0].ID)
+
+	require.ErrorContains(t, client.DeleteVPC(ctx, vpc.ID), "active Linodes")
+	require.ErrorContains(t, client.DeleteVPCSubnet(ctx, vpc.ID, vpc.Subnets[0].ID), "active Linodes")
+
+	_, err = client.CreateVPCSubnet(ctx, linodego.VPCSubnetCreateOptions{Label: "overlap", IPv4: "10.0.0.128/25"}, vpc.ID)
+	require.ErrorContains(t, err, "overlap")
+	subnet, err := client.CreateVPCSubnet(ctx, linodego.VPCSubnetCreateOptions{Label: "extra", IPv4: "10.0.1.0/24"}, vpc.ID)
+	require.NoError(t, err)
+	require.NoError(t, client.DeleteVPCSubnet(ctx, vpc.ID, subnet.ID))
+	vpc, err = client.GetVPC(ctx, vpc.ID)
+	require.NoError(t, err)
+	require.Len(t, vpc.Subnets, 1)
+
+	require.NoError(t, client.DeleteInstance(ctx, inst.ID))
+	require.NoError(t, client.DeleteVPC(ctx, vpc.ID))
+
+	vpcs, err := client.ListVPCs(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, vpcs)
+}
+
+func TestNodeBalancers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fake, client := newTestClient(t)
+
+	nb, err := client.CreateNodeBalancer(ctx, linodego.NodeBalancerCreateOptions{
+		Label:  &[]string{"test-nb"}[0],
+		Region: "us-ord",
+		Tags:   []string{"test-cluster"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, nb.IPv4)
+
+	found, err := client.ListNodeBalancers(ctx, linodego.NewListOptions(1, `{"label":"test-nb"}`))
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+
+	config, err := client.CreateNodeBalancerConfig(ctx, nb.ID, linodego.NodeBalancerConfigCreateOptions{Port: 6443, Protocol: linodego.ProtocolTCP})
+	require.NoError(t, err)
+
+	node, err := client.CreateNodeBalancerNode(ctx, nb.ID, config.ID, linodego.NodeBalancerNodeCreateOptions{Address: "192.168.128.2:6443", Label: "node"})
+	require.NoError(t, err)
+	assert.Len(t, fake.NodeBalancerNodes(nb.ID, config.ID), 1)
+
+	require.NoError(t, client.DeleteNodeBalancerNode(ctx, nb.ID, config.ID, node.ID))
+	assert.Empty(t, fake.NodeBalancerNodes(nb.ID, config.ID))
+
+	require.NoError(t, client.DeleteNodeBalancer(ctx, nb.ID))
+	assert.Empty(t, fake.NodeBalancers())
+}
+
+func TestStackscripts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, client := newTestClient(t)
+
+	created, err := client.CreateStackscript(ctx, linodego.StackscriptCreateOptions{
+		Label:  "CAPL test",
+		Images: []string{"any/all"},
+		Script: "#!/bin/bash",
+	})
+	require.NoError(t, err)
+
+	found, err := client.ListStackscripts(ctx, linodego.NewListOptions(1, `{"label":"CAPL test","mine":true}`))
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, created.ID, found[0].ID)
+}
+
+func TestObjectStorage(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fake, client := newTestClient(t)
+
+	_, err := client.GetObjectStorageBucket(ctx, "us-ord-1", "test-bucket")
+	require.True(t, linodego.ErrHasStatus(err, http.StatusNotFound))
+
+	_, err = client.CreateObjectStorageBucket(ctx, linodego.ObjectStorageBucketCreateOptions{Cluster: "nowhere-1", Label: "test-bucket"})
+	require.ErrorContains(t, err, "not found")
+
+	bucket, err := client.CreateObjectStorageBucket(ctx, linodego.ObjectStorageBucketCreateOptions{Cluster: "us-ord-1", Label: "test-bucket"})
+	require.NoError(t, err)
+	assert.Equal(t, "test-bucket.us-ord-1.linodeobjects.com", bucket.Hostname)
+
+	_, err = client.GetObjectStorageBucket(ctx, "us-ord-1", "test-bucket")
+	require.NoError(t, err)
+
+	key, err := client.CreateObjectStorageKey(ctx, linodego.ObjectStorageKeyCreateOptions{
+		Label:        "test-bucket-rw",
+		BucketAccess: &[]linodego.ObjectStorageKeyBucketAccess{{Cluster: "us-ord-1", BucketName: "test-bucket", Permissions: "read_write"}},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, key.SecretKey)
+
+	got, err := client.GetObjectStorageKey(ctx, key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, key.AccessKey, got.AccessKey)
+
+	require.NoError(t, client.DeleteObjectStorageKey(ctx, key.ID))
+	assert.Empty(t, fake.ObjectStorageKeys())
+}
+
+func TestEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fake, client := newTestClient(t)
+
+	poller := events.NewPoller(client, time.Minute)
+	var dispatched []linodego.EventAction
+	poller.Subscribe(func(_ context.Context, event linodego.Event) {
+		dispatched = append(dispatched, event.Action)
+	})
+
+	inst, err := client.CreateInstance(ctx, linodego.InstanceCreateOptions{
+		Region: "us-ord",
+		Type:   "g6-standard-1",
+		Label:  "test-instance",
+		Image:  "linode/ubuntu22.04",
+		Booted: &[]bool{false}[0],
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.BootInstance(ctx, inst.ID, 0))
+	fake.AddEvent(linodego.Event{
+		Action: linodego.ActionLinodeReboot,
+		Status: linodego.EventFailed,
+		Entity: &linodego.EventEntity{ID: inst.ID, Label: inst.Label, Type: linodego.EntityLinode},
+	})
+
+	require.NoError(t, poller.Poll(ctx))
+	assert.Equal(t, []linodego.EventAction{linodego.ActionLinodeCreate, linodego.ActionLinodeBoot, linodego.ActionLinodeReboot}, dispatched)
+
+	found, err := client.ListEvents(ctx, linodego.NewListOptions(0, `{"action":"linode_boot"}`))
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, inst.ID, events.EntityID(found[0].Entity))
+	assert.NotNil(t, found[0].Created)
+}
+
+func TestFaults(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		fault    Fault
+		timeout  time.Duration
+		wantCode int
+	}{
+		{
+			name:     "Rate limited",
+			fault:    Fault{Method: http.MethodGet, Path: "/regions", StatusCode: http.StatusTooManyRequests, Times: 1},
+			wantCode: http.StatusTooManyRequests,
+		},
+		{
+			name:     "Server error",
+			fault:    Fault{Path: "/regions/us-ord", StatusCode: http.StatusServiceUnavailable, Times: 1},
+			wantCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:    "Latency",
+			fault:   Fault{Path: "/regions", Latency: time.Minute, Times: 1},
+			timeout: 50 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			fake, client := newTestClient(t)
+			fake.InjectFault(testcase.fault)
+
+			ctx := context.Background()
+			if testcase.timeout != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, testcase.timeout)
+				defer cancel()
+			}
+
+			_, err := client.GetRegion(ctx, "us-ord")
+			require.Error(t, err)
+			if testcase.wantCode != 0 {
+				assert.True(t, linodego.ErrHasStatus(err, testcase.wantCode), "unexpected error: %v", err)
+			}
+
+			// the fault only applies once
+			_, err = client.GetRegion(context.Background(), "us-ord")
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package fakelinode
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/linode/linodego"
+)
+
+func (s *Server) registerStackscripts() {
+	s.handle("GET /linode/stackscripts", func(w http.ResponseWriter, r *http.Request) {
+		stackscripts := make([]linodego.Stackscript, 0, len(s.stackscripts))
+		for _, id := range sortedKeys(s.stackscripts) {
+			stackscripts = append(stackscripts, *s.stackscripts[id])
+		}
+		writeList(w, r, stackscripts)
+	})
+
+	s.handle("POST /linode/stackscripts", func(w http.ResponseWriter, r *http.Request) {
+		var opts linodego.StackscriptCreateOptions
+		if !decode(w, r, &opts) {
+			return
+		}
+		if opts.Script == "" {
+			writeError(w, http.StatusBadRequest, "script", "script is required")
+
+			return
+		}
+
+		stackscript := &linodego.Stackscript{
+			ID:                s.newID(),
+			Username:          "fake",
+			Label:             opts.Label,
+			Description:       opts.Description,
+			Images:            slices.Clone(opts.Images),
+			IsPublic:          opts.IsPublic,
+			Mine:              true,
+			RevNote:           opts.RevNote,
+			Script:            opts.Script,
+			UserDefinedFields: &[]linodego.StackscriptUDF{},
+		}
+		s.stackscripts[stackscript.ID] = stackscript
+		writeJSON(w, http.StatusOK, stackscript)
+	})
+
+	s.handle("GET /linode/stackscripts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := pathID(r, "id")
+		stackscript, ok := s.stackscripts[id]
+		if !ok {
+			writeNotFound(w)
+
+			return
+		}
+		writeJSON(w, http.StatusOK, stackscript)
+	})
+}
@@ -0,0 +1,250 @@
+package fakelinode
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"slices"
+
+	"github.com/linode/linodego"
+)
+
+// VPCs returns a snapshot of all VPCs.
+func (s *Server) VPCs() []linodego.VPC {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.listVPCs()
+}
+
+func (s *Server) listVPCs() []linodego.VPC {
+	vpcs := make([]linodego.VPC, 0, len(s.vpcs))
+	for _, id := range sortedKeys(s.vpcs) {
+		vpc := *s.vpcs[id]
+		vpc.Subnets = slices.Clone(vpc.Subnets)
+		vpcs = append(vpcs, vpc)
+	}
+
+	return vpcs
+}
+
+func (s *Server) registerVPCs() {
+	s.handle("GET /vpcs", func(w http.ResponseWriter, r *http.Request) {
+		writeList(w, r, s.listVPCs())
+	})
+
+	s.handle("POST /vpcs", func(w http.ResponseWriter, r *http.Request) {
+		var opts linodego.VPCCreateOptions
+		if !decode(w, r, &opts) {
+			return
+		}
+		if _, ok := s.regions[opts.Region]; !ok {
+			writeError(w, http.StatusBadRequest, "region", fmt.Sprintf("region %q not found", opts.Region))
+
+			return
+		}
+		for _, vpc := range s.vpcs {
+			if vpc.Label == opts.Label {
+				writeError(w, http.StatusBadRequest, "label", "Label must be unique among your VPCs")
+
+				return
+			}
+		}
+
+		vpc := &linodego.VPC{
+			ID:          s.newID(),
+			Label:       opts.Label,
+			Description: opts.Description,
+			Region:      opts.Region,
+			Subnets:     make([]linodego.VPCSubnet, 0, len(opts.Subnets)),
+		}
+		for i, subnetOpts := range opts.Subnets {
+			if _, err := netip.ParsePrefix(subnetOpts.IPv4); err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("subnets[%d].ipv4", i), "Must be a valid IPv4 network")
+
+				return
+			}
+			vpc.Subnets = append(vpc.Subnets, linodego.VPCSubnet{
+				ID:      s.newID(),
+				Label:   subnetOpts.Label,
+				IPv4:    subnetOpts.IPv4,
+				Linodes: []linodego.VPCSubnetLinode{},
+			})
+		}
+		s.vpcs[vpc.ID] = vpc
+		writeJSON(w, http.StatusOK, vpc)
+	})
+
+	s.handle("GET /vpcs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := pathID(r, "id")
+		vpc, ok := s.vpcs[id]
+		if !ok {
+			writeNotFound(w)
+
+			return
+		}
+		writeJSON(w, http.StatusOK, vpc)
+	})
+
+	s.handle("PUT /vpcs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := pathID(r, "id")
+		vpc, ok := s.vpcs[id]
+		if !ok {
+			writeNotFound(w)
+
+			return
+		}
+		var opts linodego.VPCUpdateOptions
+		if !decode(w, r, &opts) {
+			return
+		}
+		if opts.Label != "" {
+			vpc.Label = opts.Label
+		}
+		if opts.Description != "" {
+			vpc.Description = opts.Description
+		}
+		writeJSON(w, http.StatusOK, vpc)
+	})
+
+	s.handle("DELETE /vpcs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := pathID(r, "id")
+		vpc, ok := s.vpcs[id]
+		if !ok {
+			writeNotFound(w)
+
+			return
+		}
+		for _, subnet := range vpc.Subnets {
+			if len(subnet.Linodes) != 0 {
+				writeError(w, http.StatusBadRequest, "", "Cannot delete a VPC with active Linodes")
+
+				return
+			}
+		}
+		delete(s.vpcs, id)
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+
+	s.handle("POST /vpcs/{id}/subnets", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := pathID(r, "id")
+		vpc, ok := s.vpcs[id]
+		if !ok {
+			writeNotFound(w)
+
+			return
+		}
+		var opts linodego.VPCSubnetCreateOptions
+		if !decode(w, r, &opts) {
+			return
+		}
+		prefix, err := netip.ParsePrefix(opts.IPv4)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "ipv4", "Must be a valid IPv4 network")
+
+			return
+		}
+		for _, subnet := range vpc.Subnets {
+			if subnet.Label == opts.Label {
+				writeError(w, http.StatusBadRequest, "label", "Label must be unique among the subnets of the VPC")
+
+				return
+			}
+			if other, err := netip.ParsePrefix(subnet.IPv4); err == nil && other.Overlaps(prefix) {
+				writeError(w, http.StatusBadRequest, "ipv4", "Must not overlap with the other subnets of the VPC")
+
+				return
+			}
+		}
+
+		subnet := linodego.VPCSubnet{
+			ID:      s.newID(),
+			Label:   opts.Label,
+			IPv4:    opts.IPv4,
+			Linodes: []linodego.VPCSubnetLinode{},
+		}
+		vpc.Subnets = append(vpc.Subnets, subnet)
+		writeJSON(w, http.StatusOK, subnet)
+	})
+
+	s.handle("DELETE /vpcs/{id}/subnets/{subnetID}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := pathID(r, "id")
+		subnetID, _ := pathID(r, "subnetID")
+		vpc, ok := s.vpcs[id]
+		if !ok {
+			writeNotFound(w)
+
+			return
+		}
+		i := slices.IndexFunc(vpc.Subnets, func(subnet linodego.VPCSubnet) bool { return subnet.ID == subnetID })
+		if i < 0 {
+			writeNotFound(w)
+
+			return
+		}
+		if len(vpc.Subnets[i].Linodes) != 0 {
+			writeError(w, http.StatusBadRequest, "", "Cannot delete a subnet with active Linodes")
+
+			return
+		}
+		vpc.Subnets = slices.Delete(vpc.Subnets, i, i+1)
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+}
+
+// findSubnet returns the subnet with the given ID and the VPC it belongs to, or nil if there is none.
+func (s *Server) findSubnet(subnetID int) (*linodego.VPC, *linodego.VPCSubnet) {
+	for _, vpc := range s.vpcs {
+		for i := range vpc.Subnets {
+			if vpc.Subnets[i].ID == subnetID {
+				return vpc, &vpc.Subnets[i]
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// nextSubnetAddress returns the lowest address of subnet not used by any Linode. The first address (the network
+// address) and the second (the gateway) are never assigned.
+func (s *Server) nextSubnetAddress(subnet *linodego.VPCSubnet) (string, error) {
+	prefix, err := netip.ParsePrefix(subnet.IPv4)
+	if err != nil {
+		return "", err
+	}
+
+	used := map[string]bool{}
+	for _, inst := range s.instances {
+		for _, config := range inst.configs {
+			for _, iface := range config.Interfaces {
+				if iface.SubnetID != nil && *iface.SubnetID == subnet.ID && iface.IPv4 != nil {
+					used[iface.IPv4.VPC] = true
+				}
+			}
+		}
+	}
+
+	for addr := prefix.Masked().Addr().Next().Next(); prefix.Contains(addr); addr = addr.Next() {
+		if !prefix.Contains(addr.Next()) {
+			// the broadcast address
+			break
+		}
+		if !used[addr.String()] {
+			return addr.String(), nil
+		}
+	}
+
+	return "", errors.New("no addresses available in subnet")
+}
+
+// detachFromSubnets removes a Linode from every subnet it is attached to.
+func (s *Server) detachFromSubnets(linodeID int) {
+	for _, vpc := range s.vpcs {
+		for i := range vpc.Subnets {
+			vpc.Subnets[i].Linodes = slices.DeleteFunc(vpc.Subnets[i].Linodes, func(l linodego.VPCSubnetLinode) bool {
+				return l.ID == linodeID
+			})
+		}
+	}
+}
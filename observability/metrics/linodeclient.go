@@ -0,0 +1,259 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/linode/linodego"
+
+	"github.com/linode/cluster-api-provider-linode/clients"
+)
+
+const (
+	// codeSuccess is the code label value for successful calls. The client does not expose the status code of
+	// successful responses, which may be any 2xx code.
+	codeSuccess = "success"
+	// codeClientError is the code label value for errors that did not originate from a Linode API response,
+	// e.g. timeouts, connection failures or request encoding errors.
+	codeClientError = "client_error"
+)
+
+// LinodeClient is a [clients.LinodeClient] that records Prometheus metrics for every call to the Linode API.
+type LinodeClient struct {
+	client     clients.LinodeClient
+	reconciler string
+}
+
+var _ clients.LinodeClient = (*LinodeClient)(nil)
+
+// NewLinodeClient wraps client so that each call is counted and timed, labelled with the given reconciler name.
+func NewLinodeClient(client clients.LinodeClient, reconciler string) *LinodeClient {
+	return &LinodeClient{
+		client:     client,
+		reconciler: reconciler,
+	}
+}
+
+// observe records the outcome of a single Linode API call. It is meant to be deferred at the start of each call
+// so that start is evaluated before the call is made.
+func (c *LinodeClient) observe(method string, start time.Time, err *error) {
+	code := statusCode(*err)
+
+	linodeAPILatency.WithLabelValues(c.reconciler, method).Observe(time.Since(start).Seconds())
+	linodeAPIRequests.WithLabelValues(c.reconciler, method, code).Inc()
+	if *err != nil {
+		linodeAPIErrors.WithLabelValues(c.reconciler, method, code).Inc()
+	}
+}
+
+// statusCode returns the HTTP status code label for the result of a Linode API call.
+func statusCode(err error) string {
+	if err == nil {
+		return codeSuccess
+	}
+
+	// linodego reserves codes below 100 for errors raised before a response was received
+	var apiErr interface{ StatusCode() int }
+	if errors.As(err, &apiErr) && apiErr.StatusCode() >= 100 {
+		return strconv.Itoa(apiErr.StatusCode())
+	}
+
+	return codeClientError
+}
+
+func (c *LinodeClient) GetInstanceIPAddresses(ctx context.Context, linodeID int) (_ *linodego.InstanceIPAddressResponse, err error) {
+	defer c.observe("GetInstanceIPAddresses", time.Now(), &err)
+	return c.client.GetInstanceIPAddresses(ctx, linodeID)
+}
+
+func (c *LinodeClient) ListInstances(ctx context.Context, opts *linodego.ListOptions) (_ []linodego.Instance, err error) {
+	defer c.observe("ListInstances", time.Now(), &err)
+	return c.client.ListInstances(ctx, opts)
+}
+
+func (c *LinodeClient) CreateInstance(ctx context.Context, opts linodego.InstanceCreateOptions) (_ *linodego.Instance, err error) {
+	defer c.observe("CreateInstance", time.Now(), &err)
+	return c.client.CreateInstance(ctx, opts)
+}
+
+func (c *LinodeClient) BootInstance(ctx context.Context, linodeID int, configID int) (err error) {
+	defer c.observe("BootInstance", time.Now(), &err)
+	return c.client.BootInstance(ctx, linodeID, configID)
+}
+
+func (c *LinodeClient) ListInstanceConfigs(ctx context.Context, linodeID int, opts *linodego.ListOptions) (_ []linodego.InstanceConfig, err error) {
+	defer c.observe("ListInstanceConfigs", time.Now(), &err)
+	return c.client.ListInstanceConfigs(ctx, linodeID, opts)
+}
+
+func (c *LinodeClient) UpdateInstanceConfig(ctx context.Context, linodeID int, configID int, opts linodego.InstanceConfigUpdateOptions) (_ *linodego.InstanceConfig, err error) {
+	defer c.observe("UpdateInstanceConfig", time.Now(), &err)
+	return c.client.UpdateInstanceConfig(ctx, linodeID, configID, opts)
+}
+
+func (c *LinodeClient) GetInstanceDisk(ctx context.Context, linodeID int, diskID int) (_ *linodego.InstanceDisk, err error) {
+	defer c.observe("GetInstanceDisk", time.Now(), &err)
+	return c.client.GetInstanceDisk(ctx, linodeID, diskID)
+}
+
+func (c *LinodeClient) ResizeInstanceDisk(ctx context.Context, linodeID int, diskID int, size int) (err error) {
+	defer c.observe("ResizeInstanceDisk", time.Now(), &err)
+	return c.client.ResizeInstanceDisk(ctx, linodeID, diskID, size)
+}
+
+func (c *LinodeClient) CreateInstanceDisk(ctx context.Context, linodeID int, opts linodego.InstanceDiskCreateOptions) (_ *linodego.InstanceDisk, err error) {
+	defer c.observe("CreateInstanceDisk", time.Now(), &err)
+	return c.client.CreateInstanceDisk(ctx, linodeID, opts)
+}
+
+func (c *LinodeClient) GetInstance(ctx context.Context, linodeID int) (_ *linodego.Instance, err error) {
+	defer c.observe("GetInstance", time.Now(), &err)
+	return c.client.GetInstance(ctx, linodeID)
+}
+
+func (c *LinodeClient) UpdateInstance(ctx context.Context, linodeID int, opts linodego.InstanceUpdateOptions) (_ *linodego.Instance, err error) {
+	defer c.observe("UpdateInstance", time.Now(), &err)
+	return c.client.UpdateInstance(ctx, linodeID, opts)
+}
+
+func (c *LinodeClient) DeleteInstance(ctx context.Context, linodeID int) (err error) {
+	defer c.observe("DeleteInstance", time.Now(), &err)
+	return c.client.DeleteInstance(ctx, linodeID)
+}
+
+func (c *LinodeClient) GetRegion(ctx context.Context, regionID string) (_ *linodego.Region, err error) {
+	defer c.observe("GetRegion", time.Now(), &err)
+	return c.client.GetRegion(ctx, regionID)
+}
+
+func (c *LinodeClient) GetImage(ctx context.Context, imageID string) (_ *linodego.Image, err error) {
+	defer c.observe("GetImage", time.Now(), &err)
+	return c.client.GetImage(ctx, imageID)
+}
+
+func (c *LinodeClient) CreateStackscript(ctx context.Context, opts linodego.StackscriptCreateOptions) (_ *linodego.Stackscript, err error) {
+	defer c.observe("CreateStackscript", time.Now(), &err)
+	return c.client.CreateStackscript(ctx, opts)
+}
+
+func (c *LinodeClient) ListStackscripts(ctx context.Context, opts *linodego.ListOptions) (_ []linodego.Stackscript, err error) {
+	defer c.observe("ListStackscripts", time.Now(), &err)
+	return c.client.ListStackscripts(ctx, opts)
+}
+
+func (c *LinodeClient) GetType(ctx context.Context, typeID string) (_ *linodego.LinodeType, err error) {
+	defer c.observe("GetType", time.Now(), &err)
+	return c.client.GetType(ctx, typeID)
+}
+
+func (c *LinodeClient) GetVPC(ctx context.Context, vpcID int) (_ *linodego.VPC, err error) {
+	defer c.observe("GetVPC", time.Now(), &err)
+	return c.client.GetVPC(ctx, vpcID)
+}
+
+func (c *LinodeClient) ListVPCs(ctx context.Context, opts *linodego.ListOptions) (_ []linodego.VPC, err error) {
+	defer c.observe("ListVPCs", time.Now(), &err)
+	return c.client.ListVPCs(ctx, opts)
+}
+
+func (c *LinodeClient) CreateVPC(ctx context.Context, opts linodego.VPCCreateOptions) (_ *linodego.VPC, err error) {
+	defer c.observe("CreateVPC", time.Now(), &err)
+	return c.client.CreateVPC(ctx, opts)
+}
+
+func (c *LinodeClient) UpdateVPC(ctx context.Context, vpcID int, opts linodego.VPCUpdateOptions) (_ *linodego.VPC, err error) {
+	defer c.observe("UpdateVPC", time.Now(), &err)
+	return c.client.UpdateVPC(ctx, vpcID, opts)
+}
+
+func (c *LinodeClient) DeleteVPC(ctx context.Context, vpcID int) (err error) {
+	defer c.observe("DeleteVPC", time.Now(), &err)
+	return c.client.DeleteVPC(ctx, vpcID)
+}
+
+func (c *LinodeClient) CreateVPCSubnet(ctx context.Context, opts linodego.VPCSubnetCreateOptions, vpcID int) (_ *linodego.VPCSubnet, err error) {
+	defer c.observe("CreateVPCSubnet", time.Now(), &err)
+	return c.client.CreateVPCSubnet(ctx, opts, vpcID)
+}
+
+func (c *LinodeClient) DeleteVPCSubnet(ctx context.Context, vpcID, subnetID int) (err error) {
+	defer c.observe("DeleteVPCSubnet", time.Now(), &err)
+	return c.client.DeleteVPCSubnet(ctx, vpcID, subnetID)
+}
+
+func (c *LinodeClient) ListNodeBalancers(ctx context.Context, opts *linodego.ListOptions) (_ []linodego.NodeBalancer, err error) {
+	defer c.observe("ListNodeBalancers", time.Now(), &err)
+	return c.client.ListNodeBalancers(ctx, opts)
+}
+
+func (c *LinodeClient) GetNodeBalancer(ctx context.Context, nodebalancerID int) (_ *linodego.NodeBalancer, err error) {
+	defer c.observe("GetNodeBalancer", time.Now(), &err)
+	return c.client.GetNodeBalancer(ctx, nodebalancerID)
+}
+
+func (c *LinodeClient) CreateNodeBalancer(ctx context.Context, opts linodego.NodeBalancerCreateOptions) (_ *linodego.NodeBalancer, err error) {
+	defer c.observe("CreateNodeBalancer", time.Now(), &err)
+	return c.client.CreateNodeBalancer(ctx, opts)
+}
+
+func (c *LinodeClient) UpdateNodeBalancer(ctx context.Context, nodebalancerID int, opts linodego.NodeBalancerUpdateOptions) (_ *linodego.NodeBalancer, err error) {
+	defer c.observe("UpdateNodeBalancer", time.Now(), &err)
+	return c.client.UpdateNodeBalancer(ctx, nodebalancerID, opts)
+}
+
+func (c *LinodeClient) CreateNodeBalancerConfig(ctx context.Context, nodebalancerID int, opts linodego.NodeBalancerConfigCreateOptions) (_ *linodego.NodeBalancerConfig, err error) {
+	defer c.observe("CreateNodeBalancerConfig", time.Now(), &err)
+	return c.client.CreateNodeBalancerConfig(ctx, nodebalancerID, opts)
+}
+
+func (c *LinodeClient) DeleteNodeBalancerNode(ctx context.Context, nodebalancerID int, configID int, nodeID int) (err error) {
+	defer c.observe("DeleteNodeBalancerNode", time.Now(), &err)
+	return c.client.DeleteNodeBalancerNode(ctx, nodebalancerID, configID, nodeID)
+}
+
+func (c *LinodeClient) DeleteNodeBalancer(ctx context.Context, nodebalancerID int) (err error) {
+	defer c.observe("DeleteNodeBalancer", time.Now(), &err)
+	return c.client.DeleteNodeBalancer(ctx, nodebalancerID)
+}
+
+func (c *LinodeClient) CreateNodeBalancerNode(ctx context.Context, nodebalancerID int, configID int, opts linodego.NodeBalancerNodeCreateOptions) (_ *linodego.NodeBalancerNode, err error) {
+	defer c.observe("CreateNodeBalancerNode", time.Now(), &err)
+	return c.client.CreateNodeBalancerNode(ctx, nodebalancerID, configID, opts)
+}
+
+func (c *LinodeClient) GetObjectStorageBucket(ctx context.Context, cluster, label string) (_ *linodego.ObjectStorageBucket, err error) {
+	defer c.observe("GetObjectStorageBucket", time.Now(), &err)
+	return c.client.GetObjectStorageBucket(ctx, cluster, label)
+}
+
+func (c *LinodeClient) CreateObjectStorageBucket(ctx context.Context, opts linodego.ObjectStorageBucketCreateOptions) (_ *linodego.ObjectStorageBucket, err error) {
+	defer c.observe("CreateObjectStorageBucket", time.Now(), &err)
+	return c.client.CreateObjectStorageBucket(ctx, opts)
+}
+
+func (c *LinodeClient) GetObjectStorageKey(ctx context.Context, keyID int) (_ *linodego.ObjectStorageKey, err error) {
+	defer c.observe("GetObjectStorageKey", time.Now(), &err)
+	return c.client.GetObjectStorageKey(ctx, keyID)
+}
+
+func (c *LinodeClient) CreateObjectStorageKey(ctx context.Context, opts linodego.ObjectStorageKeyCreateOptions) (_ *linodego.ObjectStorageKey, err error) {
+	defer c.observe("CreateObjectStorageKey", time.Now(), &err)
+	return c.client.CreateObjectStorageKey(ctx, opts)
+}
+
+func (c *LinodeClient) ListObjectStorageKeys(ctx context.Context, opts *linodego.ListOptions) (_ []linodego.ObjectStorageKey, err error) {
+	defer c.observe("ListObjectStorageKeys", time.Now(), &err)
+	return c.client.ListObjectStorageKeys(ctx, opts)
+}
+
+func (c *LinodeClient) DeleteObjectStorageKey(ctx context.Context, keyID int) (err error) {
+	defer c.observe("DeleteObjectStorageKey", time.Now(), &err)
+	return c.client.DeleteObjectStorageKey(ctx, keyID)
+}
+
+func (c *LinodeClient) ListEvents(ctx context.Context, opts *linodego.ListOptions) (_ []linodego.Event, err error) {
+	defer c.observe("ListEvents", time.Now(), &err)
+	return c.client.ListEvents(ctx, opts)
+}
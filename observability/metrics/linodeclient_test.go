@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestLinodeClient(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		reconciler string
+		expects    func(client *mock.MockLinodeClient)
+		call       func(ctx context.Context, client *LinodeClient) error
+		method     string
+		code       string
+		wantErr    bool
+	}{
+		{
+			name:       "Success - call is counted as a success",
+			reconciler: "test-success",
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123}, nil)
+			},
+			call: func(ctx context.Context, client *LinodeClient) error {
+				_, err := client.GetInstance(ctx, 123)
+				return err
+			},
+			method: "GetInstance",
+			code:   "success",
+		},
+		{
+			name:       "Error - API error is counted with its status code",
+			reconciler: "test-api-error",
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().DeleteVPC(gomock.Any(), 1).Return(&linodego.Error{Code: http.StatusTooManyRequests})
+			},
+			call: func(ctx context.Context, client *LinodeClient) error {
+				return client.DeleteVPC(ctx, 1)
+			},
+			method:  "DeleteVPC",
+			code:    "429",
+			wantErr: true,
+		},
+		{
+			name:       "Error - client error is counted without a status code",
+			reconciler: "test-client-error",
+			expects: func(client *mock.MockLinodeClient) {
+				client.EXPECT().ListNodeBalancers(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+			call: func(ctx context.Context, client *LinodeClient) error {
+				_, err := client.ListNodeBalancers(ctx, nil)
+				return err
+			},
+			method:  "ListNodeBalancers",
+			code:    codeClientError,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockClient)
+
+			err := testcase.call(context.Background(), NewLinodeClient(mockClient, testcase.reconciler))
+			if testcase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.InDelta(t, 1, testutil.ToFloat64(linodeAPIRequests.WithLabelValues(testcase.reconciler, testcase.method, testcase.code)), 0)
+
+			var latency dto.Metric
+			histogram, ok := linodeAPILatency.WithLabelValues(testcase.reconciler, testcase.method).(prometheus.Histogram)
+			assert.True(t, ok)
+			assert.NoError(t, histogram.Write(&latency))
+			assert.Equal(t, uint64(1), latency.GetHistogram().GetSampleCount())
+
+			wantErrs := 0.0
+			if testcase.wantErr {
+				wantErrs = 1
+			}
+			assert.InDelta(t, wantErrs, testutil.ToFloat64(linodeAPIErrors.WithLabelValues(testcase.reconciler, testcase.method, testcase.code)), 0)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "capl"
+
+	// ReconcilerWebhook is the reconciler label value used for calls made from admission webhooks.
+	ReconcilerWebhook = "webhook"
+)
+
+var (
+	// linodeAPIRequests counts every Linode API call by the reconciler that made it, the client method and the
+	// result: "success", the HTTP status code of the error or "client_error".
+	linodeAPIRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "linode_api",
+			Name:      "requests_total",
+			Help:      "Total number of Linode API requests by reconciler, method and result (success, error HTTP status code or client_error).",
+		},
+		[]string{"reconciler", "method", "code"},
+	)
+
+	// linodeAPIErrors counts the Linode API calls that returned an error.
+	linodeAPIErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "linode_api",
+			Name:      "errors_total",
+			Help:      "Total number of failed Linode API requests by reconciler, method and HTTP status code.",
+		},
+		[]string{"reconciler", "method", "code"},
+	)
+
+	// linodeAPILatency observes the duration of Linode API calls.
+	linodeAPILatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "linode_api",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of Linode API requests in seconds by reconciler and method.",
+			Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"reconciler", "method"},
+	)
+
+	// linodeAPICacheLookups counts lookups of cached Linode catalog objects by whether they were served from the
+	// cache.
+	linodeAPICacheLookups = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "linode_api",
+			Name:      "cache_lookups_total",
+			Help:      "Total number of cached Linode API lookups by resource and result (hit or miss).",
+		},
+		[]string{"resource", "result"},
+	)
+
+	// orphanedLinodeResources is the number of Linode resources created by CAPL which no object manages anymore, as
+	// found by the last sweep of the orphan sweeper.
+	orphanedLinodeResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "orphan_sweeper",
+			Name:      "orphaned_resources",
+			Help:      "Number of Linode resources created by CAPL which are not managed by any object, by kind.",
+		},
+		[]string{"kind"},
+	)
+
+	// orphanedLinodeResourceDeletions counts the orphaned Linode resources deleted by the orphan sweeper.
+	orphanedLinodeResourceDeletions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "orphan_sweeper",
+			Name:      "deletions_total",
+			Help:      "Total number of orphaned Linode resources deleted by kind.",
+		},
+		[]string{"kind"},
+	)
+
+	// featureEnabled reports whether each feature gate is enabled.
+	featureEnabled = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "feature_enabled",
+			Help:      "Whether a feature gate is enabled (1) or disabled (0), by name and stage.",
+		},
+		[]string{"name", "stage"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		linodeAPIRequests,
+		linodeAPIErrors,
+		linodeAPILatency,
+		linodeAPICacheLookups,
+		orphanedLinodeResources,
+		orphanedLinodeResourceDeletions,
+		featureEnabled,
+	)
+}
+
+// ObserveCacheLookup records a lookup of the given resource kind in a read-through cache.
+func ObserveCacheLookup(resource string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	linodeAPICacheLookups.WithLabelValues(resource, result).Inc()
+}
+
+// SetOrphanedResources records the number of orphaned Linode resources of the given kind.
+func SetOrphanedResources(kind string, count int) {
+	orphanedLinodeResources.WithLabelValues(kind).Set(float64(count))
+}
+
+// ObserveOrphanDeletion records the deletion of an orphaned Linode resource of the given kind.
+func ObserveOrphanDeletion(kind string) {
+	orphanedLinodeResourceDeletions.WithLabelValues(kind).Inc()
+}
+
+// SetFeatureEnabled records whether the feature gate with the given name and stage is enabled.
+func SetFeatureEnabled(name, stage string, enabled bool) {
+	value := 0.0
+	if enabled {
+		value = 1
+	}
+
+	featureEnabled.WithLabelValues(name, stage).Set(value)
+}
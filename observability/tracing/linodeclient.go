@@ -0,0 +1,294 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/linode/linodego"
+
+	"github.com/linode/cluster-api-provider-linode/clients"
+)
+
+// LinodeClient is a [clients.LinodeClient] that records a span for every call to the Linode API.
+type LinodeClient struct {
+	client clients.LinodeClient
+}
+
+var _ clients.LinodeClient = (*LinodeClient)(nil)
+
+// NewLinodeClient wraps client so that each call is traced as a child of the span in its context.
+func NewLinodeClient(client clients.LinodeClient) *LinodeClient {
+	return &LinodeClient{client: client}
+}
+
+func (c *LinodeClient) GetInstanceIPAddresses(ctx context.Context, linodeID int) (_ *linodego.InstanceIPAddressResponse, err error) {
+	ctx, span := Start(ctx, "LinodeClient.GetInstanceIPAddresses")
+	defer End(span, &err)
+
+	return c.client.GetInstanceIPAddresses(ctx, linodeID)
+}
+
+func (c *LinodeClient) ListInstances(ctx context.Context, opts *linodego.ListOptions) (_ []linodego.Instance, err error) {
+	ctx, span := Start(ctx, "LinodeClient.ListInstances")
+	defer End(span, &err)
+
+	return c.client.ListInstances(ctx, opts)
+}
+
+func (c *LinodeClient) CreateInstance(ctx context.Context, opts linodego.InstanceCreateOptions) (_ *linodego.Instance, err error) {
+	ctx, span := Start(ctx, "LinodeClient.CreateInstance")
+	defer End(span, &err)
+
+	return c.client.CreateInstance(ctx, opts)
+}
+
+func (c *LinodeClient) BootInstance(ctx context.Context, linodeID int, configID int) (err error) {
+	ctx, span := Start(ctx, "LinodeClient.BootInstance")
+	defer End(span, &err)
+
+	return c.client.BootInstance(ctx, linodeID, configID)
+}
+
+func (c *LinodeClient) ListInstanceConfigs(ctx context.Context, linodeID int, opts *linodego.ListOptions) (_ []linodego.InstanceConfig, err error) {
+	ctx, span := Start(ctx, "LinodeClient.ListInstanceConfigs")
+	defer End(span, &err)
+
+	return c.client.ListInstanceConfigs(ctx, linodeID, opts)
+}
+
+func (c *LinodeClient) UpdateInstanceConfig(ctx context.Context, linodeID int, configID int, opts linodego.InstanceConfigUpdateOptions) (_ *linodego.InstanceConfig, err error) {
+	ctx, span := Start(ctx, "LinodeClient.UpdateInstanceConfig")
+	defer End(span, &err)
+
+	return c.client.UpdateInstanceConfig(ctx, linodeID, configID, opts)
+}
+
+func (c *LinodeClient) GetInstanceDisk(ctx context.Context, linodeID int, diskID int) (_ *linodego.InstanceDisk, err error) {
+	ctx, span := Start(ctx, "LinodeClient.GetInstanceDisk")
+	defer End(span, &err)
+
+	return c.client.GetInstanceDisk(ctx, linodeID, diskID)
+}
+
+func (c *LinodeClient) ResizeInstanceDisk(ctx context.Context, linodeID int, diskID int, size int) (err error) {
+	ctx, span := Start(ctx, "LinodeClient.ResizeInstanceDisk")
+	defer End(span, &err)
+
+	return c.client.ResizeInstanceDisk(ctx, linodeID, diskID, size)
+}
+
+func (c *LinodeClient) CreateInstanceDisk(ctx context.Context, linodeID int, opts linodego.InstanceDiskCreateOptions) (_ *linodego.InstanceDisk, err error) {
+	ctx, span := Start(ctx, "LinodeClient.CreateInstanceDisk")
+	defer End(span, &err)
+
+	return c.client.CreateInstanceDisk(ctx, linodeID, opts)
+}
+
+func (c *LinodeClient) GetInstance(ctx context.Context, linodeID int) (_ *linodego.Instance, err error) {
+	ctx, span := Start(ctx, "LinodeClient.GetInstance")
+	defer End(span, &err)
+
+	return c.client.GetInstance(ctx, linodeID)
+}
+
+func (c *LinodeClient) UpdateInstance(ctx context.Context, linodeID int, opts linodego.InstanceUpdateOptions) (_ *linodego.Instance, err error) {
+	ctx, span := Start(ctx, "LinodeClient.UpdateInstance")
+	defer End(span, &err)
+
+	return c.client.UpdateInstance(ctx, linodeID, opts)
+}
+
+func (c *LinodeClient) DeleteInstance(ctx context.Context, linodeID int) (err error) {
+	ctx, span := Start(ctx, "LinodeClient.DeleteInstance")
+	defer End(span, &err)
+
+	return c.client.DeleteInstance(ctx, linodeID)
+}
+
+func (c *LinodeClient) GetRegion(ctx context.Context, regionID string) (_ *linodego.Region, err error) {
+	ctx, span := Start(ctx, "LinodeClient.GetRegion")
+	defer End(span, &err)
+
+	return c.client.GetRegion(ctx, regionID)
+}
+
+func (c *LinodeClient) GetImage(ctx context.Context, imageID string) (_ *linodego.Image, err error) {
+	ctx, span := Start(ctx, "LinodeClient.GetImage")
+	defer End(span, &err)
+
+	return c.client.GetImage(ctx, imageID)
+}
+
+func (c *LinodeClient) CreateStackscript(ctx context.Context, opts linodego.StackscriptCreateOptions) (_ *linodego.Stackscript, err error) {
+	ctx, span := Start(ctx, "LinodeClient.CreateStackscript")
+	defer End(span, &err)
+
+	return c.client.CreateStackscript(ctx, opts)
+}
+
+func (c *LinodeClient) ListStackscripts(ctx context.Context, opts *linodego.ListOptions) (_ []linodego.Stackscript, err error) {
+	ctx, span := Start(ctx, "LinodeClient.ListStackscripts")
+	defer End(span, &err)
+
+	return c.client.ListStackscripts(ctx, opts)
+}
+
+func (c *LinodeClient) GetType(ctx context.Context, typeID string) (_ *linodego.LinodeType, err error) {
+	ctx, span := Start(ctx, "LinodeClient.GetType")
+	defer End(span, &err)
+
+	return c.client.GetType(ctx, typeID)
+}
+
+func (c *LinodeClient) GetVPC(ctx context.Context, vpcID int) (_ *linodego.VPC, err error) {
+	ctx, span := Start(ctx, "LinodeClient.GetVPC")
+	defer End(span, &err)
+
+	return c.client.GetVPC(ctx, vpcID)
+}
+
+func (c *LinodeClient) ListVPCs(ctx context.Context, opts *linodego.ListOptions) (_ []linodego.VPC, err error) {
+	ctx, span := Start(ctx, "LinodeClient.ListVPCs")
+	defer End(span, &err)
+
+	return c.client.ListVPCs(ctx, opts)
+}
+
+func (c *LinodeClient) CreateVPC(ctx context.Context, opts linodego.VPCCreateOptions) (_ *linodego.VPC, err error) {
+	ctx, span := Start(ctx, "LinodeClient.CreateVPC")
+	defer End(span, &err)
+
+	return c.client.CreateVPC(ctx, opts)
+}
+
+func (c *LinodeClient) UpdateVPC(ctx context.Context, vpcID int, opts linodego.VPCUpdateOptions) (_ *linodego.VPC, err error) {
+	ctx, span := Start(ctx, "LinodeClient.UpdateVPC")
+	defer End(span, &err)
+
+	return c.client.UpdateVPC(ctx, vpcID, opts)
+}
+
+func (c *LinodeClient) DeleteVPC(ctx context.Context, vpcID int) (err error) {
+	ctx, span := Start(ctx, "LinodeClient.DeleteVPC")
+	defer End(span, &err)
+
+	return c.client.DeleteVPC(ctx, vpcID)
+}
+
+func (c *LinodeClient) CreateVPCSubnet(ctx context.Context, opts linodego.VPCSubnetCreateOptions, vpcID int) (_ *linodego.VPCSubnet, err error) {
+	ctx, span := Start(ctx, "LinodeClient.CreateVPCSubnet")
+	defer End(span, &err)
+
+	return c.client.CreateVPCSubnet(ctx, opts, vpcID)
+}
+
+func (c *LinodeClient) DeleteVPCSubnet(ctx context.Context, vpcID, subnetID int) (err error) {
+	ctx, span := Start(ctx, "LinodeClient.DeleteVPCSubnet")
+	defer End(span, &err)
+
+	return c.client.DeleteVPCSubnet(ctx, vpcID, subnetID)
+}
+
+func (c *LinodeClient) ListNodeBalancers(ctx context.Context, opts *linodego.ListOptions) (_ []linodego.NodeBalancer, err error) {
+	ctx, span := Start(ctx, "LinodeClient.ListNodeBalancers")
+	defer End(span, &err)
+
+	return c.client.ListNodeBalancers(ctx, opts)
+}
+
+func (c *LinodeClient) GetNodeBalancer(ctx context.Context, nodebalancerID int) (_ *linodego.NodeBalancer, err error) {
+	ctx, span := Start(ctx, "LinodeClient.GetNodeBalancer")
+	defer End(span, &err)
+
+	return c.client.GetNodeBalancer(ctx, nodebalancerID)
+}
+
+func (c *LinodeClient) CreateNodeBalancer(ctx context.Context, opts linodego.NodeBalancerCreateOptions) (_ *linodego.NodeBalancer, err error) {
+	ctx, span := Start(ctx, "LinodeClient.CreateNodeBalancer")
+	defer End(span, &err)
+
+	return c.client.CreateNodeBalancer(ctx, opts)
+}
+
+func (c *LinodeClient) UpdateNodeBalancer(ctx context.Context, nodebalancerID int, opts linodego.NodeBalancerUpdateOptions) (_ *linodego.NodeBalancer, err error) {
+	ctx, span := Start(ctx, "LinodeClient.UpdateNodeBalancer")
+	defer End(span, &err)
+
+	return c.client.UpdateNodeBalancer(ctx, nodebalancerID, opts)
+}
+
+func (c *LinodeClient) CreateNodeBalancerConfig(ctx context.Context, nodebalancerID int, opts linodego.NodeBalancerConfigCreateOptions) (_ *linodego.NodeBalancerConfig, err error) {
+	ctx, span := Start(ctx, "LinodeClient.CreateNodeBalancerConfig")
+	defer End(span, &err)
+
+	return c.client.CreateNodeBalancerConfig(ctx, nodebalancerID, opts)
+}
+
+func (c *LinodeClient) DeleteNodeBalancerNode(ctx context.Context, nodebalancerID int, configID int, nodeID int) (err error) {
+	ctx, span := Start(ctx, "LinodeClient.DeleteNodeBalancerNode")
+	defer End(span, &err)
+
+	return c.client.DeleteNodeBalancerNode(ctx, nodebalancerID, configID, nodeID)
+}
+
+func (c *LinodeClient) DeleteNodeBalancer(ctx context.Context, nodebalancerID int) (err error) {
+	ctx, span := Start(ctx, "LinodeClient.DeleteNodeBalancer")
+	defer End(span, &err)
+
+	return c.client.DeleteNodeBalancer(ctx, nodebalancerID)
+}
+
+func (c *LinodeClient) CreateNodeBalancerNode(ctx context.Context, nodebalancerID int, configID int, opts linodego.NodeBalancerNodeCreateOptions) (_ *linodego.NodeBalancerNode, err error) {
+	ctx, span := Start(ctx, "LinodeClient.CreateNodeBalancerNode")
+	defer End(span, &err)
+
+	return c.client.CreateNodeBalancerNode(ctx, nodebalancerID, configID, opts)
+}
+
+func (c *LinodeClient) GetObjectStorageBucket(ctx context.Context, cluster, label string) (_ *linodego.ObjectStorageBucket, err error) {
+	ctx, span := Start(ctx, "LinodeClient.GetObjectStorageBucket")
+	defer End(span, &err)
+
+	return c.client.GetObjectStorageBucket(ctx, cluster, label)
+}
+
+func (c *LinodeClient) CreateObjectStorageBucket(ctx context.Context, opts linodego.ObjectStorageBucketCreateOptions) (_ *linodego.ObjectStorageBucket, err error) {
+	ctx, span := Start(ctx, "LinodeClient.CreateObjectStorageBucket")
+	defer End(span, &err)
+
+	return c.client.CreateObjectStorageBucket(ctx, opts)
+}
+
+func (c *LinodeClient) GetObjectStorageKey(ctx context.Context, keyID int) (_ *linodego.ObjectStorageKey, err error) {
+	ctx, span := Start(ctx, "LinodeClient.GetObjectStorageKey")
+	defer End(span, &err)
+
+	return c.client.GetObjectStorageKey(ctx, keyID)
+}
+
+func (c *LinodeClient) CreateObjectStorageKey(ctx context.Context, opts linodego.ObjectStorageKeyCreateOptions) (_ *linodego.ObjectStorageKey, err error) {
+	ctx, span := Start(ctx, "LinodeClient.CreateObjectStorageKey")
+	defer End(span, &err)
+
+	return c.client.CreateObjectStorageKey(ctx, opts)
+}
+
+func (c *LinodeClient) ListObjectStorageKeys(ctx context.Context, opts *linodego.ListOptions) (_ []linodego.ObjectStorageKey, err error) {
+	ctx, span := Start(ctx, "LinodeClient.ListObjectStorageKeys")
+	defer End(span, &err)
+
+	return c.client.ListObjectStorageKeys(ctx, opts)
+}
+
+func (c *LinodeClient) DeleteObjectStorageKey(ctx context.Context, keyID int) (err error) {
+	ctx, span := Start(ctx, "LinodeClient.DeleteObjectStorageKey")
+	defer End(span, &err)
+
+	return c.client.DeleteObjectStorageKey(ctx, keyID)
+}
+
+func (c *LinodeClient) ListEvents(ctx context.Context, opts *linodego.ListOptions) (_ []linodego.Event, err error) {
+	ctx, span := Start(ctx, "LinodeClient.ListEvents")
+	defer End(span, &err)
+
+	return c.client.ListEvents(ctx, opts)
+}
@@ -0,0 +1,114 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/linode/cluster-api-provider-linode/version"
+)
+
+const (
+	instrumentationName = "github.com/linode/cluster-api-provider-linode"
+	serviceName         = "cluster-api-provider-linode"
+
+	// TraceIDAnnotation is the annotation set on events recorded while a trace is being sampled.
+	TraceIDAnnotation = "infrastructure.cluster.x-k8s.io/trace-id"
+)
+
+// Options configures the export of traces.
+type Options struct {
+	// Endpoint is the host:port of an OTLP gRPC collector. Tracing is disabled when empty.
+	Endpoint string
+	// Insecure disables TLS when connecting to the collector.
+	Insecure bool
+	// SamplingRate is the fraction of new traces to sample, between 0 and 1.
+	SamplingRate float64
+}
+
+// Setup installs a global tracer provider that exports spans over OTLP as configured by opts. It returns a
+// function that flushes any buffered spans and stops the exporter. When no endpoint is configured, spans are
+// not recorded and Setup is a no-op.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	if opts.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporterOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+	if opts.Insecure {
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(version.GetVersion()),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SamplingRate))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Start creates a span with the given name as a child of any span in ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends span, marking it as failed if err points to a non-nil error. It is meant to be deferred right after
+// [Start] with a pointer to a named error result.
+func End(span trace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+
+	span.End()
+}
+
+// Logger returns logger with the trace and span IDs of the span in ctx, if any.
+func Logger(ctx context.Context, logger logr.Logger) logr.Logger {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return logger
+	}
+
+	return logger.WithValues("traceID", spanCtx.TraceID().String(), "spanID", spanCtx.SpanID().String())
+}
+
+// Event records an event for object, annotated with the trace ID of the span in ctx, if any.
+func Event(ctx context.Context, recorder record.EventRecorder, object runtime.Object, eventtype, reason, message string) {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		recorder.Event(object, eventtype, reason, message)
+
+		return
+	}
+
+	recorder.AnnotatedEventf(object, map[string]string{TraceIDAnnotation: spanCtx.TraceID().String()}, eventtype, reason, "%s", message)
+}
@@ -0,0 +1,133 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+var spans = tracetest.NewSpanRecorder()
+
+func TestMain(m *testing.M) {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+
+	os.Exit(m.Run())
+}
+
+// endedSpans returns the ended spans belonging to the given trace.
+func endedSpans(traceID trace.TraceID) []sdktrace.ReadOnlySpan {
+	var found []sdktrace.ReadOnlySpan
+	for _, span := range spans.Ended() {
+		if span.SpanContext().TraceID() == traceID {
+			found = append(found, span)
+		}
+	}
+
+	return found
+}
+
+func TestEnd(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus codes.Code
+	}{
+		{
+			name:       "Success - span is not marked as failed",
+			wantStatus: codes.Unset,
+		},
+		{
+			name:       "Error - span is marked as failed",
+			err:        errors.New("boom"),
+			wantStatus: codes.Error,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, span := Start(context.Background(), "test")
+			End(span, &testcase.err)
+
+			ended := endedSpans(span.SpanContext().TraceID())
+			require.Len(t, ended, 1)
+			assert.Equal(t, testcase.wantStatus, ended[0].Status().Code)
+		})
+	}
+}
+
+func TestLogger(t *testing.T) {
+	t.Parallel()
+
+	var logged string
+	logger := funcr.New(func(_, args string) { logged = args }, funcr.Options{})
+
+	Logger(context.Background(), logger).Info("untraced")
+	assert.NotContains(t, logged, "traceID")
+
+	ctx, span := Start(context.Background(), "test")
+	defer span.End()
+
+	Logger(ctx, logger).Info("traced")
+	assert.Contains(t, logged, span.SpanContext().TraceID().String())
+	assert.Contains(t, logged, span.SpanContext().SpanID().String())
+}
+
+func TestEvent(t *testing.T) {
+	t.Parallel()
+
+	recorder := record.NewFakeRecorder(2)
+
+	Event(context.Background(), recorder, &corev1.Pod{}, corev1.EventTypeNormal, "Untraced", "message")
+	assert.Equal(t, "Normal Untraced message", <-recorder.Events)
+
+	ctx, span := Start(context.Background(), "test")
+	defer span.End()
+
+	Event(ctx, recorder, &corev1.Pod{}, corev1.EventTypeNormal, "Traced", "message")
+	assert.Contains(t, <-recorder.Events, TraceIDAnnotation+":"+span.SpanContext().TraceID().String())
+}
+
+func TestLinodeClient(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockLinodeClient(ctrl)
+	mockClient.EXPECT().GetInstance(gomock.Any(), 123).DoAndReturn(func(ctx context.Context, _ int) (*linodego.Instance, error) {
+		assert.True(t, trace.SpanContextFromContext(ctx).IsValid(), "expected a span in the call context")
+
+		return nil, errors.New("boom")
+	})
+
+	ctx, parent := Start(context.Background(), "parent")
+	_, err := NewLinodeClient(mockClient).GetInstance(ctx, 123)
+	require.Error(t, err)
+	parent.End()
+
+	ended := endedSpans(parent.SpanContext().TraceID())
+	require.Len(t, ended, 2)
+	assert.Equal(t, "LinodeClient.GetInstance", ended[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), ended[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, ended[0].Status().Code)
+}
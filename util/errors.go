@@ -0,0 +1,105 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/linode/linodego"
+	cerrs "sigs.k8s.io/cluster-api/errors"
+)
+
+// ErrorClass is the category of an error returned while reconciling Linode resources.
+type ErrorClass string
+
+const (
+	// ErrorClassTransient errors are expected to go away when retried, e.g. rate limiting, server errors or a busy
+	// Linode.
+	ErrorClassTransient ErrorClass = "Transient"
+	// ErrorClassTerminal errors will not go away without a change to the object, e.g. an invalid region or type.
+	ErrorClassTerminal ErrorClass = "Terminal"
+	// ErrorClassCredentials errors are caused by a missing, invalid or insufficiently privileged Linode API token.
+	// They are not terminal since they are fixed by updating the credentials.
+	ErrorClassCredentials ErrorClass = "Credentials"
+)
+
+// ClassifyError returns the class of err. Errors which did not come from the Linode API are transient.
+func ClassifyError(err error) ErrorClass {
+	if err == nil || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return ErrorClassTransient
+	}
+
+	apiErr := asLinodeAPIError(err)
+	if apiErr == nil {
+		return ErrorClassTransient
+	}
+
+	switch code := apiErr.Code; {
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return ErrorClassCredentials
+	case code == http.StatusBadRequest && strings.Contains(strings.ToLower(apiErr.Message), "busy"):
+		// A Linode is busy while a previous operation, e.g. a disk resize, is still running.
+		return ErrorClassTransient
+	case code == http.StatusRequestTimeout, code == http.StatusConflict, code == http.StatusTooManyRequests:
+		return ErrorClassTransient
+	case code >= http.StatusBadRequest && code < http.StatusInternalServerError:
+		return ErrorClassTerminal
+	default:
+		// Server errors and errors raised by the client before a response was received
+		return ErrorClassTransient
+	}
+}
+
+// IsTerminalError returns true if err will not go away when retried.
+func IsTerminalError(err error) bool {
+	return ClassifyError(err) == ErrorClassTerminal
+}
+
+// MachineFailureReason returns the CAPI failure reason of err, or nil if err is not terminal. Invalid requests are
+// reported as InvalidConfigurationMachineError and other terminal errors as reason.
+func MachineFailureReason(err error, reason cerrs.MachineStatusError) *cerrs.MachineStatusError {
+	if !IsTerminalError(err) {
+		return nil
+	}
+	if isInvalidRequest(err) {
+		return Pointer(cerrs.InvalidConfigurationMachineError)
+	}
+
+	return Pointer(reason)
+}
+
+// ClusterFailureReason returns the CAPI failure reason of err, or nil if err is not terminal. Invalid requests are
+// reported as InvalidConfigurationClusterError and other terminal errors as reason.
+func ClusterFailureReason(err error, reason cerrs.ClusterStatusError) *cerrs.ClusterStatusError {
+	if !IsTerminalError(err) {
+		return nil
+	}
+	if isInvalidRequest(err) {
+		return Pointer(cerrs.InvalidConfigurationClusterError)
+	}
+
+	return Pointer(reason)
+}
+
+// isInvalidRequest returns true if err is a Linode API error rejecting the request content.
+func isInvalidRequest(err error) bool {
+	apiErr := asLinodeAPIError(err)
+
+	return apiErr != nil && (apiErr.Code == http.StatusBadRequest || apiErr.Code == http.StatusUnprocessableEntity)
+}
+
+// asLinodeAPIError returns the Linode API error wrapped by err, which may be returned by value or by reference.
+func asLinodeAPIError(err error) *linodego.Error {
+	var apiErr *linodego.Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	var valueErr linodego.Error
+	if errors.As(err, &valueErr) {
+		return &valueErr
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	cerrs "sigs.k8s.io/cluster-api/errors"
+)
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		err      error
+		expected ErrorClass
+	}{{
+		name:     "Not Linode API error",
+		err:      errors.New("foo"),
+		expected: ErrorClassTransient,
+	}, {
+		name:     "Context deadline",
+		err:      fmt.Errorf("create instance: %w", context.DeadlineExceeded),
+		expected: ErrorClassTransient,
+	}, {
+		name:     "Invalid region",
+		err:      linodego.Error{Code: 400, Message: "[region] Region is not valid"},
+		expected: ErrorClassTerminal,
+	}, {
+		name:     "Invalid type, wrapped pointer",
+		err:      fmt.Errorf("create instance: %w", &linodego.Error{Code: 400, Message: "[type] A valid plan type by that ID was not found"}),
+		expected: ErrorClassTerminal,
+	}, {
+		name:     "Linode busy",
+		err:      linodego.Error{Code: 400, Message: "Linode busy."},
+		expected: ErrorClassTransient,
+	}, {
+		name:     "Not found",
+		err:      linodego.Error{Code: 404, Message: "Not found"},
+		expected: ErrorClassTerminal,
+	}, {
+		name:     "Unauthorized",
+		err:      linodego.Error{Code: 401, Message: "Invalid Token"},
+		expected: ErrorClassCredentials,
+	}, {
+		name:     "Forbidden",
+		err:      linodego.Error{Code: 403, Message: "Your OAuth token is not authorized to use this endpoint."},
+		expected: ErrorClassCredentials,
+	}, {
+		name:     "Rate limited",
+		err:      linodego.Error{Code: 429, Message: "Too Many Requests"},
+		expected: ErrorClassTransient,
+	}, {
+		name:     "Server error",
+		err:      linodego.Error{Code: 500, Message: "Internal Server Error"},
+		expected: ErrorClassTransient,
+	}, {
+		name:     "Service unavailable",
+		err:      linodego.Error{Code: 503, Message: "Service Unavailable"},
+		expected: ErrorClassTransient,
+	}, {
+		name:     "Client error",
+		err:      linodego.Error{Code: linodego.ErrorFromError, Message: "connection refused"},
+		expected: ErrorClassTransient,
+	}}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testcase.expected, ClassifyError(testcase.err))
+			assert.Equal(t, testcase.expected == ErrorClassTerminal, IsTerminalError(testcase.err))
+		})
+	}
+}
+
+func TestFailureReason(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name                  string
+		err                   error
+		expectedMachineReason *cerrs.MachineStatusError
+		expectedClusterReason *cerrs.ClusterStatusError
+	}{{
+		name: "Transient",
+		err:  linodego.Error{Code: 503},
+	}, {
+		name: "Credentials",
+		err:  linodego.Error{Code: 403},
+	}, {
+		name:                  "Invalid request",
+		err:                   linodego.Error{Code: 400, Message: "[type] Invalid type"},
+		expectedMachineReason: Pointer(cerrs.InvalidConfigurationMachineError),
+		expectedClusterReason: Pointer(cerrs.InvalidConfigurationClusterError),
+	}, {
+		name:                  "Other terminal error",
+		err:                   linodego.Error{Code: 404},
+		expectedMachineReason: Pointer(cerrs.CreateMachineError),
+		expectedClusterReason: Pointer(cerrs.CreateClusterError),
+	}}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testcase.expectedMachineReason, MachineFailureReason(testcase.err, cerrs.CreateMachineError))
+			assert.Equal(t, testcase.expectedClusterReason, ClusterFailureReason(testcase.err, cerrs.CreateClusterError))
+		})
+	}
+}
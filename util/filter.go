@@ -1,7 +1,10 @@
 package util
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"maps"
 	"strconv"
 	"strings"
@@ -46,3 +49,223 @@ func (f Filter) String() (string, error) {
 
 	return string(p), nil
 }
+
+// FilterOperator compares a field to a value in a [FilterExpr].
+type FilterOperator string
+
+const (
+	FilterOpEq       FilterOperator = ""          // The field is equal to the value, or a list field contains it.
+	FilterOpNeq      FilterOperator = "+neq"      // The field is not equal to the value.
+	FilterOpContains FilterOperator = "+contains" // The field contains the value as a substring.
+	FilterOpGt       FilterOperator = "+gt"       // The field is greater than the value.
+	FilterOpGte      FilterOperator = "+gte"      // The field is greater than or equal to the value.
+	FilterOpLt       FilterOperator = "+lt"       // The field is less than the value.
+	FilterOpLte      FilterOperator = "+lte"      // The field is less than or equal to the value.
+)
+
+// SortOrder is the order of the results of a [FilterExpr] sorted with [FilterExpr.OrderBy].
+type SortOrder string
+
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+// FilterExpr is an expression of the Linode API filter language, built with [FilterEq], [FilterAnd], etc.
+// Unlike [Filter], it can match several fields at once, e.g. a label and a tag:
+//
+//	util.FilterAnd(util.FilterEq("label", "prod"), util.FilterEq("tags", "capl"))
+//
+// See https://techdocs.akamai.com/linode-api/reference/filtering-and-sorting.
+type FilterExpr struct {
+	fields  map[string]map[FilterOperator]any
+	and     []FilterExpr
+	or      []FilterExpr
+	orderBy string
+	order   SortOrder
+}
+
+// FilterEq matches the resources whose field is equal to value. For list fields such as tags, it matches the
+// resources whose field contains value.
+func FilterEq(field string, value any) FilterExpr {
+	return FilterCompare(field, FilterOpEq, value)
+}
+
+// FilterNeq matches the resources whose field is not equal to value.
+func FilterNeq(field string, value any) FilterExpr {
+	return FilterCompare(field, FilterOpNeq, value)
+}
+
+// FilterContains matches the resources whose field contains substr.
+func FilterContains(field, substr string) FilterExpr {
+	return FilterCompare(field, FilterOpContains, substr)
+}
+
+// FilterGt matches the resources whose field is greater than value.
+func FilterGt(field string, value any) FilterExpr {
+	return FilterCompare(field, FilterOpGt, value)
+}
+
+// FilterGte matches the resources whose field is greater than or equal to value.
+func FilterGte(field string, value any) FilterExpr {
+	return FilterCompare(field, FilterOpGte, value)
+}
+
+// FilterLt matches the resources whose field is less than value.
+func FilterLt(field string, value any) FilterExpr {
+	return FilterCompare(field, FilterOpLt, value)
+}
+
+// FilterLte matches the resources whose field is less than or equal to value.
+func FilterLte(field string, value any) FilterExpr {
+	return FilterCompare(field, FilterOpLte, value)
+}
+
+// FilterCompare matches the resources whose field compares to value with op.
+func FilterCompare(field string, op FilterOperator, value any) FilterExpr {
+	return FilterExpr{fields: map[string]map[FilterOperator]any{field: {op: value}}}
+}
+
+// FilterAnd matches the resources matching all exprs.
+func FilterAnd(exprs ...FilterExpr) FilterExpr {
+	return FilterExpr{and: append([]FilterExpr{}, exprs...)}
+}
+
+// FilterOr matches the resources matching any of exprs.
+func FilterOr(exprs ...FilterExpr) FilterExpr {
+	return FilterExpr{or: append([]FilterExpr{}, exprs...)}
+}
+
+// OrderBy returns a copy of e sorting the results by field. It is only honored on the outermost expression.
+func (e FilterExpr) OrderBy(field string, order SortOrder) FilterExpr {
+	e.orderBy = field
+	e.order = order
+
+	return e
+}
+
+// MarshalJSON returns the JSON-encoded representation of a [FilterExpr], as expected by the X-Filter header.
+func (e FilterExpr) MarshalJSON() ([]byte, error) {
+	filter := make(map[string]any, len(e.fields)+4)
+	for field, ops := range e.fields {
+		if field == "" || strings.HasPrefix(field, "+") {
+			return nil, fmt.Errorf("invalid filter field %q", field)
+		}
+		for op := range ops {
+			if !isFilterOperator(op) {
+				return nil, fmt.Errorf("unsupported filter operator %q", op)
+			}
+		}
+		if value, ok := ops[FilterOpEq]; ok {
+			if len(ops) > 1 {
+				return nil, fmt.Errorf("filter field %q combines a value with operators", field)
+			}
+			filter[field] = value
+
+			continue
+		}
+		filter[field] = ops
+	}
+	if e.and != nil {
+		filter["+and"] = e.and
+	}
+	if e.or != nil {
+		filter["+or"] = e.or
+	}
+	if e.orderBy != "" {
+		filter["+order_by"] = e.orderBy
+	}
+	if e.order != "" {
+		filter["+order"] = e.order
+	}
+
+	return json.Marshal(filter)
+}
+
+// UnmarshalJSON parses a Linode API filter expression into a [FilterExpr]. Numbers are kept as [json.Number] so
+// that they are encoded again unchanged.
+func (e *FilterExpr) UnmarshalJSON(data []byte) error {
+	var filter map[string]json.RawMessage
+	if err := json.Unmarshal(data, &filter); err != nil {
+		return err
+	}
+
+	*e = FilterExpr{}
+	for key, raw := range filter {
+		var err error
+		switch key {
+		case "+and":
+			err = json.Unmarshal(raw, &e.and)
+		case "+or":
+			err = json.Unmarshal(raw, &e.or)
+		case "+order_by":
+			err = json.Unmarshal(raw, &e.orderBy)
+		case "+order":
+			err = json.Unmarshal(raw, &e.order)
+			if err == nil && e.order != SortAscending && e.order != SortDescending {
+				err = fmt.Errorf("unsupported sort order %q", e.order)
+			}
+		default:
+			err = e.unmarshalField(key, raw)
+		}
+		if err != nil {
+			return fmt.Errorf("filter %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func (e *FilterExpr) unmarshalField(field string, raw json.RawMessage) error {
+	if strings.HasPrefix(field, "+") {
+		return errors.New("unsupported filter operator")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+
+	if e.fields == nil {
+		e.fields = make(map[string]map[FilterOperator]any)
+	}
+	values, ok := value.(map[string]any)
+	if !ok {
+		e.fields[field] = map[FilterOperator]any{FilterOpEq: value}
+
+		return nil
+	}
+
+	ops := make(map[FilterOperator]any, len(values))
+	for op, operand := range values {
+		if op == "" || !isFilterOperator(FilterOperator(op)) {
+			return fmt.Errorf("unsupported filter operator %q", op)
+		}
+		ops[FilterOperator(op)] = operand
+	}
+	e.fields[field] = ops
+
+	return nil
+}
+
+// String returns the string representation of the encoded value from
+// [FilterExpr.MarshalJSON].
+func (e FilterExpr) String() (string, error) {
+	p, err := e.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+
+	return string(p), nil
+}
+
+func isFilterOperator(op FilterOperator) bool {
+	switch op {
+	case FilterOpEq, FilterOpNeq, FilterOpContains, FilterOpGt, FilterOpGte, FilterOpLt, FilterOpLte:
+		return true
+	default:
+		return false
+	}
+}
@@ -1,6 +1,12 @@
 package util
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
 
 func TestString(t *testing.T) {
 	t.Parallel()
@@ -61,3 +67,105 @@ func TestString(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterExprString(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		filter    FilterExpr
+		expected  string
+		expectErr bool
+	}{{
+		name:     "empty",
+		filter:   FilterExpr{},
+		expected: `{}`,
+	}, {
+		name:     "equal",
+		filter:   FilterEq("label", "prod"),
+		expected: `{"label":"prod"}`,
+	}, {
+		name:     "label and tag",
+		filter:   FilterAnd(FilterEq("label", "prod"), FilterEq("tags", "capl")),
+		expected: `{"+and":[{"label":"prod"},{"tags":"capl"}]}`,
+	}, {
+		name:     "or",
+		filter:   FilterOr(FilterEq("id", 1), FilterEq("id", 2)),
+		expected: `{"+or":[{"id":1},{"id":2}]}`,
+	}, {
+		name:     "operators",
+		filter:   FilterAnd(FilterNeq("status", "offline"), FilterContains("label", "worker"), FilterGt("vcpus", 1), FilterGte("memory", 2048), FilterLt("disk", 81920), FilterLte("transfer", 2000)),
+		expected: `{"+and":[{"status":{"+neq":"offline"}},{"label":{"+contains":"worker"}},{"vcpus":{"+gt":1}},{"memory":{"+gte":2048}},{"disk":{"+lt":81920}},{"transfer":{"+lte":2000}}]}`,
+	}, {
+		name:     "order by",
+		filter:   FilterEq("tags", "capl").OrderBy("created", SortDescending),
+		expected: `{"+order":"desc","+order_by":"created","tags":"capl"}`,
+	}, {
+		name:      "invalid field",
+		filter:    FilterEq("+and", "prod"),
+		expectErr: true,
+	}, {
+		name:      "invalid operator",
+		filter:    FilterCompare("vcpus", "+between", 1),
+		expectErr: true,
+	}}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			filter, err := testcase.filter.String()
+			if testcase.expectErr {
+				require.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, testcase.expected, filter)
+		})
+	}
+}
+
+func TestFilterExprRoundTrip(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		filter    string
+		expectErr bool
+	}{
+		{name: "equal", filter: `{"label":"prod"}`},
+		{name: "implicit and", filter: `{"vcpus":1,"class":"standard"}`},
+		{name: "and", filter: `{"+and":[{"vcpus":1},{"class":"standard"}]}`},
+		{name: "or", filter: `{"+or":[{"vcpus":1},{"class":"standard"}]}`},
+		{name: "nested", filter: `{"+and":[{"label":"prod"},{"+or":[{"tags":"capl-uid=1234"},{"tags":"prod"}]}]}`},
+		{name: "contains", filter: `{"label":{"+contains":"linode"}}`},
+		{name: "range", filter: `{"vcpus":{"+gte":2,"+lte":8}}`},
+		{name: "not equal", filter: `{"class":{"+neq":"standard"}}`},
+		{name: "greater than", filter: `{"memory":{"+gt":2048}}`},
+		{name: "less than", filter: `{"price.monthly":{"+lt":10.5}}`},
+		{name: "boolean", filter: `{"mine":true}`},
+		{name: "large number", filter: `{"id":12345678901234567890}`},
+		{name: "order by", filter: `{"class":"standard","+order_by":"vcpus","+order":"asc"}`},
+		{name: "unsupported operator", filter: `{"vcpus":{"+between":[1,2]}}`, expectErr: true},
+		{name: "unsupported logical operator", filter: `{"+not":[{"vcpus":1}]}`, expectErr: true},
+		{name: "unsupported order", filter: `{"+order_by":"vcpus","+order":"up"}`, expectErr: true},
+		{name: "invalid and", filter: `{"+and":{"vcpus":1}}`, expectErr: true},
+		{name: "invalid JSON", filter: `{`, expectErr: true},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			var filter FilterExpr
+			err := json.Unmarshal([]byte(testcase.filter), &filter)
+			if testcase.expectErr {
+				require.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+
+			encoded, err := filter.String()
+			require.NoError(t, err)
+			assert.JSONEq(t, testcase.filter, encoded)
+		})
+	}
+}
@@ -32,9 +32,13 @@ const (
 	DefaultMachineControllerLinodeImage = "linode/ubuntu22.04"
 	// DefaultMachineControllerWaitForRunningDelay is the default requeue delay if instance is not running.
 	DefaultMachineControllerWaitForRunningDelay = 5 * time.Second
+	// DefaultMachineControllerWaitForEventDelay is the default requeue delay for an instance operation when the
+	// LinodeMachine is also enqueued by the Linode account events.
+	DefaultMachineControllerWaitForEventDelay = 1 * time.Minute
 	// DefaultMachineControllerWaitForPreflightTimeout is the default timeout during the preflight phase.
 	DefaultMachineControllerWaitForPreflightTimeout = 5 * time.Minute
-	// DefaultMachineControllerWaitForRunningTimeout is the default timeout if instance is not running.
+	// DefaultMachineControllerWaitForRunningTimeout is the default timeout if instance is not running, when the
+	// LinodeMachine is not enqueued by the Linode account events.
 	DefaultMachineControllerWaitForRunningTimeout = 20 * time.Minute
 
 	// DefaultVPCControllerReconcileDelay is the default requeue delay when a reconcile operation fails.
@@ -45,6 +49,8 @@ const (
 	DefaultVPCControllerWaitForHasNodesDelay = 5 * time.Second
 	// DefaultVPCControllerWaitForHasNodesTimeout is the default timeout if a VPC still has nodes.
 	DefaultVPCControllerWaitForHasNodesTimeout = 20 * time.Minute
+	// DefaultVPCControllerRefreshInterval is the default interval between two refreshes of the status of a ready VPC.
+	DefaultVPCControllerRefreshInterval = 5 * time.Minute
 
 	// DefaultClusterControllerReconcileDelay is the default requeue delay when a reconcile operation fails.
 	DefaultClusterControllerReconcileDelay = 5 * time.Second
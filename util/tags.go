@@ -0,0 +1,165 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+)
+
+const (
+	// ManagedTag is added to the Linode resources created by CAPL, so that resources leaked by a crashed reconcile or
+	// a force-deleted object can be found.
+	ManagedTag = "capl"
+
+	// ManagedVPCDescription is the description of the VPCs created by CAPL without one, since VPCs can't be tagged.
+	ManagedVPCDescription = "Managed by Cluster API Provider Linode"
+	// RetainedVPCDescription replaces ManagedVPCDescription on the VPCs retained when their LinodeVPC is deleted, so
+	// that they are not reported as orphans.
+	RetainedVPCDescription = "Retained by Cluster API Provider Linode"
+
+	// ManagementClusterTagPrefix prefixes the tag holding the ID of the management cluster owning a resource.
+	ManagementClusterTagPrefix = "capl-mc="
+	// NamespaceTagPrefix prefixes the tag holding the namespace of the LinodeCluster owning a resource.
+	NamespaceTagPrefix = "capl-ns="
+	// ClusterNameTagPrefix prefixes the tag holding the name of the LinodeCluster owning a resource.
+	ClusterNameTagPrefix = "capl-cluster="
+	// ClusterUIDTagPrefix prefixes the tag holding the UID of the LinodeCluster owning a resource.
+	ClusterUIDTagPrefix = "capl-uid="
+
+	// TagLabelPrefix prefixes the labels of a Cluster which are added as tags to its Linode resources, e.g. the
+	// label tags.infrastructure.cluster.x-k8s.io/env: prod adds the tag env=prod.
+	TagLabelPrefix = "tags.infrastructure.cluster.x-k8s.io/"
+
+	// minTagLength is the minimum length of a tag accepted by the Linode API.
+	minTagLength = 3
+	// maxTagLength is the maximum length of a tag accepted by the Linode API.
+	maxTagLength = 50
+	// tagHashLength is the length of the hash replacing the end of tags which are too long.
+	tagHashLength = 8
+)
+
+// ownershipTagPrefixes are the prefixes of the tags set by OwnershipTags.
+var ownershipTagPrefixes = []string{
+	ManagementClusterTagPrefix,
+	NamespaceTagPrefix,
+	ClusterNameTagPrefix,
+	ClusterUIDTagPrefix,
+}
+
+// OwnershipTags returns the tags identifying the Linode resources of a LinodeCluster across namespaces and
+// management clusters. The management cluster tag is omitted when its ID is unknown.
+func OwnershipTags(managementClusterID, namespace, name, uid string) []string {
+	tags := []string{ManagedTag}
+	if managementClusterID != "" {
+		tags = append(tags, ManagementClusterTag(managementClusterID))
+	}
+
+	return append(tags,
+		NamespaceTag(namespace),
+		truncateTag(ClusterNameTagPrefix+name),
+		ClusterUIDTag(uid),
+	)
+}
+
+// ManagementClusterTag returns the tag identifying the Linode resources of the given management cluster.
+func ManagementClusterTag(managementClusterID string) string {
+	return truncateTag(ManagementClusterTagPrefix + managementClusterID)
+}
+
+// NamespaceTag returns the tag identifying the Linode resources of the LinodeClusters in the given namespace.
+func NamespaceTag(namespace string) string {
+	return truncateTag(NamespaceTagPrefix + namespace)
+}
+
+// ClusterUIDTag returns the tag identifying the Linode resources of the LinodeCluster with the given UID.
+func ClusterUIDTag(uid string) string {
+	return truncateTag(ClusterUIDTagPrefix + uid)
+}
+
+// LabelTags returns the tags for the labels prefixed with TagLabelPrefix, sorted. A label with an empty value
+// adds a tag with the label name only, unless it is shorter than the Linode API allows.
+func LabelTags(labels map[string]string) []string {
+	var tags []string
+	for key, value := range labels {
+		name, ok := strings.CutPrefix(key, TagLabelPrefix)
+		if !ok || name == "" {
+			continue
+		}
+		if value != "" {
+			name += "=" + value
+		}
+		if len(name) < minTagLength {
+			continue
+		}
+		tags = append(tags, truncateTag(name))
+	}
+	slices.Sort(tags)
+
+	return tags
+}
+
+// TagValue returns the value of the tag with the given prefix, e.g. the cluster UID for ClusterUIDTagPrefix.
+func TagValue(tags []string, prefix string) (string, bool) {
+	for _, tag := range tags {
+		if value, ok := strings.CutPrefix(tag, prefix); ok {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// IsOwnedBy returns true if tags identify the LinodeCluster with the given UID as the owner of a resource. Resources
+// tagged before the ownership tags were introduced are identified by legacyTag instead, but only when bound, e.g. when
+// their ID is already set on the object, since resources of other clusters may carry the same legacy tag.
+func IsOwnedBy(tags []string, uid, legacyTag string, bound bool) bool {
+	if owner, ok := TagValue(tags, ClusterUIDTagPrefix); ok {
+		return owner == uid
+	}
+
+	return bound && slices.Contains(tags, legacyTag)
+}
+
+// MergeTags returns tags with the missing desired tags added, and whether tags had to be changed. The ownership
+// tags of tags are replaced by the desired ones, e.g. after the LinodeCluster was moved to another management
+// cluster, while all other tags are kept.
+func MergeTags(tags, desired []string) ([]string, bool) {
+	merged := make([]string, 0, len(tags)+len(desired))
+	for _, tag := range tags {
+		if isOwnershipTag(tag) && !slices.Contains(desired, tag) {
+			continue
+		}
+		merged = append(merged, tag)
+	}
+	for _, tag := range desired {
+		if !slices.Contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+
+	changed := len(merged) != len(tags)
+	for i := 0; !changed && i < len(tags); i++ {
+		changed = merged[i] != tags[i]
+	}
+
+	return merged, changed
+}
+
+func isOwnershipTag(tag string) bool {
+	return slices.ContainsFunc(ownershipTagPrefixes, func(prefix string) bool {
+		return strings.HasPrefix(tag, prefix)
+	})
+}
+
+// truncateTag shortens tags longer than the Linode API allows, replacing their end with a hash of the full tag so
+// that they stay unique.
+func truncateTag(tag string) string {
+	if len(tag) <= maxTagLength {
+		return tag
+	}
+
+	hash := sha256.Sum256([]byte(tag))
+
+	return tag[:maxTagLength-tagHashLength-1] + "-" + hex.EncodeToString(hash[:])[:tagHashLength]
+}
@@ -0,0 +1,143 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnershipTags(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name                string
+		managementClusterID string
+		namespace           string
+		clusterName         string
+		uid                 string
+		expected            []string
+	}{{
+		name:                "Management cluster",
+		managementClusterID: "mc-uid",
+		namespace:           "default",
+		clusterName:         "prod",
+		uid:                 "cluster-uid",
+		expected:            []string{"capl", "capl-mc=mc-uid", "capl-ns=default", "capl-cluster=prod", "capl-uid=cluster-uid"},
+	}, {
+		name:        "Unknown management cluster",
+		namespace:   "default",
+		clusterName: "prod",
+		uid:         "cluster-uid",
+		expected:    []string{"capl", "capl-ns=default", "capl-cluster=prod", "capl-uid=cluster-uid"},
+	}, {
+		name:        "Long names",
+		namespace:   strings.Repeat("n", 63),
+		clusterName: strings.Repeat("c", 63),
+		uid:         "cluster-uid",
+		expected: []string{
+			"capl",
+			"capl-ns=" + strings.Repeat("n", 33) + "-2f76636e",
+			"capl-cluster=" + strings.Repeat("c", 28) + "-06d63f4c",
+			"capl-uid=cluster-uid",
+		},
+	}}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			tags := OwnershipTags(testcase.managementClusterID, testcase.namespace, testcase.clusterName, testcase.uid)
+			assert.Equal(t, testcase.expected, tags)
+			for _, tag := range tags {
+				assert.LessOrEqual(t, len(tag), maxTagLength)
+			}
+		})
+	}
+}
+
+func TestLabelTags(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, []string{"a=b", "env=prod", "team"}, LabelTags(map[string]string{
+		TagLabelPrefix + "team":                 "",
+		TagLabelPrefix + "env":                  "prod",
+		TagLabelPrefix + "a":                    "b",
+		TagLabelPrefix + "ab":                   "",
+		TagLabelPrefix:                          "ignored",
+		"cluster.x-k8s.io/cluster-name":         "prod",
+		"example.com/" + TagLabelPrefix + "foo": "bar",
+	}))
+	assert.Empty(t, LabelTags(nil))
+}
+
+func TestIsOwnedBy(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		tags     []string
+		bound    bool
+		expected bool
+	}{{
+		name:     "Owner",
+		tags:     []string{"capl", "capl-uid=cluster-uid"},
+		expected: true,
+	}, {
+		name: "Other owner",
+		tags: []string{"capl", "capl-uid=other-uid", "prod"},
+	}, {
+		name:  "Other owner of a bound resource",
+		tags:  []string{"capl", "capl-uid=other-uid", "prod"},
+		bound: true,
+	}, {
+		name:     "Legacy owner of a bound resource",
+		tags:     []string{"prod"},
+		bound:    true,
+		expected: true,
+	}, {
+		name: "Legacy owner of an unbound resource",
+		tags: []string{"prod"},
+	}, {
+		name:  "No owner",
+		tags:  []string{"staging"},
+		bound: true,
+	}}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testcase.expected, IsOwnedBy(testcase.tags, "cluster-uid", "prod", testcase.bound))
+		})
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	t.Parallel()
+	desired := []string{"capl", "capl-mc=mc-uid", "capl-uid=cluster-uid", "env=prod"}
+	tests := []struct {
+		name            string
+		tags            []string
+		expected        []string
+		expectedChanged bool
+	}{{
+		name:     "Up to date",
+		tags:     []string{"capl", "capl-mc=mc-uid", "capl-uid=cluster-uid", "env=prod", "custom"},
+		expected: []string{"capl", "capl-mc=mc-uid", "capl-uid=cluster-uid", "env=prod", "custom"},
+	}, {
+		name:            "Legacy",
+		tags:            []string{"prod"},
+		expected:        []string{"prod", "capl", "capl-mc=mc-uid", "capl-uid=cluster-uid", "env=prod"},
+		expectedChanged: true,
+	}, {
+		name:            "Moved",
+		tags:            []string{"capl", "capl-mc=old-uid", "capl-uid=old-cluster-uid", "env=prod"},
+		expected:        []string{"capl", "env=prod", "capl-mc=mc-uid", "capl-uid=cluster-uid"},
+		expectedChanged: true,
+	}}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			tags, changed := MergeTags(testcase.tags, desired)
+			assert.Equal(t, testcase.expected, tags)
+			assert.Equal(t, testcase.expectedChanged, changed)
+		})
+	}
+}